@@ -19,23 +19,41 @@ const (
 	HatchState  = types.HatchState
 	OpenState   = types.OpenState
 	SettleState = types.SettleState
+	PausedState = types.PausedState
+	FailedState = types.FailedState
+
+	PausedBySigners    = types.PausedBySigners
+	PausedByGovernance = types.PausedByGovernance
+
+	NoAllocation        = types.NoAllocation
+	ProRataAllocation   = types.ProRataAllocation
+	FirstComeAllocation = types.FirstComeAllocation
 
 	DoNotModifyField = types.DoNotModifyField
 
 	AnyNumberOfReserveTokens = types.AnyNumberOfReserveTokens
 
+	MaxSwapRouteHops = types.MaxSwapRouteHops
+
 	DefaultCodespace = types.DefaultCodespace
 
 	ModuleName        = types.ModuleName
 	DefaultParamspace = types.DefaultParamspace
 	StoreKey          = types.StoreKey
 
-	BondsMintBurnAccount       = types.BondsMintBurnAccount
-	BatchesIntermediaryAccount = types.BatchesIntermediaryAccount
-	BondsReserveAccount        = types.BondsReserveAccount
+	BondsMintBurnAccount             = types.BondsMintBurnAccount
+	BatchesIntermediaryAccount       = types.BatchesIntermediaryAccount
+	BondsReserveAccount              = types.BondsReserveAccount
+	BondsHatchEscrowAccount          = types.BondsHatchEscrowAccount
+	BondsFundingPoolAccount          = types.BondsFundingPoolAccount
+	BondsVestingEscrowAccount        = types.BondsVestingEscrowAccount
+	BondsOutcomePaymentEscrowAccount = types.BondsOutcomePaymentEscrowAccount
 
 	QuerierRoute = types.QuerierRoute
 	RouterKey    = types.RouterKey
+
+	ProposalTypePauseBond  = types.ProposalTypePauseBond
+	ProposalTypeResumeBond = types.ProposalTypeResumeBond
 )
 
 var (
@@ -49,15 +67,34 @@ var (
 	SupplyInvariant    = keeper.SupplyInvariant
 	ReserveInvariant   = keeper.ReserveInvariant
 
+	ReserveAccountsInvariant = keeper.ReserveAccountsInvariant
+	BatchesAccountInvariant  = keeper.BatchesAccountInvariant
+	SwapperInvariant         = keeper.SwapperInvariant
+	AugmentedInvariant       = keeper.AugmentedInvariant
+
 	RegisterCodec = types.RegisterCodec
 
-	NewBatch         = types.NewBatch
-	NewBaseOrder     = types.NewBaseOrder
-	NewBuyOrder      = types.NewBuyOrder
-	NewSellOrder     = types.NewSellOrder
-	NewSwapOrder     = types.NewSwapOrder
-	NewFunctionParam = types.NewFunctionParam
-	NewBond          = types.NewBond
+	NewBatch                      = types.NewBatch
+	NewSettledBatch               = types.NewSettledBatch
+	NewCandle                     = types.NewCandle
+	GetCandles                    = types.GetCandles
+	NewPosition                   = types.NewPosition
+	NewVesting                    = types.NewVesting
+	NewOutcomePaymentContribution = types.NewOutcomePaymentContribution
+	NewBaseOrder                  = types.NewBaseOrder
+	NewBuyOrder                   = types.NewBuyOrder
+	NewPartialBuyOrder            = types.NewPartialBuyOrder
+	NewBuyWithReserveOrder        = types.NewBuyWithReserveOrder
+	NewSellOrder                  = types.NewSellOrder
+	NewSwapOrder                  = types.NewSwapOrder
+	NewSwapRouteOrder             = types.NewSwapRouteOrder
+	NewAddLiquiditySwapOrder      = types.NewAddLiquiditySwapOrder
+	NewAddLiquidityOrder          = types.NewAddLiquidityOrder
+	NewRemoveLiquidityOrder       = types.NewRemoveLiquidityOrder
+	NewLimitBuyOrder              = types.NewLimitBuyOrder
+	NewLimitSellOrder             = types.NewLimitSellOrder
+	NewFunctionParam              = types.NewFunctionParam
+	NewBond                       = types.NewBond
 
 	RoundReservePrice     = types.RoundReservePrice
 	RoundReserveReturn    = types.RoundReserveReturn
@@ -76,17 +113,54 @@ var (
 	ValidateGenesis     = types.ValidateGenesis
 	DefaultGenesisState = types.DefaultGenesisState
 
-	GetBondKey      = types.GetBondKey
-	GetBatchKey     = types.GetBatchKey
-	GetLastBatchKey = types.GetLastBatchKey
+	GetBondKey                       = types.GetBondKey
+	GetBatchKey                      = types.GetBatchKey
+	GetBatchOrderKey                 = types.GetBatchOrderKey
+	GetLastBatchKey                  = types.GetLastBatchKey
+	GetBatchHistoryKey               = types.GetBatchHistoryKey
+	GetPositionKey                   = types.GetPositionKey
+	GetLimitOrderKey                 = types.GetLimitOrderKey
+	GetVestingKey                    = types.GetVestingKey
+	GetOutcomePaymentContributionKey = types.GetOutcomePaymentContributionKey
+
+	GetReserveAddress              = types.GetReserveAddress
+	GetHatchEscrowAddress          = types.GetHatchEscrowAddress
+	GetFundingPoolAddress          = types.GetFundingPoolAddress
+	GetVestingEscrowAddress        = types.GetVestingEscrowAddress
+	GetOutcomePaymentEscrowAddress = types.GetOutcomePaymentEscrowAddress
+	GetSwapperProductKey           = types.GetSwapperProductKey
 
-	NewMsgCreateBond         = types.NewMsgCreateBond
-	NewMsgEditBond           = types.NewMsgEditBond
-	NewMsgBuy                = types.NewMsgBuy
-	NewMsgSell               = types.NewMsgSell
-	NewMsgSwap               = types.NewMsgSwap
-	NewMsgMakeOutcomePayment = types.NewMsgMakeOutcomePayment
-	NewMsgWithdrawShare      = types.NewMsgWithdrawShare
+	GetBatchScheduleKey          = types.GetBatchScheduleKey
+	GetBatchScheduleHeightPrefix = types.GetBatchScheduleHeightPrefix
+
+	GetHatchDeadlineKey           = types.GetHatchDeadlineKey
+	GetHatchDeadlinesHeightPrefix = types.GetHatchDeadlinesHeightPrefix
+
+	NewMsgCreateBond             = types.NewMsgCreateBond
+	NewMsgEditBond               = types.NewMsgEditBond
+	NewMsgBuy                    = types.NewMsgBuy
+	NewMsgBuyWithReserve         = types.NewMsgBuyWithReserve
+	NewMsgSell                   = types.NewMsgSell
+	NewMsgSwap                   = types.NewMsgSwap
+	NewMsgSwapRoute              = types.NewMsgSwapRoute
+	NewMsgAddLiquidity           = types.NewMsgAddLiquidity
+	NewMsgRemoveLiquidity        = types.NewMsgRemoveLiquidity
+	NewMsgCancelOrder            = types.NewMsgCancelOrder
+	NewMsgLimitBuy               = types.NewMsgLimitBuy
+	NewMsgLimitSell              = types.NewMsgLimitSell
+	NewMsgCancelLimitOrder       = types.NewMsgCancelLimitOrder
+	NewMsgMakeOutcomePayment     = types.NewMsgMakeOutcomePayment
+	NewMsgFinaliseOutcomePayment = types.NewMsgFinaliseOutcomePayment
+	NewMsgWithdrawShare          = types.NewMsgWithdrawShare
+	NewMsgWithdrawVested         = types.NewMsgWithdrawVested
+	NewMsgPauseBond              = types.NewMsgPauseBond
+	NewMsgResumeBond             = types.NewMsgResumeBond
+	NewMsgCloseBond              = types.NewMsgCloseBond
+	NewMsgWithdrawFunding        = types.NewMsgWithdrawFunding
+	NewMsgSetFundingTap          = types.NewMsgSetFundingTap
+
+	NewPauseBondProposal  = types.NewPauseBondProposal
+	NewResumeBondProposal = types.NewResumeBondProposal
 
 	ParseFunctionParams = client.ParseFunctionParams
 	ParseSigners        = client.ParseSigners
@@ -139,20 +213,65 @@ var (
 	ErrInvalidFunctionParameter             = types.ErrInvalidFunctionParameter
 	ErrArgumentMissingOrNonUInteger         = types.ErrArgumentMissingOrNonUInteger
 	ErrArgumentMissingOrNonBoolean          = types.ErrArgumentMissingOrNonBoolean
+	ErrReservedBondToken                    = types.ErrReservedBondToken
+	ErrMinReturnsNotMet                     = types.ErrMinReturnsNotMet
+	ErrMinOutputNotMet                      = types.ErrMinOutputNotMet
+	ErrOrderDoesNotExist                    = types.ErrOrderDoesNotExist
+	ErrOrderAlreadyCancelled                = types.ErrOrderAlreadyCancelled
+	ErrUnrecognizedOrderType                = types.ErrUnrecognizedOrderType
+	ErrUnrecognizedMaxSupplyAllocation      = types.ErrUnrecognizedMaxSupplyAllocation
+	ErrBondSoldOut                          = types.ErrBondSoldOut
+	ErrExpiryHeightAlreadyReached           = types.ErrExpiryHeightAlreadyReached
+	ErrInvalidSwapRoute                     = types.ErrInvalidSwapRoute
+	ErrNoSwapRouteFound                     = types.ErrNoSwapRouteFound
+	ErrMinAmountNotMet                      = types.ErrMinAmountNotMet
+	ErrHatchDeadlineAlreadyReached          = types.ErrHatchDeadlineAlreadyReached
+	ErrMinRaiseRequiresHatchDeadline        = types.ErrMinRaiseRequiresHatchDeadline
+	ErrInsufficientVestedTokens             = types.ErrInsufficientVestedTokens
+	ErrBondHasNoFundingPool                 = types.ErrBondHasNoFundingPool
+	ErrFundingTapExceeded                   = types.ErrFundingTapExceeded
+	ErrOutcomePaymentExceeded               = types.ErrOutcomePaymentExceeded
+	ErrNoOutcomePaymentMade                 = types.ErrNoOutcomePaymentMade
 
-	BondsKeyPrefix       = types.BondsKeyPrefix
-	BatchesKeyPrefix     = types.BatchesKeyPrefix
-	LastBatchesKeyPrefix = types.LastBatchesKeyPrefix
+	BondsKeyPrefix                       = types.BondsKeyPrefix
+	BatchesKeyPrefix                     = types.BatchesKeyPrefix
+	LastBatchesKeyPrefix                 = types.LastBatchesKeyPrefix
+	BatchesHistoryKeyPrefix              = types.BatchesHistoryKeyPrefix
+	PositionsKeyPrefix                   = types.PositionsKeyPrefix
+	SwapperProductsKeyPrefix             = types.SwapperProductsKeyPrefix
+	BatchScheduleKeyPrefix               = types.BatchScheduleKeyPrefix
+	LimitOrdersKeyPrefix                 = types.LimitOrdersKeyPrefix
+	HatchDeadlinesKeyPrefix              = types.HatchDeadlinesKeyPrefix
+	VestingsKeyPrefix                    = types.VestingsKeyPrefix
+	OutcomePaymentContributionsKeyPrefix = types.OutcomePaymentContributionsKeyPrefix
+
+	BuyOrdersKeyPrefix             = types.BuyOrdersKeyPrefix
+	SellOrdersKeyPrefix            = types.SellOrdersKeyPrefix
+	SwapOrdersKeyPrefix            = types.SwapOrdersKeyPrefix
+	BuyWithReserveOrdersKeyPrefix  = types.BuyWithReserveOrdersKeyPrefix
+	AddLiquidityOrdersKeyPrefix    = types.AddLiquidityOrdersKeyPrefix
+	RemoveLiquidityOrdersKeyPrefix = types.RemoveLiquidityOrdersKeyPrefix
 )
 
 type (
 	Keeper = keeper.Keeper
 
-	Batch     = types.Batch
-	BaseOrder = types.BaseOrder
-	BuyOrder  = types.BuyOrder
-	SellOrder = types.SellOrder
-	SwapOrder = types.SwapOrder
+	Batch                      = types.Batch
+	SettledBatch               = types.SettledBatch
+	Candle                     = types.Candle
+	OHLC                       = types.OHLC
+	Position                   = types.Position
+	Vesting                    = types.Vesting
+	VestingEntry               = types.VestingEntry
+	OutcomePaymentContribution = types.OutcomePaymentContribution
+	BaseOrder                  = types.BaseOrder
+	BuyOrder                   = types.BuyOrder
+	BuyWithReserveOrder        = types.BuyWithReserveOrder
+	SellOrder                  = types.SellOrder
+	SwapOrder                  = types.SwapOrder
+	LimitOrder                 = types.LimitOrder
+	AddLiquidityOrder          = types.AddLiquidityOrder
+	RemoveLiquidityOrder       = types.RemoveLiquidityOrder
 
 	FunctionParamRestrictions = types.FunctionParamRestrictions
 	FunctionParam             = types.FunctionParam
@@ -162,11 +281,29 @@ type (
 
 	GenesisState = types.GenesisState
 
-	MsgCreateBond         = types.MsgCreateBond
-	MsgEditBond           = types.MsgEditBond
-	MsgBuy                = types.MsgBuy
-	MsgSell               = types.MsgSell
-	MsgSwap               = types.MsgSwap
-	MsgMakeOutcomePayment = types.MsgMakeOutcomePayment
-	MsgWithdrawShare      = types.MsgWithdrawShare
+	MsgCreateBond             = types.MsgCreateBond
+	MsgEditBond               = types.MsgEditBond
+	MsgBuy                    = types.MsgBuy
+	MsgBuyWithReserve         = types.MsgBuyWithReserve
+	MsgSell                   = types.MsgSell
+	MsgSwap                   = types.MsgSwap
+	MsgSwapRoute              = types.MsgSwapRoute
+	MsgAddLiquidity           = types.MsgAddLiquidity
+	MsgRemoveLiquidity        = types.MsgRemoveLiquidity
+	MsgCancelOrder            = types.MsgCancelOrder
+	MsgLimitBuy               = types.MsgLimitBuy
+	MsgLimitSell              = types.MsgLimitSell
+	MsgCancelLimitOrder       = types.MsgCancelLimitOrder
+	MsgMakeOutcomePayment     = types.MsgMakeOutcomePayment
+	MsgFinaliseOutcomePayment = types.MsgFinaliseOutcomePayment
+	MsgWithdrawShare          = types.MsgWithdrawShare
+	MsgWithdrawVested         = types.MsgWithdrawVested
+	MsgPauseBond              = types.MsgPauseBond
+	MsgResumeBond             = types.MsgResumeBond
+	MsgCloseBond              = types.MsgCloseBond
+	MsgWithdrawFunding        = types.MsgWithdrawFunding
+	MsgSetFundingTap          = types.MsgSetFundingTap
+
+	PauseBondProposal  = types.PauseBondProposal
+	ResumeBondProposal = types.ResumeBondProposal
 )
@@ -34,7 +34,17 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 )
 
-const appName = "Bonds"
+const (
+	appName = "Bonds"
+
+	// bondsReserveAccountsUpgrade is the name of the upgrade plan that moves
+	// bond reserves to per-bond reserve addresses
+	bondsReserveAccountsUpgrade = "bonds-reserve-accounts"
+
+	// bondsBatchOrdersUpgrade is the name of the upgrade plan that moves the
+	// orders of batches to their own keys
+	bondsBatchOrdersUpgrade = "bonds-batch-orders"
+)
 
 var (
 	// DefaultCLIHome represents the default home directory for the application CLI
@@ -53,6 +63,7 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			bonds.PauseBondProposalHandler, bonds.ResumeBondProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -216,16 +227,6 @@ func NewSimApp(
 	evidenceKeeper.SetRouter(evidenceRouter)
 	app.evidenceKeeper = *evidenceKeeper
 
-	// register the proposal types
-	govRouter := gov.NewRouter()
-	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
-		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
-	app.govKeeper = gov.NewKeeper(
-		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper, &stakingKeeper, govRouter,
-	)
-
 	// register the staking hooks
 	// NOTE: StakingKeeper above is passed by reference, so that it will contain these hooks
 	app.StakingKeeper = *stakingKeeper.SetHooks(
@@ -242,6 +243,34 @@ func NewSimApp(
 		app.cdc,
 	)
 
+	// register the upgrade handler that moves bond reserves out of the shared
+	// bonds reserve module account and into per-bond reserve addresses
+	app.upgradeKeeper.SetUpgradeHandler(bondsReserveAccountsUpgrade,
+		func(ctx sdk.Context, plan upgrade.Plan) {
+			err := app.BondsKeeper.MigrateReserveAccounts(ctx)
+			if err != nil {
+				panic(err)
+			}
+		})
+
+	// register the upgrade handler that moves the orders of batches out of the
+	// batches themselves and into their own keys
+	app.upgradeKeeper.SetUpgradeHandler(bondsBatchOrdersUpgrade,
+		func(ctx sdk.Context, plan upgrade.Plan) {
+			app.BondsKeeper.MigrateBatchOrders(ctx)
+		})
+
+	// register the proposal types
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(bonds.RouterKey, bonds.NewBondsProposalHandler(app.BondsKeeper))
+	app.govKeeper = gov.NewKeeper(
+		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper, &stakingKeeper, govRouter,
+	)
+
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
@@ -16,6 +16,7 @@ const (
 	FlagExitFeePercentage      = "exit-fee-percentage"
 	FlagFeeAddress             = "fee-address"
 	FlagMaxSupply              = "max-supply"
+	FlagMaxSupplyAllocation    = "max-supply-allocation"
 	FlagOrderQuantityLimits    = "order-quantity-limits"
 	FlagSanityRate             = "sanity-rate"
 	FlagSanityMarginPercentage = "sanity-margin-percentage"
@@ -23,12 +24,22 @@ const (
 	FlagSigners                = "signers"
 	FlagBatchBlocks            = "batch-blocks"
 	FlagOutcomePayment         = "outcome-payment"
+	FlagAllowPartial           = "allow-partial"
+	FlagExpiryHeight           = "expiry-height"
+	FlagHatchDeadline          = "hatch-deadline"
+	FlagMinRaise               = "min-raise"
+	FlagVestingCliff           = "vesting-cliff"
+	FlagVestingDuration        = "vesting-duration"
+	FlagFundingTap             = "funding-tap"
+	FlagFundingTapBlocks       = "funding-tap-blocks"
+	FlagFundingTaxPercentage   = "funding-tax-percentage"
 )
 
 var (
-	fsBondGeneral = flag.NewFlagSet("", flag.ContinueOnError)
-	fsBondCreate  = flag.NewFlagSet("", flag.ContinueOnError)
-	fsBondEdit    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsBondGeneral    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsBondCreate     = flag.NewFlagSet("", flag.ContinueOnError)
+	fsBondEdit       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsBondFundingTap = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -45,16 +56,27 @@ func init() {
 	fsBondCreate.String(FlagExitFeePercentage, "", "The percentage fee charged on sells")
 	fsBondCreate.String(FlagFeeAddress, "", "The address that will hold any charged fees")
 	fsBondCreate.String(FlagMaxSupply, "", "The maximum supply that can be achieved")
+	fsBondCreate.String(FlagMaxSupplyAllocation, types.NoAllocation, "How the remaining supply is allocated when buys exceed the max supply (none, pro_rata, first_come)")
 	fsBondCreate.String(FlagOrderQuantityLimits, "", "The max number of tokens bought/sold/swapped per order")
 	fsBondCreate.String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
 	fsBondCreate.String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
 	fsBondCreate.Bool(FlagAllowSells, false, "Whether or not sells will be allowed")
 	fsBondCreate.String(FlagBatchBlocks, "", "The duration in terms of blocks of each orders batch")
 	fsBondCreate.String(FlagOutcomePayment, "", "The payment that would be required to transition the bond to settlement")
+	fsBondCreate.Int64(FlagHatchDeadline, 0, "For augmented, the block height by which the hatch phase must end (zero for no deadline)")
+	fsBondCreate.String(FlagMinRaise, "", "For augmented, the min raise with which the hatch phase succeeds at the hatch deadline")
+	fsBondCreate.Int64(FlagVestingCliff, 0, "For augmented, the number of blocks before tokens bought in the hatch phase start vesting")
+	fsBondCreate.Int64(FlagVestingDuration, 0, "For augmented, the number of blocks over which tokens bought in the hatch phase vest after the cliff")
+	fsBondCreate.String(FlagFundingTap, "", "For augmented, the max amount that can be withdrawn from the funding pool per funding tap period")
+	fsBondCreate.Int64(FlagFundingTapBlocks, 0, "For augmented, the duration in terms of blocks of each funding tap period")
+	fsBondCreate.String(FlagFundingTaxPercentage, "0", "For augmented, the percentage charged on open-phase buys that goes to the funding pool")
 
 	fsBondEdit.String(FlagName, types.DoNotModifyField, "The bond's name")
 	fsBondEdit.String(FlagDescription, types.DoNotModifyField, "The bond's description")
 	fsBondEdit.String(FlagOrderQuantityLimits, types.DoNotModifyField, "The max number of tokens bought/sold/swapped per order")
 	fsBondEdit.String(FlagSanityRate, types.DoNotModifyField, "For swappers, this is the typical t1 per t2 rate")
 	fsBondEdit.String(FlagSanityMarginPercentage, types.DoNotModifyField, "For swappers, this is the acceptable deviation from the sanity rate")
+
+	fsBondFundingTap.String(FlagFundingTap, "", "The max amount that can be withdrawn from the funding pool per funding tap period")
+	fsBondFundingTap.Int64(FlagFundingTapBlocks, 0, "The duration in terms of blocks of each funding tap period")
 }
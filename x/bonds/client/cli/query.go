@@ -11,7 +11,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
@@ -28,12 +30,25 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdBond(storeKey, cdc),
 		GetCmdBatch(storeKey, cdc),
 		GetCmdLastBatch(storeKey, cdc),
+		GetCmdOrder(storeKey, cdc),
+		GetCmdBatchesHistory(storeKey, cdc),
+		GetCmdCandles(storeKey, cdc),
+		GetCmdPosition(storeKey, cdc),
+		GetCmdVesting(storeKey, cdc),
+		GetCmdFundingPool(storeKey, cdc),
+		GetCmdNextFundingWithdrawal(storeKey, cdc),
+		GetCmdLimitOrders(storeKey, cdc),
+		GetCmdOutcomePayments(storeKey, cdc),
 		GetCmdCurrentPrice(storeKey, cdc),
 		GetCmdCurrentReserve(storeKey, cdc),
 		GetCmdCustomPrice(storeKey, cdc),
 		GetCmdBuyPrice(storeKey, cdc),
 		GetCmdSellReturn(storeKey, cdc),
 		GetCmdSwapReturn(storeKey, cdc),
+		GetCmdSwapRouteReturn(storeKey, cdc),
+		GetCmdBestSwapRoute(storeKey, cdc),
+		GetCmdAddLiquidityReturn(storeKey, cdc),
+		GetCmdRemoveLiquidityReturn(storeKey, cdc),
 		GetCmdQueryParams(cdc),
 	)...)
 
@@ -135,6 +150,235 @@ func GetCmdLastBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "order [bond-token] [order-id]",
+		Short: "Query info of an order in a bond's current or last batch",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			orderID := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/order/%s/%s",
+					queryRoute, bondToken, orderID), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryOrder
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBatchesHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batches-history [bond-token]",
+		Short: "Query a bond's history of settled batches",
+		Example: "" +
+			"batches-history abc\n" +
+			"batches-history abc --page=2 --limit=10",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			page := viper.GetInt(flags.FlagPage)
+			limit := viper.GetInt(flags.FlagLimit)
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/batches_history/%s/%d/%d",
+					queryRoute, bondToken, page, limit), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.SettledBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, rest.DefaultPage, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, rest.DefaultLimit, "Query number of settled batches per page")
+	return cmd
+}
+
+func GetCmdCandles(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "candles [bond-token] [interval]",
+		Short:   "Query a bond's OHLCV candles aggregated to intervals of blocks",
+		Example: "candles abc 100",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			interval := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/candles/%s/%s",
+					queryRoute, bondToken, interval), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.Candle
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdPosition(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "position [bond-token] [address]",
+		Short: "Query an address' totals of tokens bought, sold, and withdrawn, reserve paid and received, and fees paid in a bond",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			address := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/position/%s/%s",
+					queryRoute, bondToken, address), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.Position
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdVesting(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vesting [bond-token] [address]",
+		Short: "Query an address' tokens bought in a bond's hatch phase and how many of these have vested",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			address := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/vesting/%s/%s",
+					queryRoute, bondToken, address), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryVesting
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdFundingPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "funding-pool [bond-token]",
+		Short: "Query the address, balance, and funding tap of a bond's funding pool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/funding_pool/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryFundingPool
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdNextFundingWithdrawal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "next-funding-withdrawal [bond-token]",
+		Short: "Query the height and amount of the next allowed withdrawal from a bond's funding pool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/next_funding_withdrawal/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryNextFundingWithdrawal
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdOutcomePayments(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "outcome-payments [bond-token]",
+		Short: "Query the outcome payment received by a bond and the contributions made towards it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/outcome_payments/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryOutcomePayments
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdLimitOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "limit-orders [bond-token]",
+		Short: "Query the limit orders resting in a bond's limit order book",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/limit_orders/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.LimitOrder
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
 func GetCmdCurrentPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "current-price [bond-token]",
@@ -250,10 +494,12 @@ func GetCmdBuyPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSellReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "sell-return [bond-token-with-amount]",
-		Example: "sell-return 10abc",
-		Short:   "Query return(s) on selling an amount of tokens of the bond",
-		Args:    cobra.ExactArgs(1),
+		Use: "sell-return [bond-token-with-amount] [min-returns]",
+		Example: "" +
+			"sell-return 10abc\n" +
+			"sell-return 10abc 900res1",
+		Short: "Query return(s) on selling an amount of tokens of the bond",
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			bondTokenWithAmount := args[0]
@@ -264,10 +510,21 @@ func GetCmdSellReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return nil
 			}
 
-			res, _, err := cliCtx.QueryWithData(
-				fmt.Sprintf("custom/%s/sell_return/%s/%s",
-					queryRoute, bondCoinWithAmount.Denom,
-					bondCoinWithAmount.Amount.String()), nil)
+			route := fmt.Sprintf("custom/%s/sell_return/%s/%s",
+				queryRoute, bondCoinWithAmount.Denom,
+				bondCoinWithAmount.Amount.String())
+
+			// Min returns are optional
+			if len(args) > 1 {
+				minReturns, err := sdk.ParseCoins(args[1])
+				if err != nil {
+					fmt.Printf("%s", err.Error())
+					return nil
+				}
+				route = fmt.Sprintf("%s/%s", route, minReturns.String())
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
 			if err != nil {
 				fmt.Printf("%s", err.Error())
 				return nil
@@ -282,10 +539,12 @@ func GetCmdSellReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSwapReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "swap-return [bond-token] [from-token-with-amount] [to-token]",
-		Example: "swap-return abc 10res1 res2",
-		Short:   "Query return(s) on swapping an amount of tokens to another token",
-		Args:    cobra.ExactArgs(3),
+		Use: "swap-return [bond-token] [from-token-with-amount] [to-token] [min-output-amount]",
+		Example: "" +
+			"swap-return abc 10res1 res2\n" +
+			"swap-return abc 10res1 res2 9",
+		Short: "Query return(s) on swapping an amount of tokens to another token",
+		Args:  cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			bondToken := args[0]
@@ -298,16 +557,172 @@ func GetCmdSwapReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return nil
 			}
 
+			route := fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
+				queryRoute, bondToken, fromCoinWithAmount.Denom,
+				fromCoinWithAmount.Amount.String(), toToken)
+
+			// Min output is optional
+			if len(args) > 3 {
+				minOutput, ok := sdk.NewIntFromString(args[3])
+				if !ok {
+					fmt.Printf("invalid min output amount %s", args[3])
+					return nil
+				}
+				route = fmt.Sprintf("%s/%s", route, minOutput.String())
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySwapReturn
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdSwapRouteReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "swap-route-return [bond-tokens] [from-token-with-amount]",
+		Example: "swap-route-return abc,def 10res1",
+		Short:   "Query return(s) on swapping an amount of tokens through a route of swapper bonds",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondTokens := args[0]
+			fromTokenWithAmount := args[1]
+
+			fromCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
 			res, _, err := cliCtx.QueryWithData(
-				fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
-					queryRoute, bondToken, fromCoinWithAmount.Denom,
+				fmt.Sprintf("custom/%s/swap_route_return/%s/%s/%s",
+					queryRoute, fromCoinWithAmount.Denom,
+					fromCoinWithAmount.Amount.String(), bondTokens), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySwapRouteReturn
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdBestSwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "best-swap-route [from-token-with-amount] [to-token]",
+		Example: "best-swap-route 10res1 res3",
+		Short:   "Query the route of swapper bonds giving the largest return on swapping an amount of tokens to another token",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			fromTokenWithAmount := args[0]
+			toToken := args[1]
+
+			fromCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/best_swap_route/%s/%s/%s",
+					queryRoute, fromCoinWithAmount.Denom,
 					fromCoinWithAmount.Amount.String(), toToken), nil)
 			if err != nil {
 				fmt.Printf("%s", err.Error())
 				return nil
 			}
 
-			var out types.QuerySwapReturn
+			var out types.QuerySwapRouteReturn
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdAddLiquidityReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use: "add-liquidity-return [bond-token] [deposit]",
+		Example: "" +
+			"add-liquidity-return abc 1000res1\n" +
+			"add-liquidity-return abc 1000res1,10res2",
+		Short: "Query bond tokens obtained on adding liquidity to a swapper bond in any ratio of its reserve tokens",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			deposit, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/add_liquidity_return/%s/%s",
+					queryRoute, bondToken, deposit.String()), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryAddLiquidityReturn
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdRemoveLiquidityReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use: "remove-liquidity-return [bond-token-with-amount] [to-token] [min-output-amount]",
+		Example: "" +
+			"remove-liquidity-return 10abc res1\n" +
+			"remove-liquidity-return 10abc res1 900",
+		Short: "Query return on removing liquidity from a swapper bond in a single one of its reserve tokens",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondTokenWithAmount := args[0]
+			toToken := args[1]
+
+			bondCoinWithAmount, err := sdk.ParseCoin(bondTokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			route := fmt.Sprintf("custom/%s/remove_liquidity_return/%s/%s/%s",
+				queryRoute, bondCoinWithAmount.Denom,
+				bondCoinWithAmount.Amount.String(), toToken)
+
+			// Min output is optional
+			if len(args) > 2 {
+				minOutput, ok := sdk.NewIntFromString(args[2])
+				if !ok {
+					fmt.Printf("invalid min output amount %s", args[2])
+					return nil
+				}
+				route = fmt.Sprintf("%s/%s", route, minOutput.String())
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryRemoveLiquidityReturn
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
@@ -11,10 +11,13 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	client2 "github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 	"strings"
 )
 
@@ -31,10 +34,25 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdCreateBond(cdc),
 		GetCmdEditBond(cdc),
 		GetCmdBuy(cdc),
+		GetCmdBuyWithReserve(cdc),
 		GetCmdSell(cdc),
 		GetCmdSwap(cdc),
+		GetCmdSwapRoute(cdc),
+		GetCmdAddLiquidity(cdc),
+		GetCmdRemoveLiquidity(cdc),
+		GetCmdCancelOrder(cdc),
+		GetCmdLimitBuy(cdc),
+		GetCmdLimitSell(cdc),
+		GetCmdCancelLimitOrder(cdc),
 		GetCmdMakeOutcomePayment(cdc),
+		GetCmdFinaliseOutcomePayment(cdc),
 		GetCmdWithdrawShare(cdc),
+		GetCmdWithdrawVested(cdc),
+		GetCmdPauseBond(cdc),
+		GetCmdResumeBond(cdc),
+		GetCmdCloseBond(cdc),
+		GetCmdWithdrawFunding(cdc),
+		GetCmdSetFundingTap(cdc),
 	)...)
 
 	return bondsTxCmd
@@ -55,6 +73,7 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
 			_feeAddress := viper.GetString(FlagFeeAddress)
 			_maxSupply := viper.GetString(FlagMaxSupply)
+			_maxSupplyAllocation := viper.GetString(FlagMaxSupplyAllocation)
 			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
 			_sanityRate := viper.GetString(FlagSanityRate)
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
@@ -62,6 +81,13 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_signers := viper.GetString(FlagSigners)
 			_batchBlocks := viper.GetString(FlagBatchBlocks)
 			_outcomePayment := viper.GetString(FlagOutcomePayment)
+			_hatchDeadline := viper.GetInt64(FlagHatchDeadline)
+			_minRaise := viper.GetString(FlagMinRaise)
+			_vestingCliff := viper.GetInt64(FlagVestingCliff)
+			_vestingDuration := viper.GetInt64(FlagVestingDuration)
+			_fundingTap := viper.GetString(FlagFundingTap)
+			_fundingTapBlocks := viper.GetInt64(FlagFundingTapBlocks)
+			_fundingTaxPercentage := viper.GetString(FlagFundingTaxPercentage)
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -136,11 +162,32 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse min raise
+			minRaise, err := sdk.ParseCoins(_minRaise)
+			if err != nil {
+				return err
+			}
+
+			// Parse funding tap
+			fundingTap, err := sdk.ParseCoins(_fundingTap)
+			if err != nil {
+				return err
+			}
+
+			// Parse funding tax percentage
+			fundingTaxPercentage, err := sdk.NewDecFromStr(_fundingTaxPercentage)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "funding tax percentage")
+			}
+
 			msg := types.NewMsgCreateBond(_token, _name, _description,
 				cliCtx.GetFromAddress(), _functionType, functionParams,
 				reserveTokens, txFeePercentage, exitFeePercentage, feeAddress,
-				maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-				_allowSells, signers, batchBlocks, outcomePayment)
+				maxSupply, _maxSupplyAllocation, orderQuantityLimits, sanityRate,
+				sanityMarginPercentage,
+				_allowSells, signers, batchBlocks, outcomePayment, _hatchDeadline,
+				minRaise, _vestingCliff, _vestingDuration, fundingTap,
+				_fundingTapBlocks, fundingTaxPercentage)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -164,6 +211,13 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(FlagSigners)
 	_ = cmd.MarkFlagRequired(FlagBatchBlocks)
 	// _ = cmd.MarkFlagRequired(FlagOutcomePayment) // Optional
+	// _ = cmd.MarkFlagRequired(FlagHatchDeadline) // Optional
+	// _ = cmd.MarkFlagRequired(FlagMinRaise) // Optional
+	// _ = cmd.MarkFlagRequired(FlagVestingCliff) // Optional
+	// _ = cmd.MarkFlagRequired(FlagVestingDuration) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingTap) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingTapBlocks) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingTaxPercentage) // Optional
 
 	return cmd
 }
@@ -232,8 +286,39 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			allowPartial := viper.GetBool(FlagAllowPartial)
+
 			msg := types.NewMsgBuy(cliCtx.GetFromAddress(),
-				bondCoinWithAmount, maxPrices)
+				bondCoinWithAmount, maxPrices, allowPartial)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Bool(FlagAllowPartial, false, "Whether or not the order can be partially filled instead of cancelled")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdBuyWithReserve(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "buy-with-reserve [bond-token] [budget]",
+		Example: "" +
+			"buy-with-reserve abc 1000res1\n" +
+			"buy-with-reserve abc 1000res1,1000res2",
+		Short: "Buy from a bond by spending up to a budget of reserve tokens",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			budget, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyWithReserve(cliCtx.GetFromAddress(),
+				args[0], budget)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -243,10 +328,13 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "sell [bond-token-with-amount]",
-		Example: "sell 10abc",
-		Short:   "Sell from a bond",
-		Args:    cobra.ExactArgs(1),
+		Use: "sell [bond-token-with-amount] [min-returns]",
+		Example: "" +
+			"sell 10abc\n" +
+			"sell 10abc 900res1\n" +
+			"sell 10abc 900res1,900res2",
+		Short: "Sell from a bond",
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -258,7 +346,17 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSell(cliCtx.GetFromAddress(), bondCoinWithAmount)
+			// Min returns are optional
+			var minReturns sdk.Coins
+			if len(args) > 1 {
+				minReturns, err = sdk.ParseCoins(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSell(cliCtx.GetFromAddress(),
+				bondCoinWithAmount, minReturns)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -268,12 +366,13 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "swap [bond-token] [from-amount] [from-token] [to-token]",
+		Use: "swap [bond-token] [from-amount] [from-token] [to-token] [min-output-amount]",
 		Example: "" +
 			"swap abc 100 res1 res2\n" +
-			"swap abc 100 res2 res1",
+			"swap abc 100 res2 res1\n" +
+			"swap abc 100 res1 res2 95",
 		Short: "Perform a swap between two tokens",
-		Args:  cobra.ExactArgs(4),
+		Args:  cobra.RangeArgs(4, 5),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -286,7 +385,268 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from, args[3])
+			// Min output is optional (defaults to zero)
+			minOutput := sdk.Coin{Denom: args[3], Amount: sdk.ZeroInt()}
+			if len(args) > 4 {
+				minOutput, err = client2.ParseTwoPartCoin(args[4], args[3])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from, args[3], minOutput)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSwapRoute(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "swap-route [bond-tokens] [from-amount] [from-token] [to-token] [min-output-amount]",
+		Example: "" +
+			"swap-route abc,def 100 res1 res3\n" +
+			"swap-route abc,def 100 res1 res3 95",
+		Short: "Perform a swap through a route of swapper bonds",
+		Long: strings.TrimSpace(`Perform a swap through a route of swapper bonds, where the tokens received
+from each bond are swapped through the next bond in the route. The min output
+applies to the whole route.
+
+The route is not all-or-nothing. Each hop is performed in its bond's batch, so
+if a later hop is cancelled (e.g. since its min output is no longer met or its
+bond was closed), the tokens received from the previous hop are returned
+rather than the tokens originally swapped, and the fees of the completed hops
+are not refunded.
+`),
+		Args: cobra.RangeArgs(4, 5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bondTokens := strings.Split(args[0], ",")
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := client2.ParseTwoPartCoin(args[1], args[2])
+			if err != nil {
+				return err
+			}
+
+			// Min output is optional (defaults to zero)
+			minOutput := sdk.Coin{Denom: args[3], Amount: sdk.ZeroInt()}
+			if len(args) > 4 {
+				minOutput, err = client2.ParseTwoPartCoin(args[4], args[3])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSwapRoute(cliCtx.GetFromAddress(), bondTokens, from, minOutput)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdAddLiquidity(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "add-liquidity [bond-token] [deposit] [min-amount]",
+		Example: "" +
+			"add-liquidity abc 1000res1\n" +
+			"add-liquidity abc 1000res1,10res2\n" +
+			"add-liquidity abc 1000res1 5",
+		Short: "Add liquidity to a swapper bond in any ratio of its reserve tokens",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			deposit, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			// Min amount is optional (defaults to zero)
+			minAmount := sdk.Coin{Denom: args[0], Amount: sdk.ZeroInt()}
+			if len(args) > 2 {
+				minAmount, err = client2.ParseTwoPartCoin(args[2], args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgAddLiquidity(cliCtx.GetFromAddress(),
+				args[0], deposit, minAmount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdRemoveLiquidity(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "remove-liquidity [bond-token-with-amount] [to-token] [min-output-amount]",
+		Example: "" +
+			"remove-liquidity 10abc res1\n" +
+			"remove-liquidity 10abc res1 900",
+		Short: "Remove liquidity from a swapper bond in a single one of its reserve tokens",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bondCoinWithAmount, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			// Min output is optional (defaults to zero)
+			minOutput := sdk.Coin{Denom: args[1], Amount: sdk.ZeroInt()}
+			if len(args) > 2 {
+				minOutput, err = client2.ParseTwoPartCoin(args[2], args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgRemoveLiquidity(cliCtx.GetFromAddress(),
+				bondCoinWithAmount, args[1], minOutput)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "cancel-order [bond-token] [order-type] [order-id]",
+		Example: "" +
+			"cancel-order abc buy 0\n" +
+			"cancel-order abc sell 2",
+		Short: "Cancel an order in the current batch of a bond",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			orderID, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelOrder(cliCtx.GetFromAddress(),
+				args[0], args[1], orderID)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdLimitBuy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "limit-buy [bond-token-with-amount] [max-prices-per-token]",
+		Example: "" +
+			"limit-buy 10abc 100res1\n" +
+			"limit-buy 10abc 100res1,100res2\n" +
+			"limit-buy 10abc 99.5res1 --expiry-height 1000",
+		Short: "Place a limit buy in a bond's limit order book",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bondCoinWithAmount, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			maxPricesPerToken, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			expiryHeight := viper.GetInt64(FlagExpiryHeight)
+
+			msg := types.NewMsgLimitBuy(cliCtx.GetFromAddress(),
+				bondCoinWithAmount, maxPricesPerToken, expiryHeight)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(FlagExpiryHeight, 0, "The block height at which the order expires (zero for no expiry)")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdLimitSell(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "limit-sell [bond-token-with-amount] [min-prices-per-token]",
+		Example: "" +
+			"limit-sell 10abc 90res1\n" +
+			"limit-sell 10abc 90res1,90res2\n" +
+			"limit-sell 10abc 90.5res1 --expiry-height 1000",
+		Short: "Place a limit sell in a bond's limit order book",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bondCoinWithAmount, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			minPricesPerToken, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			expiryHeight := viper.GetInt64(FlagExpiryHeight)
+
+			msg := types.NewMsgLimitSell(cliCtx.GetFromAddress(),
+				bondCoinWithAmount, minPricesPerToken, expiryHeight)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(FlagExpiryHeight, 0, "The block height at which the order expires (zero for no expiry)")
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdCancelLimitOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cancel-limit-order [bond-token] [order-id]",
+		Example: "cancel-limit-order abc 12",
+		Short:   "Cancel a limit order in a bond's limit order book",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			orderID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelLimitOrder(cliCtx.GetFromAddress(),
+				args[0], orderID)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -296,21 +656,62 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdMakeOutcomePayment(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "make-outcome-payment [bond-token]",
-		Example: "make-outcome-payment abc",
-		Short:   "Make an outcome payment to a bond",
-		Args:    cobra.ExactArgs(1),
+		Use:     "make-outcome-payment [bond-token] [amount]",
+		Example: "make-outcome-payment abc 100res1",
+		Short:   "Make an outcome payment to a bond (pays the remaining outcome payment if no amount is specified)",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var amount sdk.Coins
+			if len(args) > 1 {
+				var err error
+				amount, err = sdk.ParseCoins(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgMakeOutcomePayment(cliCtx.GetFromAddress(), args[0], amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdFinaliseOutcomePayment(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "finalise-outcome-payment",
+		Short: "Finalise a bond's outcome payment, moving it to settlement state even if the full outcome payment was not made",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			msg := types.NewMsgMakeOutcomePayment(cliCtx.GetFromAddress(), args[0])
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgFinaliseOutcomePayment(_token, cliCtx.GetFromAddress(), signers)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
 	return cmd
 }
 
@@ -333,3 +734,260 @@ func GetCmdWithdrawShare(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
+
+func GetCmdWithdrawVested(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "withdraw-vested [bond-token]",
+		Example: "withdraw-vested abc",
+		Short:   "Withdraw vested bond tokens from the bond's vesting escrow",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawVested(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdPauseBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause-bond",
+		Short: "Pause bond",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgPauseBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdResumeBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume-bond",
+		Short: "Resume bond",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgResumeBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdCloseBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close-bond",
+		Short: "Close bond, cancelling any pending orders and moving it to settlement state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCloseBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdWithdrawFunding(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "withdraw-funding [bond-token] [amount]",
+		Example: "withdraw-funding abc 100res1,100res2",
+		Short:   "Withdraw funding from a bond's funding pool to the bond's fee address",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			amount, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgWithdrawFunding(cliCtx.GetFromAddress(), args[0], amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSetFundingTap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-funding-tap",
+		Short: "Set the funding tap of a bond's funding pool",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+			_fundingTap := viper.GetString(FlagFundingTap)
+			_fundingTapBlocks := viper.GetInt64(FlagFundingTapBlocks)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse funding tap
+			fundingTap, err := sdk.ParseCoins(_fundingTap)
+			if err != nil {
+				return err
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetFundingTap(_token, fundingTap,
+				_fundingTapBlocks, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+	cmd.Flags().AddFlagSet(fsBondFundingTap)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+	_ = cmd.MarkFlagRequired(FlagFundingTap)
+	_ = cmd.MarkFlagRequired(FlagFundingTapBlocks)
+
+	return cmd
+}
+
+func GetCmdSubmitPauseBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pause-bond [bond-token]",
+		Example: "pause-bond abc --title=\"...\" --description=\"...\" --deposit=\"...\"",
+		Short:   "Submit a proposal to pause a bond",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewPauseBondProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription), args[0])
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+
+	return cmd
+}
+
+func GetCmdSubmitResumeBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "resume-bond [bond-token]",
+		Example: "resume-bond abc --title=\"...\" --description=\"...\" --deposit=\"...\"",
+		Short:   "Submit a proposal to resume a bond",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewResumeBondProposal(
+				viper.GetString(govcli.FlagTitle),
+				viper.GetString(govcli.FlagDescription), args[0])
+
+			msg := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+
+	return cmd
+}
@@ -30,6 +30,51 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		queryLastBatchHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/order/{%s}", RestBondToken, RestOrderID),
+		queryOrderHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/batches_history", RestBondToken),
+		queryBatchesHistoryHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/candles/{%s}", RestBondToken, RestInterval),
+		queryCandlesHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/position/{%s}", RestBondToken, RestAddress),
+		queryPositionHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/vesting/{%s}", RestBondToken, RestAddress),
+		queryVestingHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/funding_pool", RestBondToken),
+		queryFundingPoolHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/next_funding_withdrawal", RestBondToken),
+		queryNextFundingWithdrawalHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/limit_orders", RestBondToken),
+		queryLimitOrdersHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/outcome_payments", RestBondToken),
+		queryOutcomePaymentsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/current_price", RestBondToken),
 		queryCurrentPriceHandler(cliCtx, queryRoute),
@@ -55,11 +100,46 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		querySellReturnHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/sell_return/{%s}/{%s}", RestBondToken, RestBondAmount, RestMinReturns),
+		querySellReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/swap_return/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken),
 		querySwapReturnHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/swap_return/{%s}/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken, RestMinOutput),
+		querySwapReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/swap_route_return/{%s}/{%s}", RestFromTokenWithAmount, RestBondTokens),
+		querySwapRouteReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/best_swap_route/{%s}/{%s}", RestFromTokenWithAmount, RestToToken),
+		queryBestSwapRouteHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/add_liquidity_return/{%s}", RestBondToken, RestDeposit),
+		queryAddLiquidityReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/remove_liquidity_return/{%s}/{%s}", RestBondToken, RestBondAmount, RestToToken),
+		queryRemoveLiquidityReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/remove_liquidity_return/{%s}/{%s}/{%s}", RestBondToken, RestBondAmount, RestToToken, RestMinOutput),
+		queryRemoveLiquidityReturnHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/bonds/params",
 		queryParamsRequestHandler(cliCtx),
@@ -129,6 +209,169 @@ func queryLastBatchHandler(cliCtx context.CLIContext, queryRoute string) http.Ha
 	}
 }
 
+func queryOrderHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		orderID := vars[RestOrderID]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/order/%s/%s",
+				queryRoute, bondToken, orderID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBatchesHistoryHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		_, page, limit, err := rest.ParseHTTPArgs(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/batches_history/%s/%d/%d",
+				queryRoute, bondToken, page, limit), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryCandlesHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		interval := vars[RestInterval]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/candles/%s/%s",
+				queryRoute, bondToken, interval), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryPositionHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		address := vars[RestAddress]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/position/%s/%s",
+				queryRoute, bondToken, address), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryVestingHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		address := vars[RestAddress]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/vesting/%s/%s",
+				queryRoute, bondToken, address), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryFundingPoolHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/funding_pool/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryNextFundingWithdrawalHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/next_funding_withdrawal/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryLimitOrdersHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/limit_orders/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryOutcomePaymentsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/outcome_payments/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryCurrentPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -205,9 +448,15 @@ func querySellReturnHandler(cliCtx context.CLIContext, queryRoute string) http.H
 		bondToken := vars[RestBondToken]
 		bondAmount := vars[RestBondAmount]
 
-		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/sell_return/%s/%s",
-				queryRoute, bondToken, bondAmount), nil)
+		route := fmt.Sprintf("custom/%s/sell_return/%s/%s",
+			queryRoute, bondToken, bondAmount)
+
+		// Min returns are optional
+		if minReturns, ok := vars[RestMinReturns]; ok {
+			route = fmt.Sprintf("%s/%s", route, minReturns)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -230,10 +479,109 @@ func querySwapReturnHandler(cliCtx context.CLIContext, queryRoute string) http.H
 			return
 		}
 
+		route := fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
+			queryRoute, bondToken, reserveCoinWithAmount.Denom,
+			reserveCoinWithAmount.Amount.String(), toToken)
+
+		// Min output is optional
+		if minOutput, ok := vars[RestMinOutput]; ok {
+			route = fmt.Sprintf("%s/%s", route, minOutput)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func querySwapRouteReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		bondTokens := vars[RestBondTokens]
+
+		fromCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/swap_route_return/%s/%s/%s",
+				queryRoute, fromCoinWithAmount.Denom,
+				fromCoinWithAmount.Amount.String(), bondTokens), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBestSwapRouteHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		toToken := vars[RestToToken]
+
+		fromCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
 		res, _, err := cliCtx.QueryWithData(
-			fmt.Sprintf("custom/%s/swap_return/%s/%s/%s/%s",
-				queryRoute, bondToken, reserveCoinWithAmount.Denom,
-				reserveCoinWithAmount.Amount.String(), toToken), nil)
+			fmt.Sprintf("custom/%s/best_swap_route/%s/%s/%s",
+				queryRoute, fromCoinWithAmount.Denom,
+				fromCoinWithAmount.Amount.String(), toToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryAddLiquidityReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		deposit := vars[RestDeposit]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/add_liquidity_return/%s/%s",
+				queryRoute, bondToken, deposit), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryRemoveLiquidityReturnHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		bondAmount := vars[RestBondAmount]
+		toToken := vars[RestToToken]
+
+		route := fmt.Sprintf("custom/%s/remove_liquidity_return/%s/%s/%s",
+			queryRoute, bondToken, bondAmount, toToken)
+
+		// Min output is optional
+		if minOutput, ok := vars[RestMinOutput]; ok {
+			route = fmt.Sprintf("%s/%s", route, minOutput)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -12,8 +12,15 @@ import (
 const (
 	RestBondToken           = "bond_token"
 	RestBondAmount          = "bond_amount"
+	RestOrderID             = "order_id"
+	RestInterval            = "interval"
+	RestAddress             = "address"
 	RestFromTokenWithAmount = "from_token_with_amount"
 	RestToToken             = "to_token"
+	RestMinReturns          = "min_returns"
+	RestMinOutput           = "min_output"
+	RestBondTokens          = "bond_tokens"
+	RestDeposit             = "deposit"
 )
 
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
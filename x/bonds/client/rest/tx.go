@@ -6,10 +6,13 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/gorilla/mux"
 	"github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -17,10 +20,25 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/bonds/create_bond", createBondRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/edit_bond", editBondRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/buy", buyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/buy_with_reserve", buyWithReserveRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/sell", sellRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/swap", swapRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/swap_route", swapRouteRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/add_liquidity", addLiquidityRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/remove_liquidity", removeLiquidityRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/cancel_order", cancelOrderRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/limit_buy", limitBuyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/limit_sell", limitSellRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/cancel_limit_order", cancelLimitOrderRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/make_outcome_payment", makeOutcomePaymentRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/finalise_outcome_payment", finaliseOutcomePaymentRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/withdraw_share", withdrawShareRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/withdraw_vested", withdrawVestedRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/pause_bond", pauseBondRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/resume_bond", resumeBondRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/close_bond", closeBondRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/withdraw_funding", withdrawFundingRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/set_funding_tap", setFundingTapRequestHandler(cliCtx)).Methods("POST")
 }
 
 type createBondReq struct {
@@ -35,6 +53,7 @@ type createBondReq struct {
 	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
 	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
+	MaxSupplyAllocation    string       `json:"max_supply_allocation" yaml:"max_supply_allocation"`
 	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
@@ -42,6 +61,13 @@ type createBondReq struct {
 	Signers                string       `json:"signers" yaml:"signers"`
 	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
+	HatchDeadline          string       `json:"hatch_deadline" yaml:"hatch_deadline"`
+	MinRaise               string       `json:"min_raise" yaml:"min_raise"`
+	VestingCliff           string       `json:"vesting_cliff" yaml:"vesting_cliff"`
+	VestingDuration        string       `json:"vesting_duration" yaml:"vesting_duration"`
+	FundingTap             string       `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks       string       `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+	FundingTaxPercentage   string       `json:"funding_tax_percentage" yaml:"funding_tax_percentage"`
 }
 
 func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -104,6 +130,12 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse max supply allocation (defaults to none)
+		maxSupplyAllocation := req.MaxSupplyAllocation
+		if maxSupplyAllocation == "" {
+			maxSupplyAllocation = types.NoAllocation
+		}
+
 		// Parse order quantity limits
 		orderQuantityLimits, err2 := sdk.ParseCoins(req.OrderQuantityLimits)
 		if err2 != nil {
@@ -160,11 +192,76 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Hatch deadline is optional (defaults to zero, i.e. no deadline)
+		var hatchDeadline int64
+		if req.HatchDeadline != "" {
+			hatchDeadline, err2 = strconv.ParseInt(req.HatchDeadline, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse min raise
+		minRaise, err2 := sdk.ParseCoins(req.MinRaise)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Vesting cliff and duration are optional (default to zero, i.e. no
+		// vesting)
+		var vestingCliff, vestingDuration int64
+		if req.VestingCliff != "" {
+			vestingCliff, err2 = strconv.ParseInt(req.VestingCliff, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+		if req.VestingDuration != "" {
+			vestingDuration, err2 = strconv.ParseInt(req.VestingDuration, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse funding tap
+		fundingTap, err2 := sdk.ParseCoins(req.FundingTap)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Funding tap blocks are optional (default to zero, i.e. no funding pool)
+		var fundingTapBlocks int64
+		if req.FundingTapBlocks != "" {
+			fundingTapBlocks, err2 = strconv.ParseInt(req.FundingTapBlocks, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Funding tax percentage is optional (default to zero, i.e. no tax)
+		fundingTaxPercentageDec := sdk.ZeroDec()
+		if req.FundingTaxPercentage != "" {
+			fundingTaxPercentageDec, err = sdk.NewDecFromStr(req.FundingTaxPercentage)
+			if err != nil {
+				err = sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "funding tax percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
 		msg := types.NewMsgCreateBond(req.Token, req.Name, req.Description,
 			creator, req.FunctionType, functionParams, reserveTokens,
 			txFeePercentageDec, exitFeePercentageDec, feeAddress, maxSupply,
-			orderQuantityLimits, sanityRate, sanityMarginPercentage,
-			allowSells, signers, batchBlocks, outcomePayment)
+			maxSupplyAllocation, orderQuantityLimits, sanityRate, sanityMarginPercentage,
+			allowSells, signers, batchBlocks, outcomePayment, hatchDeadline, minRaise,
+			vestingCliff, vestingDuration, fundingTap, fundingTapBlocks,
+			fundingTaxPercentageDec)
 
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
@@ -217,10 +314,11 @@ func editBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type buyReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
-	MaxPrices  string       `json:"max_prices" yaml:"max_prices"`
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken    string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount   string       `json:"bond_amount" yaml:"bond_amount"`
+	MaxPrices    string       `json:"max_prices" yaml:"max_prices"`
+	AllowPartial bool         `json:"allow_partial" yaml:"allow_partial"`
 }
 
 func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -255,7 +353,44 @@ func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgBuy(buyer, bondCoin, maxPrices)
+		msg := types.NewMsgBuy(buyer, bondCoin, maxPrices, req.AllowPartial)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type buyWithReserveReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Budget    string       `json:"budget" yaml:"budget"`
+}
+
+func buyWithReserveRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req buyWithReserveReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		buyer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		budget, err := sdk.ParseCoins(req.Budget)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuyWithReserve(buyer, req.BondToken, budget)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -264,6 +399,7 @@ type sellReq struct {
 	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
 	BondToken  string       `json:"bond_token" yaml:"bond_token"`
 	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
+	MinReturns string       `json:"min_returns" yaml:"min_returns"`
 }
 
 func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -292,7 +428,13 @@ func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSell(seller, bondCoin)
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSell(seller, bondCoin, minReturns)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -303,6 +445,7 @@ type swapReq struct {
 	FromAmount string       `json:"from_amount" yaml:"from_amount"`
 	FromToken  string       `json:"from_token" yaml:"from_token"`
 	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinOutput  string       `json:"min_output" yaml:"min_output"`
 }
 
 func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -332,19 +475,84 @@ func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSwap(swapper, req.BondToken, fromCoin, req.ToToken)
+		// Min output is optional (defaults to zero)
+		minOutput := sdk.Coin{Denom: req.ToToken, Amount: sdk.ZeroInt()}
+		if req.MinOutput != "" {
+			minOutput, err = client.ParseTwoPartCoin(req.MinOutput, req.ToToken)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgSwap(swapper, req.BondToken, fromCoin, req.ToToken, minOutput)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-type makeOutcomePaymentReq struct {
+type swapRouteReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondTokens string       `json:"bond_tokens" yaml:"bond_tokens"`
+	FromAmount string       `json:"from_amount" yaml:"from_amount"`
+	FromToken  string       `json:"from_token" yaml:"from_token"`
+	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinOutput  string       `json:"min_output" yaml:"min_output"`
+}
+
+func swapRouteRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req swapRouteReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		swapper, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bondTokens := strings.Split(req.BondTokens, ",")
+
+		// Check that from amount and token can be parsed to a coin
+		fromCoin, err := client.ParseTwoPartCoin(req.FromAmount, req.FromToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Min output is optional (defaults to zero)
+		minOutput := sdk.Coin{Denom: req.ToToken, Amount: sdk.ZeroInt()}
+		if req.MinOutput != "" {
+			minOutput, err = client.ParseTwoPartCoin(req.MinOutput, req.ToToken)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgSwapRoute(swapper, bondTokens, fromCoin, minOutput)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type addLiquidityReq struct {
 	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
 	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Deposit   string       `json:"deposit" yaml:"deposit"`
+	MinAmount string       `json:"min_amount" yaml:"min_amount"`
 }
 
-func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func addLiquidityRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req makeOutcomePaymentReq
+		var req addLiquidityReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
@@ -356,25 +564,93 @@ func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFun
 			return
 		}
 
-		sender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		provider, err := sdk.AccAddressFromBech32(baseReq.From)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		msg := types.NewMsgMakeOutcomePayment(sender, req.BondToken)
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Min amount is optional (defaults to zero)
+		minAmount := sdk.Coin{Denom: req.BondToken, Amount: sdk.ZeroInt()}
+		if req.MinAmount != "" {
+			minAmount, err = client.ParseTwoPartCoin(req.MinAmount, req.BondToken)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgAddLiquidity(provider, req.BondToken, deposit, minAmount)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-type withdrawShareReq struct {
+type removeLiquidityReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken  string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
+	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinOutput  string       `json:"min_output" yaml:"min_output"`
+}
+
+func removeLiquidityRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req removeLiquidityReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		provider, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that bond amount and token can be parsed to a coin
+		bondCoin, err := client.ParseTwoPartCoin(req.BondAmount, req.BondToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Min output is optional (defaults to zero)
+		minOutput := sdk.Coin{Denom: req.ToToken, Amount: sdk.ZeroInt()}
+		if req.MinOutput != "" {
+			minOutput, err = client.ParseTwoPartCoin(req.MinOutput, req.ToToken)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgRemoveLiquidity(provider, bondCoin, req.ToToken, minOutput)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelOrderReq struct {
 	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
 	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	OrderType string       `json:"order_type" yaml:"order_type"`
+	OrderID   string       `json:"order_id" yaml:"order_id"`
 }
 
-func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func cancelOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req withdrawShareReq
+		var req cancelOrderReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
@@ -386,13 +662,586 @@ func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		address, err := sdk.AccAddressFromBech32(baseReq.From)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		msg := types.NewMsgWithdrawShare(recipient, req.BondToken)
+		orderID, err := strconv.ParseUint(req.OrderID, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelOrder(address, req.BondToken, req.OrderType, orderID)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type limitBuyReq struct {
+	BaseReq           rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken         string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount        string       `json:"bond_amount" yaml:"bond_amount"`
+	MaxPricesPerToken string       `json:"max_prices_per_token" yaml:"max_prices_per_token"`
+	ExpiryHeight      string       `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func limitBuyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req limitBuyReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		buyer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bondCoin, err := client.ParseTwoPartCoin(req.BondAmount, req.BondToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		maxPricesPerToken, err := sdk.ParseDecCoins(req.MaxPricesPerToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Expiry height is optional (defaults to zero, i.e. no expiry)
+		var expiryHeight int64
+		if req.ExpiryHeight != "" {
+			expiryHeight, err = strconv.ParseInt(req.ExpiryHeight, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgLimitBuy(buyer, bondCoin, maxPricesPerToken, expiryHeight)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type limitSellReq struct {
+	BaseReq           rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken         string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount        string       `json:"bond_amount" yaml:"bond_amount"`
+	MinPricesPerToken string       `json:"min_prices_per_token" yaml:"min_prices_per_token"`
+	ExpiryHeight      string       `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func limitSellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req limitSellReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		seller, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bondCoin, err := client.ParseTwoPartCoin(req.BondAmount, req.BondToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minPricesPerToken, err := sdk.ParseDecCoins(req.MinPricesPerToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Expiry height is optional (defaults to zero, i.e. no expiry)
+		var expiryHeight int64
+		if req.ExpiryHeight != "" {
+			expiryHeight, err = strconv.ParseInt(req.ExpiryHeight, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		msg := types.NewMsgLimitSell(seller, bondCoin, minPricesPerToken, expiryHeight)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelLimitOrderReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	OrderID   string       `json:"order_id" yaml:"order_id"`
+}
+
+func cancelLimitOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelLimitOrderReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		address, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		orderID, err := strconv.ParseUint(req.OrderID, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelLimitOrder(address, req.BondToken, orderID)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type makeOutcomePaymentReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Amount    string       `json:"amount" yaml:"amount"`
+}
+
+func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req makeOutcomePaymentReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		sender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse amount (optional; empty amount pays the remaining outcome payment)
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgMakeOutcomePayment(sender, req.BondToken, amount)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type finaliseOutcomePaymentReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Signers   string       `json:"signers" yaml:"signers"`
+}
+
+func finaliseOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req finaliseOutcomePaymentReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFinaliseOutcomePayment(req.BondToken, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawShareReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawShareReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawShare(recipient, req.BondToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawVestedReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func withdrawVestedRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawVestedReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawVested(recipient, req.BondToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type pauseOrResumeBondReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Signers   string       `json:"signers" yaml:"signers"`
+}
+
+func pauseBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req pauseOrResumeBondReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgPauseBond(req.BondToken, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func resumeBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req pauseOrResumeBondReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgResumeBond(req.BondToken, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type closeBondReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Signers   string       `json:"signers" yaml:"signers"`
+}
+
+func closeBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req closeBondReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCloseBond(req.BondToken, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawFundingReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Amount    string       `json:"amount" yaml:"amount"`
+}
+
+func withdrawFundingRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawFundingReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		beneficiary, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawFunding(beneficiary, req.BondToken, amount)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setFundingTapReq struct {
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken        string       `json:"bond_token" yaml:"bond_token"`
+	FundingTap       string       `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks string       `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+	Signers          string       `json:"signers" yaml:"signers"`
+}
+
+func setFundingTapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setFundingTapReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse funding tap
+		fundingTap, err := sdk.ParseCoins(req.FundingTap)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse funding tap blocks
+		fundingTapBlocks, err := strconv.ParseInt(req.FundingTapBlocks, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetFundingTap(req.BondToken, fundingTap,
+			fundingTapBlocks, editor, signers)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type pauseOrResumeBondProposalReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	Deposit     sdk.Coins    `json:"deposit" yaml:"deposit"`
+	BondToken   string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func PauseBondProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "pause_bond",
+		Handler:  postPauseBondProposalHandler(cliCtx),
+	}
+}
+
+func postPauseBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req pauseOrResumeBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewPauseBondProposal(req.Title, req.Description, req.BondToken)
+		msg := govtypes.NewMsgSubmitProposal(content, req.Deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func ResumeBondProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "resume_bond",
+		Handler:  postResumeBondProposalHandler(cliCtx),
+	}
+}
+
+func postResumeBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req pauseOrResumeBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		content := types.NewResumeBondProposal(req.Title, req.Description, req.BondToken)
+		msg := govtypes.NewMsgSubmitProposal(content, req.Deposit, proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -19,6 +19,7 @@ var (
 	blankSanityMarginPercentage = "0"
 	reserveToken                = "res"
 	reserveToken2               = "rez"
+	reserveToken3               = "rex"
 
 	anotherAddress = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	userAddress    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
@@ -31,6 +32,7 @@ var (
 	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
 	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
 	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
+	initMaxSupplyAllocation    = types.NoAllocation
 	initOrderQuantityLimits    = sdk.Coins(nil)
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
@@ -38,6 +40,13 @@ var (
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.OneUint()
 	initOutcomePayment         = sdk.Coins(nil)
+	initHatchDeadline          = int64(0)
+	initMinRaise               = sdk.Coins(nil)
+	initVestingCliff           = int64(0)
+	initVestingDuration        = int64(0)
+	initFundingTap             = sdk.Coins(nil)
+	initFundingTapBlocks       = int64(0)
+	initFundingTaxPercentage   = sdk.ZeroDec()
 
 	amountLTMaxSupply = initMaxSupply.Amount.Sub(sdk.OneInt()).Int64()
 	amountGTMaxSupply = initMaxSupply.Amount.Add(sdk.OneInt()).Int64()
@@ -128,34 +137,96 @@ func newValidMsgCreateBond() types.MsgCreateBond {
 	return types.NewMsgCreateBond(token, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage)
 }
 
 func newValidMsgBuy(amount int64, maxPrice int64) types.MsgBuy {
 	amountCoin := sdk.NewInt64Coin(token, amount)
 	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, maxPrice))
-	return types.NewMsgBuy(userAddress, amountCoin, maxPrices)
+	return types.NewMsgBuy(userAddress, amountCoin, maxPrices, false)
+}
+
+func newValidMsgBuyWithReserve(budget int64) types.MsgBuyWithReserve {
+	budgetCoins := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, budget))
+	return types.NewMsgBuyWithReserve(userAddress, token, budgetCoins)
 }
 
 func newValidMsgSell(amount int64) types.MsgSell {
 	amountCoin := sdk.NewInt64Coin(token, amount)
-	return types.NewMsgSell(userAddress, amountCoin)
+	return types.NewMsgSell(userAddress, amountCoin, nil)
 }
 
 func newValidMsgSwap(fromToken, toToken string, amount int64) types.MsgSwap {
 	fromAmount := sdk.NewInt64Coin(fromToken, amount)
-	return types.NewMsgSwap(userAddress, token, fromAmount, toToken)
+	return types.NewMsgSwap(userAddress, token, fromAmount, toToken, sdk.NewCoin(toToken, sdk.ZeroInt()))
+}
+
+func newValidMsgSwapRoute(bondTokens []string, fromToken string, amount int64, minOutput sdk.Coin) types.MsgSwapRoute {
+	fromAmount := sdk.NewInt64Coin(fromToken, amount)
+	return types.NewMsgSwapRoute(userAddress, bondTokens, fromAmount, minOutput)
+}
+
+func newValidMsgAddLiquidity(deposit sdk.Coins, minAmount int64) types.MsgAddLiquidity {
+	return types.NewMsgAddLiquidity(userAddress, token, deposit, sdk.NewInt64Coin(token, minAmount))
+}
+
+func newValidMsgRemoveLiquidity(amount int64, toToken string, minOutput int64) types.MsgRemoveLiquidity {
+	amountCoin := sdk.NewInt64Coin(token, amount)
+	return types.NewMsgRemoveLiquidity(userAddress, amountCoin, toToken, sdk.NewInt64Coin(toToken, minOutput))
+}
+
+func newValidMsgCancelOrder(orderType string, orderID uint64) types.MsgCancelOrder {
+	return types.NewMsgCancelOrder(userAddress, token, orderType, orderID)
+}
+
+func newValidMsgLimitBuy(amount, maxPricePerToken, expiryHeight int64) types.MsgLimitBuy {
+	amountCoin := sdk.NewInt64Coin(token, amount)
+	maxPricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, maxPricePerToken))
+	return types.NewMsgLimitBuy(userAddress, amountCoin, maxPricesPerToken, expiryHeight)
+}
+
+func newValidMsgLimitSell(amount, minPricePerToken, expiryHeight int64) types.MsgLimitSell {
+	amountCoin := sdk.NewInt64Coin(token, amount)
+	minPricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, minPricePerToken))
+	return types.NewMsgLimitSell(userAddress, amountCoin, minPricesPerToken, expiryHeight)
+}
+
+func newValidMsgCancelLimitOrder(orderID uint64) types.MsgCancelLimitOrder {
+	return types.NewMsgCancelLimitOrder(userAddress, token, orderID)
 }
 
 func newValidMsgMakeOutcomePayment() types.MsgMakeOutcomePayment {
-	return types.NewMsgMakeOutcomePayment(userAddress, token)
+	return types.NewMsgMakeOutcomePayment(userAddress, token, nil)
+}
+
+func newValidMsgMakeOutcomePaymentOf(amount int64) types.MsgMakeOutcomePayment {
+	return types.NewMsgMakeOutcomePayment(userAddress, token,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, amount)))
 }
 
 func newValidMsgWithdrawShareFrom(from sdk.AccAddress) types.MsgWithdrawShare {
 	return types.NewMsgWithdrawShare(from, token)
 }
 
+func newValidMsgPauseBond() types.MsgPauseBond {
+	return types.NewMsgPauseBond(token, initCreator, initSigners)
+}
+
+func newValidMsgResumeBond() types.MsgResumeBond {
+	return types.NewMsgResumeBond(token, initCreator, initSigners)
+}
+
+func newValidMsgCloseBond() types.MsgCloseBond {
+	return types.NewMsgCloseBond(token, initCreator, initSigners)
+}
+
+func newValidMsgFinaliseOutcomePayment() types.MsgFinaliseOutcomePayment {
+	return types.NewMsgFinaliseOutcomePayment(token, initCreator, initSigners)
+}
+
 func addCoinsToUser(app *simapp.SimApp, ctx sdk.Context, coins sdk.Coins) error {
 	_, err := app.BondsKeeper.BankKeeper.AddCoins(ctx, userAddress, coins)
 	return err
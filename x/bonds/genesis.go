@@ -11,9 +11,54 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 		keeper.SetBond(ctx, b.Token, b)
 	}
 
-	// Initialise batches
+	// Initialise hatch deadlines of bonds that are still in the hatch phase
+	for _, b := range data.Bonds {
+		if b.HasHatchDeadline() && b.GetActiveState() == types.HatchState {
+			keeper.SetHatchDeadline(ctx, b.Token)
+		}
+	}
+
+	// Initialise limit orders
+	for _, lo := range data.LimitOrders {
+		keeper.SetLimitOrder(ctx, lo.Amount.Denom, lo)
+	}
+
+	// Initialise batches, scheduling any batches that have orders or whose
+	// bond has limit orders (unless the bond is paused) for their remaining
+	// blocks
 	for _, b := range data.Batches {
 		keeper.SetBatch(ctx, b.Token, b)
+		scheduled := b.HasOrders() || keeper.HasLimitOrders(ctx, b.Token)
+		if scheduled && keeper.MustGetBond(ctx, b.Token).State != types.PausedState {
+			keeper.ScheduleBatch(ctx, b.Token)
+		}
+	}
+
+	// Initialise batches history
+	for _, sb := range data.BatchesHistory {
+		keeper.SetSettledBatch(ctx, sb)
+	}
+
+	// Initialise positions
+	for _, p := range data.Positions {
+		keeper.SetPosition(ctx, p)
+	}
+
+	// Initialise vestings
+	for _, v := range data.Vestings {
+		keeper.SetVesting(ctx, v)
+	}
+
+	// Initialise outcome payment contributions
+	for _, c := range data.OutcomePaymentContributions {
+		keeper.SetOutcomePaymentContribution(ctx, c)
+	}
+
+	// Move reserves of bonds without a reserve address (e.g. from an older
+	// genesis file) to their own reserve address
+	err := keeper.MigrateReserveAccounts(ctx)
+	if err != nil {
+		panic(err)
 	}
 
 	// Initialise params
@@ -21,23 +66,46 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	// Export bonds and batches
+	// Export bonds, batches, batches history, positions, limit orders,
+	// vestings, and outcome payment contributions
 	var bonds []types.Bond
 	var batches []types.Batch
+	var batchesHistory []types.SettledBatch
+	var positions []types.Position
+	var limitOrders []types.LimitOrder
+	var vestings []types.Vesting
+	var outcomePaymentContributions []types.OutcomePaymentContribution
 	iterator := k.GetBondIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		bond := k.MustGetBondByKey(ctx, iterator.Key())
 		batch := k.MustGetBatch(ctx, bond.Token)
+		if k.BatchIsScheduled(ctx, bond.Token) {
+			// Execution heights are not carried over, so export the remaining
+			// blocks, counting the current block as the first remaining block
+			batch.BlocksRemaining = k.GetBatchBlocksRemaining(ctx, bond.Token).AddUint64(1)
+			batch.ExecutionHeight = 0
+		}
 		bonds = append(bonds, bond)
 		batches = append(batches, batch)
+		batchesHistory = append(batchesHistory, k.GetBatchesHistory(ctx, bond.Token)...)
+		positions = append(positions, k.GetPositions(ctx, bond.Token)...)
+		limitOrders = append(limitOrders, k.GetLimitOrders(ctx, bond.Token)...)
+		vestings = append(vestings, k.GetVestings(ctx, bond.Token)...)
+		outcomePaymentContributions = append(outcomePaymentContributions,
+			k.GetOutcomePaymentContributions(ctx, bond.Token)...)
 	}
 
 	// Export params
 	params := k.GetParams(ctx)
 
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
-		Params:  params,
+		Bonds:                       bonds,
+		Batches:                     batches,
+		BatchesHistory:              batchesHistory,
+		Positions:                   positions,
+		LimitOrders:                 limitOrders,
+		Vestings:                    vestings,
+		OutcomePaymentContributions: outcomePaymentContributions,
+		Params:                      params,
 	}
 }
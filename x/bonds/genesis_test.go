@@ -29,6 +29,7 @@ func TestInitAndExportGenesis(t *testing.T) {
 	exitFeePercentage := sdk.MustNewDecFromStr("0.2")
 	feeAddress := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	maxSupply := sdk.NewInt64Coin(token, 10000)
+	maxSupplyAllocation := types.ProRataAllocation
 	orderQuantityLimits := sdk.NewCoins(
 		sdk.NewInt64Coin("token1", 1),
 		sdk.NewInt64Coin("token2", 2),
@@ -44,26 +45,87 @@ func TestInitAndExportGenesis(t *testing.T) {
 		sdk.NewInt64Coin("token2", 2),
 		sdk.NewInt64Coin("token3", 3),
 	)
+	hatchDeadline := int64(100)
+	minRaise := sdk.NewCoins(sdk.NewInt64Coin("token1", 10))
+	vestingCliff := int64(10)
+	vestingDuration := int64(20)
+	fundingTap := sdk.NewCoins(sdk.NewInt64Coin("token1", 5))
+	fundingTapBlocks := int64(30)
+	fundingTaxPercentage := sdk.MustNewDecFromStr("0.5")
 	state := "dummy_state"
 
 	bond := types.NewBond(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
-		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		feeAddress, maxSupply, maxSupplyAllocation, orderQuantityLimits, sanityRate, sanityMarginPercentage,
+		allowSell, signers, batchBlocks, outcomePayment, hatchDeadline, minRaise,
+		vestingCliff, vestingDuration, fundingTap, fundingTapBlocks,
+		fundingTaxPercentage, state)
 	batch := types.NewBatch(bond.Token, bond.BatchBlocks)
+	settledBatches := []types.SettledBatch{
+		types.NewSettledBatch(10, types.NewBatch(bond.Token, bond.BatchBlocks)),
+		types.NewSettledBatch(20, types.NewBatch(bond.Token, bond.BatchBlocks)),
+	}
+	reserve := sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100))
+	fees := sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 10))
+	positions := []types.Position{
+		types.NewPosition(bond.Token, creator).
+			AddBuy(sdk.NewInt64Coin(token, 10), reserve, fees).
+			AddSell(sdk.NewInt64Coin(token, 5), reserve, fees).
+			AddShareWithdrawal(sdk.NewInt64Coin(token, 5), reserve),
+	}
 
-	genesisState = bonds.NewGenesisState(
-		[]types.Bond{bond}, []types.Batch{batch}, types.DefaultParams())
+	limitOrder := types.NewLimitBuyOrder(bond, creator, sdk.NewInt64Coin(token, 10),
+		sdk.NewDecCoins(sdk.NewInt64DecCoin("reservetoken", 500)), 100)
+	limitOrder.ID = 3
+	limitOrders := []types.LimitOrder{limitOrder}
+
+	vestings := []types.Vesting{
+		types.NewVesting(bond.Token, creator).
+			AddEntry(10, sdk.NewInt64Coin(token, 10)).
+			AddEntry(20, sdk.NewInt64Coin(token, 5)),
+	}
+
+	outcomePaymentContributions := []types.OutcomePaymentContribution{
+		types.NewOutcomePaymentContribution(bond.Token, creator).
+			Add(sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 10))),
+	}
+
+	genesisState = bonds.NewGenesisState([]types.Bond{bond},
+		[]types.Batch{batch}, settledBatches, positions, limitOrders, vestings,
+		outcomePaymentContributions, types.DefaultParams())
 
 	bonds.InitGenesis(ctx, app.BondsKeeper, genesisState)
 
 	returnedBond := app.BondsKeeper.MustGetBond(ctx, token)
 	require.EqualValues(t, bond, returnedBond)
 
+	// The batch has no orders, but is scheduled since the bond has limit orders
+	require.True(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
 	returnedBatch := app.BondsKeeper.MustGetBatch(ctx, token)
+	returnedBatch.ExecutionHeight = 0
 	require.Equal(t, batch, returnedBatch)
 
+	returnedBatchesHistory := app.BondsKeeper.GetBatchesHistory(ctx, token)
+	require.Equal(t, settledBatches, returnedBatchesHistory)
+
+	returnedPosition := app.BondsKeeper.GetPosition(ctx, token, creator)
+	require.Equal(t, positions[0], returnedPosition)
+
+	returnedLimitOrders := app.BondsKeeper.GetLimitOrders(ctx, token)
+	require.Equal(t, limitOrders, returnedLimitOrders)
+
+	returnedVesting := app.BondsKeeper.GetVesting(ctx, token, creator)
+	require.Equal(t, vestings[0], returnedVesting)
+
+	returnedContribution := app.BondsKeeper.GetOutcomePaymentContribution(ctx, token, creator)
+	require.Equal(t, outcomePaymentContributions[0], returnedContribution)
+
 	exportedGenesisState := bonds.ExportGenesis(ctx, app.BondsKeeper)
 	require.Equal(t, genesisState.Bonds, exportedGenesisState.Bonds)
 	require.Equal(t, genesisState.Batches, exportedGenesisState.Batches)
+	require.Equal(t, genesisState.BatchesHistory, exportedGenesisState.BatchesHistory)
+	require.Equal(t, genesisState.Positions, exportedGenesisState.Positions)
+	require.Equal(t, genesisState.LimitOrders, exportedGenesisState.LimitOrders)
+	require.Equal(t, genesisState.Vestings, exportedGenesisState.Vestings)
+	require.Equal(t, genesisState.OutcomePaymentContributions, exportedGenesisState.OutcomePaymentContributions)
 }
@@ -21,14 +21,44 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 			return handleMsgEditBond(ctx, keeper, msg)
 		case types.MsgBuy:
 			return handleMsgBuy(ctx, keeper, msg)
+		case types.MsgBuyWithReserve:
+			return handleMsgBuyWithReserve(ctx, keeper, msg)
 		case types.MsgSell:
 			return handleMsgSell(ctx, keeper, msg)
 		case types.MsgSwap:
 			return handleMsgSwap(ctx, keeper, msg)
+		case types.MsgSwapRoute:
+			return handleMsgSwapRoute(ctx, keeper, msg)
+		case types.MsgAddLiquidity:
+			return handleMsgAddLiquidity(ctx, keeper, msg)
+		case types.MsgRemoveLiquidity:
+			return handleMsgRemoveLiquidity(ctx, keeper, msg)
+		case types.MsgCancelOrder:
+			return handleMsgCancelOrder(ctx, keeper, msg)
+		case types.MsgLimitBuy:
+			return handleMsgLimitBuy(ctx, keeper, msg)
+		case types.MsgLimitSell:
+			return handleMsgLimitSell(ctx, keeper, msg)
+		case types.MsgCancelLimitOrder:
+			return handleMsgCancelLimitOrder(ctx, keeper, msg)
 		case types.MsgMakeOutcomePayment:
 			return handleMsgMakeOutcomePayment(ctx, keeper, msg)
+		case types.MsgFinaliseOutcomePayment:
+			return handleMsgFinaliseOutcomePayment(ctx, keeper, msg)
 		case types.MsgWithdrawShare:
 			return handleMsgWithdrawShare(ctx, keeper, msg)
+		case types.MsgWithdrawVested:
+			return handleMsgWithdrawVested(ctx, keeper, msg)
+		case types.MsgPauseBond:
+			return handleMsgPauseBond(ctx, keeper, msg)
+		case types.MsgResumeBond:
+			return handleMsgResumeBond(ctx, keeper, msg)
+		case types.MsgCloseBond:
+			return handleMsgCloseBond(ctx, keeper, msg)
+		case types.MsgWithdrawFunding:
+			return handleMsgWithdrawFunding(ctx, keeper, msg)
+		case types.MsgSetFundingTap:
+			return handleMsgSetFundingTap(ctx, keeper, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Unrecognized bonds Msg type: %v", msg.Type())
 		}
@@ -37,43 +67,63 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 
-	iterator := keeper.GetBondIterator(ctx)
-	for ; iterator.Valid(); iterator.Next() {
-		bond := keeper.MustGetBondByKey(ctx, iterator.Key())
-		batch := keeper.MustGetBatch(ctx, bond.Token)
+	// Only batches that have orders (or whose bond has limit orders) are
+	// scheduled, and the batches of paused bonds are removed from the
+	// schedule, so that idle bonds are not touched
+	for _, token := range keeper.GetBatchesDue(ctx) {
 
-		// Subtract one block
-		batch.BlocksRemaining = batch.BlocksRemaining.SubUint64(1)
-		keeper.SetBatch(ctx, bond.Token, batch)
-
-		// If blocks remaining > 0 do not perform orders
-		if !batch.BlocksRemaining.IsZero() {
-			continue
-		}
+		// Add any limit orders that can be filled to the batch, and remove
+		// any expired limit orders
+		keeper.AddLimitOrdersToBatch(ctx, token)
 
 		// Perform orders
-		keeper.PerformOrders(ctx, bond.Token)
+		keeper.PerformOrders(ctx, token)
 
-		// Get bond again just in case current supply was updated
-		// Get batch again just in case orders were cancelled
-		bond = keeper.MustGetBond(ctx, bond.Token)
-		batch = keeper.MustGetBatch(ctx, bond.Token)
+		// Remove the limit orders that were filled from the limit order book
+		keeper.RemoveFilledLimitOrders(ctx, token)
+
+		// Get bond and batch after performing orders, just in case current
+		// supply was updated or orders were cancelled
+		bond := keeper.MustGetBond(ctx, token)
+		batch := keeper.MustGetBatch(ctx, token)
 
 		// For augmented, if hatch phase and newSupply >= S0, go to open phase
 		if bond.FunctionType == types.AugmentedFunction &&
 			bond.State == types.HatchState {
 			args := bond.FunctionParameters.AsMap()
 			if bond.CurrentSupply.Amount.ToDec().GTE(args["S0"]) {
-				keeper.SetBondState(ctx, bond.Token, types.OpenState)
+				keeper.EndHatch(ctx, bond.Token)
 				bond = keeper.MustGetBond(ctx, bond.Token) // get bond again
-				bond.AllowSells = true                     // enable sells
-				keeper.SetBond(ctx, bond.Token, bond)      // update bond
 			}
 		}
 
-		// Save current batch as last batch and reset current batch
-		keeper.SetLastBatch(ctx, bond.Token, batch)
-		keeper.SetBatch(ctx, bond.Token, types.NewBatch(bond.Token, bond.BatchBlocks))
+		// Save current batch as last batch (and add it to the batches
+		// history) and reset current batch. A batch without orders was only
+		// scheduled for the bond's limit orders, so it is not saved
+		if batch.HasOrders() {
+			batch.BlocksRemaining = sdk.ZeroUint()
+			keeper.SetLastBatch(ctx, bond.Token, batch)
+			keeper.AddSettledBatch(ctx, bond.Token, batch)
+		}
+		newBatch := types.NewBatch(bond.Token, bond.BatchBlocks)
+		newBatch.NextOrderID = batch.NextOrderID
+		keeper.SetBatch(ctx, bond.Token, newBatch)
+
+		// Schedule the new batch if the bond still has limit orders, so that
+		// these are considered again in the new batch
+		if keeper.HasLimitOrders(ctx, bond.Token) {
+			keeper.ScheduleBatch(ctx, bond.Token)
+		}
+	}
+
+	// For augmented with a hatch deadline, if hatch phase at the deadline
+	// (i.e. S0 was not reached), go to open phase if the min raise was
+	// reached, otherwise go to failed state
+	for _, token := range keeper.GetHatchDeadlinesDue(ctx) {
+		bond := keeper.MustGetBond(ctx, token)
+		if bond.GetActiveState() == types.HatchState {
+			keeper.EndHatchAtDeadline(ctx, token)
+		}
 	}
 	return []abci.ValidatorUpdate{}
 }
@@ -95,6 +145,12 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 		return nil, types.ErrReservedBondToken
 	}
 
+	// Check that hatch deadline (if any) not already reached
+	if msg.HatchDeadline != 0 && msg.HatchDeadline <= ctx.BlockHeight() {
+		return nil, sdkerrors.Wrap(types.ErrHatchDeadlineAlreadyReached,
+			strconv.FormatInt(msg.HatchDeadline, 10))
+	}
+
 	// Set state to open by default (overridden below if augmented function)
 	state := types.OpenState
 
@@ -128,12 +184,17 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 	bond := types.NewBond(msg.Token, msg.Name, msg.Description, msg.Creator,
 		msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
 		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
-		msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
-		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
-		msg.BatchBlocks, msg.OutcomePayment, state)
+		msg.MaxSupply, msg.MaxSupplyAllocation, msg.OrderQuantityLimits,
+		msg.SanityRate, msg.SanityMarginPercentage, msg.AllowSells,
+		msg.Signers, msg.BatchBlocks, msg.OutcomePayment, msg.HatchDeadline,
+		msg.MinRaise, msg.VestingCliff, msg.VestingDuration, msg.FundingTap,
+		msg.FundingTapBlocks, msg.FundingTaxPercentage, state)
 
 	keeper.SetBond(ctx, msg.Token, bond)
 	keeper.SetBatch(ctx, msg.Token, types.NewBatch(bond.Token, msg.BatchBlocks))
+	if bond.HasHatchDeadline() {
+		keeper.SetHatchDeadline(ctx, bond.Token)
+	}
 
 	logger := keeper.Logger(ctx)
 	logger.Info(fmt.Sprintf("bond %s [%s] with reserve(s) [%s] created by %s", msg.Token,
@@ -152,6 +213,7 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeyExitFeePercentage, msg.ExitFeePercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyFeeAddress, msg.FeeAddress.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxSupply, msg.MaxSupply.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxSupplyAllocation, msg.MaxSupplyAllocation),
 			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits.String()),
 			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate.String()),
 			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage.String()),
@@ -159,6 +221,13 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(msg.Signers)),
 			sdk.NewAttribute(types.AttributeKeyBatchBlocks, msg.BatchBlocks.String()),
 			sdk.NewAttribute(types.AttributeKeyOutcomePayment, msg.OutcomePayment.String()),
+			sdk.NewAttribute(types.AttributeKeyHatchDeadline, strconv.FormatInt(msg.HatchDeadline, 10)),
+			sdk.NewAttribute(types.AttributeKeyMinRaise, msg.MinRaise.String()),
+			sdk.NewAttribute(types.AttributeKeyVestingCliff, strconv.FormatInt(msg.VestingCliff, 10)),
+			sdk.NewAttribute(types.AttributeKeyVestingDuration, strconv.FormatInt(msg.VestingDuration, 10)),
+			sdk.NewAttribute(types.AttributeKeyFundingTap, msg.FundingTap.String()),
+			sdk.NewAttribute(types.AttributeKeyFundingTapBlocks, strconv.FormatInt(msg.FundingTapBlocks, 10)),
+			sdk.NewAttribute(types.AttributeKeyFundingTaxPercentage, msg.FundingTaxPercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyState, state),
 		),
 		sdk.NewEvent(
@@ -280,7 +349,12 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 	}
 
 	// Create order
-	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	var order types.BuyOrder
+	if msg.AllowPartial {
+		order = types.NewPartialBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	} else {
+		order = types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	}
 
 	// Get buy price and check if can add buy order to batch
 	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
@@ -289,7 +363,7 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 	}
 
 	// Add buy order to batch
-	keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
+	orderID := keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
 
 	// Cancel unfulfillable orders
 	keeper.CancelUnfulfillableOrders(ctx, token)
@@ -298,8 +372,10 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 		sdk.NewEvent(
 			types.EventTypeBuy,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyAllowPartial, strconv.FormatBool(msg.AllowPartial)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -349,6 +425,14 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 	// Update supply
 	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Add(msg.Amount))
 
+	// Any previously noted swapper product no longer applies, given that the
+	// reserve was re-initialised at an arbitrary ratio
+	keeper.DeleteSwapperProduct(ctx, bond.Token)
+
+	// Update buyer's position (no fees are charged)
+	position := keeper.GetPosition(ctx, bond.Token, msg.Buyer)
+	keeper.SetPosition(ctx, position.AddBuy(msg.Amount, msg.MaxPrices, nil))
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeInitSwapper,
@@ -366,6 +450,57 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgBuyWithReserve(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuyWithReserve) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check current state is HATCH/OPEN, budget denoms, order quantity limits
+	if bond.State != types.OpenState && bond.State != types.HatchState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if !bond.ReserveDenomsEqualTo(msg.Budget) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.Budget.String(), strings.Join(bond.ReserveTokens, ","))
+	} else if bond.AnyOrderQuantityLimitsExceeded(msg.Budget) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Budget.String())
+	}
+
+	// For the swapper, the first buy (the initialisation of the reserves)
+	// has to be a MsgBuy since the budget alone does not define a price
+	if bond.CurrentSupply.IsZero() && bond.FunctionType == types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionRequiresNonZeroCurrentSupply, bond.CurrentSupply.Amount.String())
+	}
+
+	// Take budget from buyer (enforces budget <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer,
+		types.BatchesIntermediaryAccount, msg.Budget)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create order and add it to batch. The amount of tokens bought is only
+	// determined at the end of the batch, so there are no prices to update
+	order := types.NewBuyWithReserveOrder(msg.Buyer, msg.BondToken, msg.Budget)
+	orderID := keeper.AddBuyWithReserveOrder(ctx, msg.BondToken, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeBuyWithReserve,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(types.AttributeKeyBudget, msg.Budget.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*sdk.Result, error) {
 
 	token := msg.Amount.Denom
@@ -374,17 +509,38 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
 	}
 
-	// Check sells allowed, current state is OPEN, and order limits not exceeded
+	// Check sells allowed, current state is OPEN, min returns (if any), and
+	// order limits not exceeded
 	if !bond.AllowSells {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
 	} else if bond.State != types.OpenState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if !msg.MinReturns.Empty() && !bond.ReserveDenomsEqualTo(msg.MinReturns) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MinReturns.String(), strings.Join(bond.ReserveTokens, ","))
 	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
 		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
 	}
 
+	// Withdraw any of the seller's bond tokens that have vested from the
+	// vesting escrow, so that these can be sold (unvested tokens stay locked)
+	if bond.HasVesting() {
+		_, err := keeper.WithdrawVested(ctx, token, msg.Seller)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create order
+	order := types.NewSellOrder(msg.Seller, msg.Amount, msg.MinReturns)
+
+	// Get sell price and check if can add sell order to batch
+	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, token, order)
+	if err != nil {
+		return nil, err
+	}
+
 	// Send coins to be burned from seller (enforces sellAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
 		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
 	if err != nil {
 		return nil, err
@@ -397,26 +553,19 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 		return nil, err
 	}
 
-	// Create order
-	order := types.NewSellOrder(msg.Seller, msg.Amount)
-
-	// Get sell price and check if can add sell order to batch
-	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, token, order)
-	if err != nil {
-		return nil, err
-	}
-
 	// Add sell order to batch
-	keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
+	orderID := keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
 
-	//// Cancel unfulfillable orders (Note: no need)
-	//keeper.CancelUnfulfillableOrders(ctx, token)
+	// Cancel unfulfillable orders
+	keeper.CancelUnfulfillableOrders(ctx, token)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeSell,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -462,10 +611,10 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 	}
 
 	// Create order
-	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken)
+	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken, msg.MinOutput)
 
 	// Add swap order to batch
-	keeper.AddSwapOrder(ctx, msg.BondToken, order)
+	orderID := keeper.AddSwapOrder(ctx, msg.BondToken, order)
 
 	//// Cancel unfulfillable orders (Note: no need)
 	//keeper.CancelUnfulfillableOrders(ctx, token)
@@ -474,9 +623,11 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 		sdk.NewEvent(
 			types.EventTypeSwap,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
 			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyMinOutput, msg.MinOutput.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -488,104 +639,834 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgMakeOutcomePayment) (*sdk.Result, error) {
+func handleMsgSwapRoute(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwapRoute) (*sdk.Result, error) {
+
+	// Check that each hop of the route can be performed (i.e. that each bond
+	// is an OPEN swapper bond with the previous hop's to token as a reserve
+	// token) and that the route ends with the token of the min output. The
+	// quantity limits of bonds other than the first are checked at batch time.
+	var toTokens []string
+	fromToken := msg.From.Denom
+	for i, bondToken := range msg.BondTokens {
+		bond, found := keeper.GetBond(ctx, bondToken)
+		if !found {
+			return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
+		} else if bond.FunctionType != types.SwapperFunction {
+			return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+		} else if bond.State != types.OpenState {
+			return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+		}
+
+		toToken, err := bond.GetSwapToToken(fromToken)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidSwapRoute,
+				"hop %d: %s is not a reserve token of %s", i, fromToken, bondToken)
+		}
+		toTokens = append(toTokens, toToken)
+		fromToken = toToken
+	}
+	if fromToken != msg.MinOutput.Denom {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidSwapRoute,
+			"route ends with %s instead of %s", fromToken, msg.MinOutput.Denom)
+	}
+
+	// Check if order quantity limit exceeded for the first bond
+	firstBond := keeper.MustGetBond(ctx, msg.BondTokens[0])
+	if firstBond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+	}
+
+	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Swapper,
+		types.BatchesIntermediaryAccount, sdk.Coins{msg.From})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create order for the first hop, which carries the rest of the route
+	order := types.NewSwapRouteOrder(msg.Swapper, msg.From, toTokens[0],
+		msg.MinOutput, msg.BondTokens[1:])
+
+	// Add swap order to the first bond's batch
+	orderID := keeper.AddSwapOrder(ctx, firstBond.Token, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSwapRoute,
+			sdk.NewAttribute(types.AttributeKeyBond, firstBond.Token),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.MinOutput.Denom),
+			sdk.NewAttribute(types.AttributeKeySwapRoute, strings.Join(msg.BondTokens, ",")),
+			sdk.NewAttribute(types.AttributeKeyMinOutput, msg.MinOutput.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgAddLiquidity(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgAddLiquidity) (*sdk.Result, error) {
 
 	bond, found := keeper.GetBond(ctx, msg.BondToken)
 	if !found {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
 	}
 
-	// Confirm that state is OPEN and that outcome payment is not nil
-	if bond.State != types.OpenState {
+	// Confirm that function type is swapper_function, state is OPEN, and
+	// that the reserves were initialised (i.e. the reserve ratio is defined)
+	if bond.FunctionType != types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if bond.State != types.OpenState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
-	} else if bond.OutcomePayment.Empty() {
-		return nil, types.ErrCannotMakeZeroOutcomePayment
+	} else if bond.CurrentSupply.IsZero() {
+		return nil, sdkerrors.Wrap(types.ErrFunctionRequiresNonZeroCurrentSupply, bond.CurrentSupply.Amount.String())
+	}
+
+	// Check that the deposit (in any ratio) only has reserve tokens, and
+	// that the order quantity limits are not exceeded
+	for _, coin := range msg.Deposit {
+		if coin.Denom != bond.ReserveTokens[0] && coin.Denom != bond.ReserveTokens[1] {
+			return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.Deposit.String(), strings.Join(bond.ReserveTokens, ","))
+		}
+	}
+	if bond.AnyOrderQuantityLimitsExceeded(msg.Deposit) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Deposit.String())
 	}
 
-	// Send outcome payment to reserve
-	err := keeper.DepositReserve(ctx, bond.Token, msg.Sender, bond.OutcomePayment)
+	// Take deposit from provider (enforces deposit <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Provider,
+		types.BatchesIntermediaryAccount, msg.Deposit)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set bond state to SETTLE
-	keeper.SetBondState(ctx, bond.Token, types.SettleState)
+	// Create order and add it to batch. The excess of either reserve token
+	// and the amount of tokens bought are only determined at the end of the
+	// batch, at the batch's swap rate
+	order := types.NewAddLiquidityOrder(msg.Provider, msg.Deposit, msg.MinAmount)
+	orderID := keeper.AddAddLiquidityOrder(ctx, msg.BondToken, order)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
-			types.EventTypeMakeOutcomePayment,
+			types.EventTypeAddLiquidity,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
-			sdk.NewAttribute(types.AttributeKeyAddress, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(types.AttributeKeyDeposit, msg.Deposit.String()),
+			sdk.NewAttribute(types.AttributeKeyMinAmount, msg.MinAmount.Amount.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
 		),
 	})
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawShare) (*sdk.Result, error) {
+func handleMsgRemoveLiquidity(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRemoveLiquidity) (*sdk.Result, error) {
 
-	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	token := msg.Amount.Denom
+	bond, found := keeper.GetBond(ctx, token)
 	if !found {
-		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
 	}
 
-	// Check that state is SETTLE
-	if bond.State != types.SettleState {
+	// Confirm that function type is swapper_function, sells allowed, state is
+	// OPEN, to token is a reserve token, and order limits not exceeded
+	if bond.FunctionType != types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if !bond.AllowSells {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+	} else if bond.State != types.OpenState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if _, err := bond.GetSwapToToken(msg.ToToken); err != nil {
+		return nil, err
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
 	}
 
-	// Get number of bond tokens owned by the recipient
-	bondTokensOwnedAmount := keeper.BankKeeper.GetCoins(ctx, msg.Recipient).AmountOf(msg.BondToken)
-	if bondTokensOwnedAmount.IsZero() {
-		return nil, sdkerrors.Wrap(types.ErrNoBondTokensOwned, bondTokensOwnedAmount.String())
+	// Cannot burn more tokens than what exists
+	adjustedSupply := keeper.GetSupplyAdjustedForSell(ctx, token)
+	if adjustedSupply.IsLT(msg.Amount) {
+		return nil, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, adjustedSupply.String())
 	}
-	bondTokensOwned := sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)
 
-	// Send coins to be burned from recipient
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(
-		ctx, msg.Recipient, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensOwned))
+	// Send coins to be burned from provider (enforces amount <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Provider,
+		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
 	if err != nil {
 		return nil, err
 	}
 
 	// Burn bond tokens
 	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
-		sdk.NewCoins(sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)))
+		sdk.Coins{msg.Amount})
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate amount owned
-	remainingReserve := keeper.GetReserveBalances(ctx, bond.Token)
-	bondTokensShare := bondTokensOwnedAmount.ToDec().QuoInt(bond.CurrentSupply.Amount)
-	reserveOwedDec := sdk.NewDecCoinsFromCoins(remainingReserve...).MulDec(bondTokensShare)
-	reserveOwed, _ := reserveOwedDec.TruncateDecimal()
+	// Create order and add it to batch
+	order := types.NewRemoveLiquidityOrder(msg.Provider, msg.Amount, msg.ToToken, msg.MinOutput)
+	orderID := keeper.AddRemoveLiquidityOrder(ctx, token, order)
 
-	// Send coins owed to recipient
-	err = keeper.WithdrawReserve(ctx, bond.Token, msg.Recipient, reserveOwed)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRemoveLiquidity,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyMinOutput, msg.MinOutput.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelOrder) (*sdk.Result, error) {
+
+	if !keeper.BondExists(ctx, msg.BondToken) {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Cancel order (checks that order exists and belongs to msg.Address)
+	err := keeper.CancelOrder(ctx, msg.BondToken, msg.OrderType,
+		msg.OrderID, msg.Address, "cancelled by order owner")
 	if err != nil {
 		return nil, err
 	}
 
-	// Update supply
-	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Sub(bondTokensOwned))
+	// Cancel orders made unfulfillable by the change in batch prices
+	keeper.CancelUnfulfillableOrders(ctx, msg.BondToken)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
-			types.EventTypeWithdrawShare,
+			types.EventTypeCancelOrder,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
-			sdk.NewAttribute(types.AttributeKeyAddress, msg.Recipient.String()),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, reserveOwed.String()),
+			sdk.NewAttribute(types.AttributeKeyOrderType, msg.OrderType),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(msg.OrderID, 10)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgLimitBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgLimitBuy) (*sdk.Result, error) {
+
+	token := msg.Amount.Denom
+	bond, found := keeper.GetBond(ctx, token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	// For the swapper, the first buy (the initialisation of the reserves)
+	// has to be a MsgBuy since limit prices alone do not define a price
+	if bond.CurrentSupply.IsZero() && bond.FunctionType == types.SwapperFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionRequiresNonZeroCurrentSupply, bond.CurrentSupply.Amount.String())
+	}
+
+	// Create order (calculates the max prices to be held in escrow)
+	order := types.NewLimitBuyOrder(bond, msg.Buyer, msg.Amount,
+		msg.MaxPricesPerToken, msg.ExpiryHeight)
+
+	// Check current state is HATCH/OPEN, max prices, order quantity limits,
+	// and expiry height
+	if bond.State != types.OpenState && bond.State != types.HatchState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if !bond.ReserveDenomsEqualTo(order.MaxPrices) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MaxPricesPerToken.String(), strings.Join(bond.ReserveTokens, ","))
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	} else if order.IsExpired(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrap(types.ErrExpiryHeightAlreadyReached, strconv.FormatInt(msg.ExpiryHeight, 10))
+	}
+
+	// Take max prices into escrow (enforces maxPrices <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer,
+		types.BatchesIntermediaryAccount, order.MaxPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add limit order to limit order book
+	orderID := keeper.AddLimitOrder(ctx, token, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeLimitBuy,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxPricesPerToken, msg.MaxPricesPerToken.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxPrices, order.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(msg.ExpiryHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgLimitSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgLimitSell) (*sdk.Result, error) {
+
+	token := msg.Amount.Denom
+	bond, found := keeper.GetBond(ctx, token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	}
+
+	// Create order (calculates the min returns at the min prices per token)
+	order := types.NewLimitSellOrder(bond, msg.Seller, msg.Amount,
+		msg.MinPricesPerToken, msg.ExpiryHeight)
+
+	// Check sells allowed, current state is OPEN, min prices, order quantity
+	// limits, and expiry height
+	reservePrices := types.MultiplyDecCoinsByInt(msg.MinPricesPerToken, msg.Amount.Amount)
+	if !bond.AllowSells {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, token)
+	} else if bond.State != types.OpenState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if !bond.ReserveDenomsEqualTo(types.RoundReservePrices(reservePrices)) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MinPricesPerToken.String(), strings.Join(bond.ReserveTokens, ","))
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	} else if order.IsExpired(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrap(types.ErrExpiryHeightAlreadyReached, strconv.FormatInt(msg.ExpiryHeight, 10))
+	}
+
+	// Withdraw any of the seller's bond tokens that have vested from the
+	// vesting escrow, so that these can be sold (unvested tokens stay locked)
+	if bond.HasVesting() {
+		_, err := keeper.WithdrawVested(ctx, token, msg.Seller)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Take bond tokens to be sold into escrow (enforces sellAmount <= balance).
+	// These are only burned once the limit sell is filled
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+		types.BatchesIntermediaryAccount, sdk.Coins{msg.Amount})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add limit order to limit order book
+	orderID := keeper.AddLimitOrder(ctx, token, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeLimitSell,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinPricesPerToken, msg.MinPricesPerToken.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, order.MinReturns.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(msg.ExpiryHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Seller.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelLimitOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelLimitOrder) (*sdk.Result, error) {
+
+	if !keeper.BondExists(ctx, msg.BondToken) {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Cancel limit order (checks that order exists and belongs to msg.Address)
+	err := keeper.CancelLimitOrder(ctx, msg.BondToken, msg.OrderID,
+		msg.Address, "cancelled by order owner")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelLimitOrder,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(msg.OrderID, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgMakeOutcomePayment) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Confirm that state is OPEN and that outcome payment is not nil
+	if bond.State != types.OpenState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if bond.OutcomePayment.Empty() {
+		return nil, types.ErrCannotMakeZeroOutcomePayment
+	}
+
+	// If no amount is specified, pay the remaining outcome payment. Otherwise,
+	// check that the amount is in the outcome payment's denominations and that
+	// it does not exceed the remaining outcome payment
+	remaining := bond.GetOutcomePaymentRemaining()
+	amount := msg.Amount
+	if amount.Empty() {
+		amount = remaining
+	}
+	for _, c := range amount {
+		if bond.OutcomePayment.AmountOf(c.Denom).IsZero() {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "%s is not in the outcome payment denominations; expected: %s", c.Denom, bond.OutcomePayment.String())
+		}
+	}
+	if !remaining.IsAllGTE(amount) {
+		return nil, sdkerrors.Wrapf(types.ErrOutcomePaymentExceeded, "%s exceeds remaining outcome payment %s", amount.String(), remaining.String())
+	}
+
+	// Send outcome payment to reserve and record the contribution
+	err := keeper.MakeOutcomePayment(ctx, bond.Token, msg.Sender, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set bond state to SETTLE if the full outcome payment has been made
+	bond = keeper.MustGetBond(ctx, bond.Token)
+	if bond.OutcomePaymentReached() {
+		err = keeper.SettleBond(ctx, bond.Token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("outcome payment of %s made to bond %s by %s",
+		amount.String(), msg.BondToken, msg.Sender.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeMakeOutcomePayment,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Sender.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOutcomePaymentReceived, bond.OutcomePaymentReceived.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgFinaliseOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgFinaliseOutcomePayment) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	}
+
+	// Confirm that state is OPEN and that an outcome payment has been made
+	if bond.State != types.OpenState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if bond.OutcomePaymentReceived.IsZero() {
+		return nil, types.ErrNoOutcomePaymentMade
+	}
+
+	// Set bond state to SETTLE even if the full outcome payment was not made
+	err := keeper.SettleBond(ctx, bond.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("outcome payment of bond %s finalised at %s by %s",
+		msg.BondToken, bond.OutcomePaymentReceived.String(), msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFinaliseOutcomePayment,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyOutcomePaymentReceived, bond.OutcomePaymentReceived.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawShare) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check that state is SETTLE or FAILED
+	if bond.State != types.SettleState && bond.State != types.FailedState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	}
+
+	// Withdraw any of the recipient's bond tokens held in the vesting escrow,
+	// which are all withdrawable once the bond has settled or failed
+	if bond.HasVesting() {
+		_, err := keeper.WithdrawVested(ctx, bond.Token, msg.Recipient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get number of bond tokens owned by the recipient
+	bondTokensOwnedAmount := keeper.BankKeeper.GetCoins(ctx, msg.Recipient).AmountOf(msg.BondToken)
+	if bondTokensOwnedAmount.IsZero() {
+		return nil, sdkerrors.Wrap(types.ErrNoBondTokensOwned, bondTokensOwnedAmount.String())
+	}
+	bondTokensOwned := sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)
+
+	// Send coins to be burned from recipient
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(
+		ctx, msg.Recipient, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensOwned))
+	if err != nil {
+		return nil, err
+	}
+
+	// Burn bond tokens
+	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
+		sdk.NewCoins(sdk.NewCoin(msg.BondToken, bondTokensOwnedAmount)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate amount owned
+	remainingReserve := keeper.GetReserveBalances(ctx, bond.Token)
+	bondTokensShare := bondTokensOwnedAmount.ToDec().QuoInt(bond.CurrentSupply.Amount)
+	reserveOwedDec := sdk.NewDecCoinsFromCoins(remainingReserve...).MulDec(bondTokensShare)
+	reserveOwed, _ := reserveOwedDec.TruncateDecimal()
+
+	// Send coins owed to recipient
+	err = keeper.WithdrawReserve(ctx, bond.Token, msg.Recipient, reserveOwed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update supply
+	keeper.SetCurrentSupply(ctx, bond.Token, bond.CurrentSupply.Sub(bondTokensOwned))
+
+	// Update recipient's position
+	position := keeper.GetPosition(ctx, bond.Token, msg.Recipient)
+	keeper.SetPosition(ctx, position.AddShareWithdrawal(bondTokensOwned, reserveOwed))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawShare,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Recipient.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, reserveOwed.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawVested(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawVested) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Withdraw bond tokens that have vested from the vesting escrow
+	amount, err := keeper.WithdrawVested(ctx, bond.Token, msg.Recipient)
+	if err != nil {
+		return nil, err
+	} else if amount.IsZero() {
+		return nil, sdkerrors.Wrap(types.ErrInsufficientVestedTokens,
+			"no vested bond tokens to withdraw")
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgPauseBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgPauseBond) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	}
+
+	err := keeper.PauseBond(ctx, bond.Token, types.PausedBySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s paused by %s",
+		msg.BondToken, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypePauseBond,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgResumeBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgResumeBond) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	}
+
+	err := keeper.ResumeBond(ctx, bond.Token, types.PausedBySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s resumed by %s",
+		msg.BondToken, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeResumeBond,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCloseBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCloseBond) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	}
+
+	// Confirm that state is HATCH, OPEN, or PAUSED
+	if bond.State != types.HatchState && bond.State != types.OpenState &&
+		bond.State != types.PausedState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	}
+
+	// Cancel any pending orders and limit orders, returning the tokens held
+	// for them
+	err := keeper.CancelAllOrders(ctx, bond.Token, "bond closed")
+	if err != nil {
+		return nil, err
+	}
+	err = keeper.CancelAllLimitOrders(ctx, bond.Token, "bond closed")
+	if err != nil {
+		return nil, err
+	}
+
+	// Move any funding share of the hatch phase held in escrow to the reserve
+	err = keeper.MoveHatchEscrowToReserve(ctx, bond.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move any partial outcome payment held in escrow to the reserve
+	err = keeper.MoveOutcomePaymentEscrowToReserve(ctx, bond.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set bond state to SETTLE, so that holders can withdraw their share
+	keeper.SetBondState(ctx, bond.Token, types.SettleState)
+	if bond.State == types.PausedState {
+		bond = keeper.MustGetBond(ctx, bond.Token)
+		bond.StateBeforePause = ""
+		bond.PausedBy = ""
+		keeper.SetBond(ctx, bond.Token, bond)
+
+		// Schedule the batch of the previously paused bond, if it has any
+		// (now cancelled) orders, so that the batch gets settled
+		if keeper.BatchHasOrders(ctx, bond.Token) {
+			keeper.ScheduleBatch(ctx, bond.Token)
+		}
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s closed by %s",
+		msg.BondToken, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCloseBond,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawFunding(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawFunding) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	// Check that the bond has a funding pool and that the beneficiary is the
+	// bond's fee address
+	if !bond.HasFundingPool() {
+		return nil, sdkerrors.Wrap(types.ErrBondHasNoFundingPool, msg.BondToken)
+	} else if !bond.FeeAddress.Equals(msg.Beneficiary) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "beneficiary is not the bond's fee address")
+	}
+
+	err := keeper.WithdrawFunding(ctx, bond.Token, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawFunding,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyBeneficiary, msg.Beneficiary.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Beneficiary.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSetFundingTap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSetFundingTap) (*sdk.Result, error) {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, msg.BondToken)
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the bond")
+	}
+
+	// Check that bond is augmented and that funding tap is in reserve tokens
+	if bond.FunctionType != types.AugmentedFunction {
+		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	}
+	for _, c := range msg.FundingTap {
+		isReserveToken := false
+		for _, rt := range bond.ReserveTokens {
+			isReserveToken = isReserveToken || c.Denom == rt
+		}
+		if !isReserveToken {
+			return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s is not a reserve token; expected: %s", c.Denom, strings.Join(bond.ReserveTokens, ","))
+		}
+	}
+
+	// Any funding already withdrawn in the current period still applies
+	bond.FundingTap = msg.FundingTap
+	bond.FundingTapBlocks = msg.FundingTapBlocks
+	keeper.SetBond(ctx, bond.Token, bond)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("funding tap of bond %s set to %s per %d blocks by %s",
+		msg.BondToken, msg.FundingTap.String(), msg.FundingTapBlocks, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetFundingTap,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyFundingTap, msg.FundingTap.String()),
+			sdk.NewAttribute(types.AttributeKeyFundingTapBlocks, strconv.FormatInt(msg.FundingTapBlocks, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
 		),
 	})
 
@@ -2,10 +2,12 @@ package bonds_test
 
 import (
 	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/app"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/stretchr/testify/require"
 )
@@ -435,6 +437,196 @@ func TestBuyingABondCorrectlyPasses(t *testing.T) {
 	require.Equal(t, sdk.NewInt(232), reserveBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
+
+	position := app.BondsKeeper.GetPosition(ctx, token, userAddress)
+	require.Equal(t, sdk.NewInt(2), position.TokensBought.Amount)
+	require.Equal(t, sdk.NewInt(232), position.ReservePaid.AmountOf(reserveToken))
+	require.Equal(t, sdk.OneInt(), position.FeesPaid.AmountOf(reserveToken))
+}
+
+func TestBuyingWithReserveANonExistingBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Buy with reserve
+	_, err := h(ctx, newValidMsgBuyWithReserve(1000))
+
+	require.Error(t, err)
+	require.False(t, app.BondsKeeper.BondExists(ctx, token))
+}
+
+func TestBuyingWithReserveWithInvalidBudgetDenomsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken2, 1000)})
+	require.Nil(t, err)
+
+	// Buy with reserve, with a budget in a non-reserve token
+	msg := newValidMsgBuyWithReserve(0) // budget replaced below
+	msg.Budget = sdk.Coins{sdk.NewInt64Coin(reserveToken2, 1000)}
+	_, err = h(ctx, msg)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(1000), userBalance.AmountOf(reserveToken2))
+}
+
+func TestBuyingWithReserveFromUninitialisedSwapperFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	budget := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 1000),
+	)
+	err := addCoinsToUser(app, ctx, budget)
+	require.Nil(t, err)
+
+	// Buy with reserve (swapper needs to first be initialised using a buy)
+	msg := newValidMsgBuyWithReserve(0) // budget replaced below
+	msg.Budget = budget
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
+}
+
+func TestBuyingWithReserveCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+
+	// Buy with a budget of 1000; 4 tokens cost 656 + 1 fee, 5 tokens would
+	// cost 1000 + 1 fee, so 4 tokens are bought and the remainder returned
+	_, err = h(ctx, newValidMsgBuyWithReserve(1000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(343), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(4), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(656), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(4), currentSupply.Amount)
+
+	// Check that the amount bought is recorded in the last batch
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Len(t, lastBatch.BuysWithReserve, 1)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), lastBatch.BuysWithReserve[0].Amount)
+}
+
+func TestBuyingWithReserveWithInsufficientBudgetGetsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100)})
+	require.Nil(t, err)
+
+	// Buy with a budget of 100; 1 token costs 104 + 1 fee, so none bought
+	_, err = h(ctx, newValidMsgBuyWithReserve(100))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(100), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+
+	// Check that the order was cancelled
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	require.Len(t, lastBatch.BuysWithReserve, 1)
+	require.True(t, lastBatch.BuysWithReserve[0].Cancelled)
+}
+
+func TestBuyingWithReserveFromSwapperCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens to initialise swapper (1 token costs 5000res,5000rez)
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Buy with a budget of 16000res,16000rez; 3 tokens cost 15000 + 15 fee
+	// of each reserve token, 4 tokens would cost 20000 + 20 fee
+	msg := newValidMsgBuyWithReserve(0) // budget replaced below
+	msg.Budget = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 16000),
+		sdk.NewInt64Coin(reserveToken2, 16000),
+	)
+	_, err = h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(74985), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(74985), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(5), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(25000), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(25000), reserveBalance.AmountOf(reserveToken2))
+}
+
+func TestBuyingWithReserveFromAugmentedInHatchIsLimitedToS0(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond (S0 = d0/p0 = 50000)
+	h(ctx, newValidMsgCreateAugmentedBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+
+	// Buy with a budget of 1000; at p0=0.01 this would be enough for more
+	// than S0 tokens, so only S0 tokens are bought (500 + 1 fee)
+	_, err = h(ctx, newValidMsgBuyWithReserve(1000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(499), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(50000), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(50000), bond.CurrentSupply.Amount)
+	require.Equal(t, types.OpenState, bond.State)
 }
 
 func TestSellingANonExistingBondFails(t *testing.T) {
@@ -613,6 +805,93 @@ func TestSellingABondCorrectlyPasses(t *testing.T) {
 	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.NewInt(3), feeBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+
+	position := app.BondsKeeper.GetPosition(ctx, token, userAddress)
+	require.Equal(t, sdk.NewInt(2), position.TokensBought.Amount)
+	require.Equal(t, sdk.NewInt(2), position.TokensSold.Amount)
+	require.Equal(t, sdk.NewInt(232), position.ReservePaid.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(230), position.ReserveReceived.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(3), position.FeesPaid.AmountOf(reserveToken))
+}
+
+func TestSellingABondWithUnmetMinReturnsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens with min returns greater than possible returns
+	msg := newValidMsgSell(2)
+	msg.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)}
+	_, err = h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Error(t, err)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
+}
+
+func TestSellingABondWithMetMinReturnsPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens with min returns less than expected returns
+	msg := newValidMsgSell(2)
+	msg.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken, 200)}
+	_, err = h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(3997), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+}
+
+func TestSellingABondWithInvalidMinReturnsDenomsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens with min returns in a denom that is not a reserve
+	msg := newValidMsgSell(2)
+	msg.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken2, 1)}
+	_, err = h(ctx, msg)
+
+	require.Error(t, err)
 }
 
 func TestSwapBondDoesNotExistFails(t *testing.T) {
@@ -694,7 +973,7 @@ func TestSwapOrderQuantityLimitExceededFails(t *testing.T) {
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
 	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, sdk.NewInt64Coin(reserveToken, 5), reserveToken2)
+	msg := newValidMsgSwap(reserveToken, reserveToken2, 5)
 	_, err = h(ctx, msg)
 
 	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
@@ -725,7 +1004,7 @@ func TestSwapInvalidAmount(t *testing.T) {
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
 	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, tenReserveTokens, reserveToken2)
+	msg := newValidMsgSwap(reserveToken, reserveToken2, tenReserveTokens.Amount.Int64())
 	_, err = h(ctx, msg)
 
 	require.Error(t, err)
@@ -769,9 +1048,17 @@ func TestSwapValidAmount(t *testing.T) {
 	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken))
 	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken2))
 	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+
+	// Position includes both the initial buy and the swap
+	position := app.BondsKeeper.GetPosition(ctx, token, userAddress)
+	require.Equal(t, sdk.NewInt(2), position.TokensBought.Amount)
+	require.Equal(t, sdk.NewInt(10009), position.ReservePaid.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10000), position.ReservePaid.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(8), position.ReserveReceived.AmountOf(reserveToken2))
+	require.Equal(t, sdk.OneInt(), position.FeesPaid.AmountOf(reserveToken))
 }
 
-func TestSwapValidAmountReversed(t *testing.T) {
+func TestInvariantsHoldAcrossSwapperOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
@@ -795,56 +1082,1153 @@ func TestSwapValidAmountReversed(t *testing.T) {
 	h(ctx, buyMsg)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Perform swap
-	_, err = h(ctx, newValidMsgSwap(reserveToken2, reserveToken, 10))
+	// Place buy, sell, and swap orders; invariants hold with pending orders
+	buyMsg = newValidMsgBuy(2, 0)
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 20000),
+		sdk.NewInt64Coin(reserveToken2, 20000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgSell(1))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	require.NoError(t, err)
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Invariants hold after the orders are performed
 	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, broken = bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
 
-	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
-	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
-	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken2))
-	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
+	// Decreasing the reserve breaks the swapper invariant
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.CurrentReserve = bond.CurrentReserve.Sub(
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, broken = bonds.SwapperInvariant(app.BondsKeeper)(ctx)
+	require.True(t, broken)
 }
 
-func TestMakeOutcomePayment(t *testing.T) {
+func TestSwapWithUnmetMinOutputGetsCancelled(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
-	// Create bond with 100k outcome payment
-	bondMsg := newValidMsgCreateBond()
-	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
-	h(ctx, bondMsg)
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
 	require.Nil(t, err)
 
-	// Make outcome payment
-	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
 
-	// Check that outcome payment is now in the bond reserve
+	// Perform swap with min output greater than the actual output (8)
+	msg := newValidMsgSwap(reserveToken, reserveToken2, 10)
+	msg.MinOutput = sdk.NewInt64Coin(reserveToken2, 9)
+	_, err = h(ctx, msg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Swap order is cancelled and from amount returned to the swapper
 	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
 	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
 	require.NoError(t, err)
-	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
-
-	// Check that the bond is now in SETTLE state
-	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken2))
+	require.True(t, feeBalance.AmountOf(reserveToken).IsZero())
 }
 
-func TestWithdrawShare(t *testing.T) {
+func TestSwapValidAmountReversed(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
 
 	// Create bond
-	h(ctx, newValidMsgCreateBond())
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap
+	_, err = h(ctx, newValidMsgSwap(reserveToken2, reserveToken, 10))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(90008), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(89990), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(9992), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10009), reserveBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
+}
+
+func TestCancelOrderNonExistingBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	_, err := h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+}
+
+func TestCancelOrderNonExistingOrderFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	_, err := h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.Error(t, err)
+	require.True(t, types.ErrOrderDoesNotExist.Is(err))
+}
+
+func TestCancelOrderNotOwnedBySenderFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+
+	// Cancel order from another address
+	msg := newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0)
+	msg.Address = anotherAddress
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+}
+
+func TestCancelOrderByID(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user and another address
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+	_, err = app.BankKeeper.AddCoins(ctx, anotherAddress,
+		sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Another address buys 1 token (order 0) and user buys 1 token (order 1)
+	anotherBuyMsg := newValidMsgBuy(1, 4000)
+	anotherBuyMsg.Buyer = anotherAddress
+	_, err = h(ctx, anotherBuyMsg)
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgBuy(1, 4000))
+	require.NoError(t, err)
+
+	// User cannot cancel order 0, which belongs to another address
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// User cannot cancel order 1 as an order of another type
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueSellOrder, 1))
+	require.True(t, types.ErrOrderDoesNotExist.Is(err))
+
+	// User can cancel order 1
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 1))
+	require.NoError(t, err)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.False(t, batch.Buys[0].IsCancelled())
+	require.True(t, batch.Buys[1].IsCancelled())
+	require.Equal(t, sdk.NewInt(4000), app.BankKeeper.GetCoins(
+		ctx, userAddress).AmountOf(reserveToken))
+}
+
+func TestCancelOrderAlreadyCancelledFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+
+	// Cancel order twice
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.Error(t, err)
+	require.True(t, types.ErrOrderAlreadyCancelled.Is(err))
+}
+
+func TestCancelBuyOrderCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens and cancel the order
+	h(ctx, newValidMsgBuy(2, 4000))
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueBuyOrder, 0))
+	require.NoError(t, err)
+
+	// Check that max prices were returned and batch updated
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.True(t, batch.Buys[0].IsCancelled())
+	require.True(t, batch.TotalBuyAmount.IsZero())
+
+	// Check that nothing was bought
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
+}
+
+func TestCancelSellOrderCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Sell 2 tokens and cancel the order
+	h(ctx, newValidMsgSell(2))
+	orderID := app.BondsKeeper.MustGetBatch(ctx, token).Sells[0].ID
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueSellOrder, orderID))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Check that bond tokens were returned and nothing was sold
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	currentSupply := app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt(3767), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
+}
+
+func TestCancelSwapOrderCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Perform swap and cancel the order
+	h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	orderID := app.BondsKeeper.MustGetBatch(ctx, token).Swaps[0].ID
+	_, err = h(ctx, newValidMsgCancelOrder(types.AttributeValueSwapOrder, orderID))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Check that swap amount was returned and nothing was swapped
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(10000), reserveBalance.AmountOf(reserveToken2))
+}
+
+// createSwapRouteBonds creates a res/rez swapper bond and a rez/rex swapper
+// bond, each initialised with 10000 of each of its reserve tokens, and gives
+// the user 100000 of each reserve token before the bonds are initialised.
+func createSwapRouteBonds(t *testing.T, app *simapp.SimApp, ctx sdk.Context, h sdk.Handler) {
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+		sdk.NewInt64Coin(reserveToken3, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	createMsg := newValidMsgCreateSwapperBond()
+	_, err = h(ctx, createMsg)
+	require.NoError(t, err)
+	createMsg.Token = token2
+	createMsg.MaxSupply = sdk.NewCoin(token2, initMaxSupply.Amount)
+	createMsg.ReserveTokens = []string{reserveToken2, reserveToken3}
+	_, err = h(ctx, createMsg)
+	require.NoError(t, err)
+
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	buyMsg.Amount = sdk.NewInt64Coin(token2, 2)
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 10000),
+		sdk.NewInt64Coin(reserveToken3, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+}
+
+func TestSwapRouteInvalidRouteFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapRouteBonds(t, app, ctx, h)
+
+	// Hops in the wrong order
+	msg := newValidMsgSwapRoute([]string{token2, token}, reserveToken, 100,
+		sdk.NewInt64Coin(reserveToken3, 0))
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidSwapRoute.Is(err))
+
+	// Route does not end with the min output token
+	msg = newValidMsgSwapRoute([]string{token}, reserveToken, 100,
+		sdk.NewInt64Coin(reserveToken3, 0))
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidSwapRoute.Is(err))
+
+	// Bond does not exist
+	msg = newValidMsgSwapRoute([]string{token, "nonexistent"}, reserveToken, 100,
+		sdk.NewInt64Coin(reserveToken3, 0))
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+}
+
+func TestSwapRouteValidAmount(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapRouteBonds(t, app, ctx, h)
+
+	// Swap 1000res to rex through the two bonds
+	msg := newValidMsgSwapRoute([]string{token, token2}, reserveToken, 1000,
+		sdk.NewInt64Coin(reserveToken3, 831))
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// First hop: 999res*10000rez/(10000res+999res) = 908rez, which are added as
+	// a swap order to the second bond's batch rather than given to the user
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(89000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(80000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken3))
+	swaps := app.BondsKeeper.MustGetBatch(ctx, token2).Swaps
+	require.Len(t, swaps, 1)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken2, 908), swaps[0].Amount)
+	require.Equal(t, reserveToken3, swaps[0].ToToken)
+	require.False(t, swaps[0].IsRouted())
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Second hop: 907rez*10000rex/(10000rez+907rez) = 831rex
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.Equal(t, sdk.NewInt(89000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(80000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(90831), userBalance.AmountOf(reserveToken3))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
+	_, broken = bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestSwapRouteWithUnmetMinOutputGetsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapRouteBonds(t, app, ctx, h)
+
+	// Min output greater than the output through the route (831rex)
+	msg := newValidMsgSwapRoute([]string{token, token2}, reserveToken, 1000,
+		sdk.NewInt64Coin(reserveToken3, 832))
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Swap is cancelled at the first hop and the from amount returned
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(80000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken3))
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token2))
+}
+
+func TestSwapRouteCancelledAtLaterHopReturnsIntermediateToken(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapRouteBonds(t, app, ctx, h)
+
+	// Min output exactly met by the quote through the route (831rex)
+	msg := newValidMsgSwapRoute([]string{token, token2}, reserveToken, 1000,
+		sdk.NewInt64Coin(reserveToken3, 831))
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Another swap in the second bond's batch worsens the rate for the hop
+	err = addCoinsToUser2(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 1000)))
+	require.Nil(t, err)
+	otherSwap := newValidMsgSwap(reserveToken2, reserveToken3, 1000)
+	otherSwap.Swapper = anotherAddress
+	otherSwap.BondToken = token2
+	_, err = h(ctx, otherSwap)
+	require.NoError(t, err)
+
+	// Second hop is cancelled and the user gets the 908rez from the first hop
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(89000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(80908), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken3))
+	otherBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
+	require.True(t, otherBalance.AmountOf(reserveToken3).IsPositive())
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestSwapRouteCancelledByClosingLaterBondReturnsIntermediateToken(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createSwapRouteBonds(t, app, ctx, h)
+
+	// Swap 1000res to rex through the two bonds; first hop gives 908rez
+	msg := newValidMsgSwapRoute([]string{token, token2}, reserveToken, 1000,
+		sdk.NewInt64Coin(reserveToken3, 831))
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BondsKeeper.BatchHasOrders(ctx, token2))
+
+	// Second bond is closed while the second hop is pending
+	closeMsg := newValidMsgCloseBond()
+	closeMsg.BondToken = token2
+	_, err = h(ctx, closeMsg)
+	require.NoError(t, err)
+
+	// Second hop is cancelled and the user gets the 908rez from the first
+	// hop; the 1res fee charged by the first hop is not refunded
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.Equal(t, sdk.NewInt(89000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(80908), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken3))
+	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken))
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+// createLiquidityBond creates a res/rez swapper bond with a supply of 100
+// tokens, all held by the user, and with 10000res and 10000rez in its reserve.
+func createLiquidityBond(t *testing.T, app *simapp.SimApp, ctx sdk.Context, h sdk.Handler) {
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	_, err = h(ctx, newValidMsgCreateSwapperBond())
+	require.NoError(t, err)
+
+	buyMsg := newValidMsgBuy(100, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+}
+
+func TestAddLiquidityInvalidDepositFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Deposit in a token that is not a reserve token
+	msg := newValidMsgAddLiquidity(sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken3, 1000)), 0)
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrReserveDenomsMismatch.Is(err))
+
+	// Deposit more than the user's balance
+	msg = newValidMsgAddLiquidity(sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000)), 0)
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+}
+
+func TestAddLiquidityToNonSwapperFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	msg := newValidMsgAddLiquidity(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)), 0)
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestAddLiquiditySingleSided(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Add 1000res with a min amount of 4 tokens
+	msg := newValidMsgAddLiquidity(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)), 4)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// 488res are swapped for 487res*10000rez/(10000res+487res) = 464rez, and
+	// the remaining 512res and the 464rez buy 4 tokens for 420res+1res fee and
+	// 382rez+1rez fee, with the remainder being returned to the user
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.Equal(t, sdk.NewInt(104), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(90000-1000+91), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000+81), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(1), feeBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt64Coin(token, 104),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestAddLiquidityWithUnmetMinAmountGetsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Min amount greater than the amount that can be bought (4 tokens)
+	msg := newValidMsgAddLiquidity(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)), 5)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Order is cancelled after its swap, so the user gets back the 512res
+	// that were not swapped and the 464rez returned by the swap
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(100), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(90000-1000+512), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000+464), userBalance.AmountOf(reserveToken2))
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestRemoveLiquiditySingleSided(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Remove 10 tokens to res, with a min output of 1895res
+	msg := newValidMsgRemoveLiquidity(10, reserveToken, 1895)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Tokens are burned immediately
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(90), userBalance.AmountOf(token))
+
+	// Burning 10 tokens returns 998res and 998rez (after fees of 2 of each),
+	// and the 998rez are swapped for 997rez*9000res/(9000rez+997rez) = 897res
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	feeBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	require.Equal(t, sdk.NewInt(90), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(90000+1895), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(3), feeBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt64Coin(token, 90),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestRemoveLiquidityWithFailingSwapGetsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Set a sanity rate of 1res per rez with a 10% margin, so that swapping
+	// the 998rez returned by burning 10 tokens for 897res (reserve ratio of
+	// 8103res/9997rez = 0.81) violates the sanity rate
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	bond.SanityRate = sdk.OneDec()
+	bond.SanityMarginPercentage = sdk.NewDec(10)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	reserveBefore := app.BondsKeeper.GetReserveBalances(ctx, token)
+
+	// Remove 10 tokens to res, without a min output
+	_, err := h(ctx, newValidMsgRemoveLiquidity(10, reserveToken, 0))
+	require.NoError(t, err)
+
+	// Whole order is cancelled, rather than the user getting 998res and the
+	// 998rez that could not be swapped, and the burned tokens are returned
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(100), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, reserveBefore, app.BondsKeeper.GetReserveBalances(ctx, token))
+	require.Equal(t, sdk.NewInt64Coin(token, 100),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	require.True(t, app.BondsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress).IsZero())
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestRemoveLiquidityPendingKeepsInvariants(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Remove 10 tokens, which are burned immediately but only deducted from
+	// the bond's supply once the order is performed
+	_, err := h(ctx, newValidMsgRemoveLiquidity(10, reserveToken, 0))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin(token, 100),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	require.Equal(t, sdk.NewInt64Coin(token, 90),
+		app.BondsKeeper.GetSupplyAdjustedForSell(ctx, token))
+
+	// Invariants hold while the order is pending
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// The pending burn is taken into account when burning more tokens
+	_, err = h(ctx, newValidMsgRemoveLiquidity(91, reserveToken, 0))
+	require.Error(t, err)
+	require.True(t, types.ErrCannotBurnMoreThanSupply.Is(err))
+
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt64Coin(token, 90),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	_, broken = bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestRemoveLiquidityWithUnmetMinOutputGetsCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	createLiquidityBond(t, app, ctx, h)
+
+	// Min output greater than the estimated output (1895res)
+	msg := newValidMsgRemoveLiquidity(10, reserveToken, 1896)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Order is cancelled and the burned tokens are returned to the user
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(100), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(90000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt64Coin(token, 100),
+		app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply)
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestLimitBuyWithExpiryHeightAlreadyReachedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Place limit buy that expires at the current height
+	ctx = ctx.WithBlockHeight(5)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 120, 5))
+	require.Error(t, err)
+	require.True(t, types.ErrExpiryHeightAlreadyReached.Is(err))
+}
+
+func TestLimitBuyFilledWhenPriceReached(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Limit buy 2 tokens at 120res per token; 241res (240res + 1res fee) held
+	_, err = h(ctx, newValidMsgLimitBuy(2, 120, 0))
+	require.NoError(t, err)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3759), userBalance.AmountOf(reserveToken))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 1)
+
+	// Buy price is 116res per token, so the order is filled for 232res + 1res fee
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3767), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 0)
+	require.Equal(t, uint64(0), app.BondsKeeper.MustGetLastBatch(ctx, token).Buys[0].ID)
+
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestLimitBuyRestsUntilCancelled(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Limit buy 2 tokens at 100res per token; 201res (200res + 1res fee) held
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 0))
+	require.NoError(t, err)
+
+	// Buy price is 116res per token, so the order is not filled across batches
+	for i := 0; i < 3; i++ {
+		bonds.EndBlocker(ctx, app.BondsKeeper)
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	}
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3799), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.Amount)
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 1)
+	require.True(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
+
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Cancel limit order from another address fails
+	msg := newValidMsgCancelLimitOrder(0)
+	msg.Address = anotherAddress
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// Cancel limit order; escrow is returned
+	_, err = h(ctx, newValidMsgCancelLimitOrder(0))
+	require.NoError(t, err)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 0)
+
+	// Cancelling it again fails
+	_, err = h(ctx, newValidMsgCancelLimitOrder(0))
+	require.Error(t, err)
+	require.True(t, types.ErrOrderDoesNotExist.Is(err))
+}
+
+func TestLimitBuyExpires(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Limit buy 2 tokens at 100res per token, expiring at height 2
+	ctx = ctx.WithBlockHeight(1)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 2))
+	require.NoError(t, err)
+
+	// Order is not filled and rests until its expiry height
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 1)
+
+	// Order expires and escrow is returned
+	ctx = ctx.WithBlockHeight(2)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 0)
+	require.False(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
+}
+
+func TestLimitBuyDoesNotDisplaceOtherOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to users
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens with just enough max prices (232res + 1res fee)
+	_, err = h(ctx, newValidMsgBuy(2, 233))
+	require.NoError(t, err)
+
+	// Limit buy 1 token at 1000res per token from another address, which
+	// would push the buy price up to 136res per token if added to the batch
+	limitMsg := newValidMsgLimitBuy(1, 1000, 0)
+	limitMsg.Buyer = anotherAddress
+	_, err = h(ctx, limitMsg)
+	require.NoError(t, err)
+
+	// Buy is performed but the limit buy is left for the next batch
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 1)
+
+	// Limit buy is filled in the next batch for 176res + 1res fee
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	anotherBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
+	require.Equal(t, sdk.NewInt(1), anotherBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(3823), anotherBalance.AmountOf(reserveToken))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 0)
+}
+
+func TestLimitSellFilledWhenPriceReached(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Limit sell 2 tokens at 120res per token, which is not reached
+	_, err = h(ctx, newValidMsgLimitSell(2, 120, 0))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(2), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.Amount)
+
+	// Cancel it and limit sell 2 tokens at 110res per token instead
+	_, err = h(ctx, newValidMsgCancelLimitOrder(1))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgLimitSell(2, 110, 0))
+	require.NoError(t, err)
+
+	// Sell price is 116res per token, so the order is filled, and the tokens
+	// held in escrow are burned
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3997), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(token))
+	require.Equal(t, sdk.ZeroInt(), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.Amount)
+	require.Equal(t, sdk.ZeroInt(), app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(token))
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token), 0)
+
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestMakeOutcomePayment(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+
+	// Make outcome payment
+	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Check that outcome payment is now in the bond reserve
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+
+	// Check that the bond is now in SETTLE state
+	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+}
+
+func TestMakePartialOutcomePayments(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// Add reserve tokens to both users
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+
+	// User 1 makes two partial outcome payments, user 2 makes one
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(10000))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(20000))
+	require.NoError(t, err)
+	msg := newValidMsgMakeOutcomePaymentOf(30000)
+	msg.Sender = anotherAddress
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+
+	// Bond is still OPEN and the total received is recorded
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+	require.Equal(t, sdk.NewInt(60000), bond.OutcomePaymentReceived.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(40000), bond.GetOutcomePaymentRemaining().AmountOf(reserveToken))
+
+	// Contributions are recorded per payer
+	contribution1 := app.BondsKeeper.GetOutcomePaymentContribution(ctx, token, userAddress)
+	contribution2 := app.BondsKeeper.GetOutcomePaymentContribution(ctx, token, anotherAddress)
+	require.Equal(t, sdk.NewInt(30000), contribution1.Amount.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(30000), contribution2.Amount.AmountOf(reserveToken))
+	require.Len(t, app.BondsKeeper.GetOutcomePaymentContributions(ctx, token), 2)
+
+	// Partial outcome payments are held in escrow, not in the bond reserve
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
+	require.Equal(t, sdk.NewInt(60000), app.BondsKeeper.
+		GetOutcomePaymentEscrowBalances(ctx, token).AmountOf(reserveToken))
+
+	// Paying more than the remaining outcome payment fails
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(40001))
+	require.Error(t, err)
+	require.True(t, types.ErrOutcomePaymentExceeded.Is(err))
+
+	// Paying in a denomination not in the outcome payment fails
+	msg = newValidMsgMakeOutcomePayment()
+	msg.Amount = sdk.NewCoins(sdk.NewInt64Coin(token, 1))
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+
+	// Paying the remaining outcome payment (no amount) settles the bond
+	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	require.NoError(t, err)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.SettleState, bond.State)
+	require.Equal(t, bond.OutcomePayment, bond.OutcomePaymentReceived)
+
+	// Check that the full outcome payment is now in the bond reserve
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, token)
+	require.Equal(t, sdk.NewInt(30000), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+	require.True(t, app.BondsKeeper.GetOutcomePaymentEscrowBalances(ctx, token).IsZero())
+}
+
+func TestSellAfterPartialOutcomePaymentDoesNotTakePayment(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// User 1 buys 2 tokens, adding 232res to the reserve
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// User 2 makes a partial outcome payment, which does not change the reserve
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 50000)})
+	require.Nil(t, err)
+	msg := newValidMsgMakeOutcomePaymentOf(50000)
+	msg.Sender = anotherAddress
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(232), app.BondsKeeper.
+		GetReserveBalances(ctx, token).AmountOf(reserveToken))
+
+	// User 1 sells both tokens and gets back the same amount (minus fees) that
+	// it would have got without the partial outcome payment
+	_, err = h(ctx, newValidMsgSell(2))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(3997), userBalance.AmountOf(reserveToken))
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
+
+	// The partial outcome payment is still held in escrow, and is only moved
+	// to the reserve once the outcome payment is finalised
+	require.Equal(t, sdk.NewInt(50000), app.BondsKeeper.
+		GetOutcomePaymentEscrowBalances(ctx, token).AmountOf(reserveToken))
+	_, err = h(ctx, newValidMsgFinaliseOutcomePayment())
+	require.NoError(t, err)
+	require.True(t, app.BondsKeeper.GetOutcomePaymentEscrowBalances(ctx, token).IsZero())
+	require.Equal(t, sdk.NewInt(50000), app.BondsKeeper.
+		GetReserveBalances(ctx, token).AmountOf(reserveToken))
+
+	_, broken := bonds.AllInvariants(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestFinaliseOutcomePayment(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// Finalising before any outcome payment is made fails
+	_, err := h(ctx, newValidMsgFinaliseOutcomePayment())
+	require.Error(t, err)
+	require.True(t, types.ErrNoOutcomePaymentMade.Is(err))
+
+	// Make partial outcome payment
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(60000))
+	require.NoError(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Place a buy order, which remains pending in the current batch
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(36000), app.BondsKeeper.BankKeeper.
+		GetCoins(ctx, userAddress).AmountOf(reserveToken))
+
+	// Finalising with different signers fails
+	msg := newValidMsgFinaliseOutcomePayment()
+	msg.Signers = []sdk.AccAddress{anotherAddress}
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Finalising with the bond's signers settles the bond
+	_, err = h(ctx, newValidMsgFinaliseOutcomePayment())
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.SettleState, bond.State)
+	require.Equal(t, sdk.NewInt(60000), bond.OutcomePaymentReceived.AmountOf(reserveToken))
+
+	// The outcome payment is now in the reserve, and the pending buy order was
+	// cancelled, returning the reserve tokens held for it
+	require.Equal(t, sdk.NewInt(60000), app.BondsKeeper.
+		GetReserveBalances(ctx, token).AmountOf(reserveToken))
+	require.True(t, app.BondsKeeper.GetBuyOrders(ctx, token)[0].IsCancelled())
+	require.Equal(t, sdk.NewInt(40000), app.BondsKeeper.BankKeeper.
+		GetCoins(ctx, userAddress).AmountOf(reserveToken))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.True(t, app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.IsZero())
+
+	// Further outcome payments are no longer allowed
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(10000))
+	require.Error(t, err)
+}
+
+func TestWithdrawShare(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
 
 	// Set bond current supply to 3 and state to SETTLE
 	bond := app.BondsKeeper.MustGetBond(ctx, token)
@@ -883,6 +2267,11 @@ func TestWithdrawShare(t *testing.T) {
 	require.Equal(t, sdk.NewInt(66666), user1Balance.AmountOf(reserveToken))
 	require.Equal(t, sdk.NewInt(33334), reserveBalance.AmountOf(reserveToken))
 
+	// User 1's position records the withdrawal
+	position := app.BondsKeeper.GetPosition(ctx, token, userAddress)
+	require.Equal(t, sdk.NewInt(2), position.TokensWithdrawn.Amount)
+	require.Equal(t, sdk.NewInt(66666), position.SharesWithdrawn.AmountOf(reserveToken))
+
 	// Note: rounding is rounded to floor, so despite user 1 being owed 66666.67
 	// tokens, user 1 gets 66666 and not 66667 tokens. Then, since user 2 now owns
 	// the entire share of the bond tokens, they will get 100% of the remaining
@@ -897,8 +2286,221 @@ func TestWithdrawShare(t *testing.T) {
 	user2Balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
 	reserveBalance = app.BondsKeeper.GetReserveBalances(ctx, initToken)
 	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
-	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+}
+
+func TestPauseBondWithDifferentSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	msg := newValidMsgPauseBond()
+	msg.Signers = []sdk.AccAddress{anotherAddress}
+
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+}
+
+func TestResumeBondThatIsNotPausedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	_, err := h(ctx, newValidMsgResumeBond())
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+}
+
+func TestPausedBondRejectsOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateSwapperBond())
+
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err := addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	h(ctx, buyMsg)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Pause bond
+	_, err = h(ctx, newValidMsgPauseBond())
+	require.NoError(t, err)
+	require.Equal(t, types.PausedState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Pausing again fails
+	_, err = h(ctx, newValidMsgPauseBond())
+	require.Error(t, err)
+
+	// Buys, sells, and swaps are rejected
+	_, err = h(ctx, buyMsg)
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+	_, err = h(ctx, newValidMsgSell(1))
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 10))
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+}
+
+func TestEndBlockerSkipsPausedBondUntilResumed(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens and pause the bond before the batch is performed
+	h(ctx, newValidMsgBuy(2, 4000))
+	_, err = h(ctx, newValidMsgPauseBond())
+	require.NoError(t, err)
+
+	// Order is not performed and the batch does not count down
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, initBatchBlocks, batch.BlocksRemaining)
+
+	// Resume bond; bond goes back to its previous state
+	_, err = h(ctx, newValidMsgResumeBond())
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+	require.Equal(t, "", bond.StateBeforePause)
+
+	// Order is now performed
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 0)
+	require.Equal(t, sdk.NewInt(2), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.Amount)
+}
+
+func TestCloseBondWithDifferentSignersFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	msg := newValidMsgCloseBond()
+	msg.Signers = []sdk.AccAddress{anotherAddress}
+
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+}
+
+func TestCloseBondCancelsOrdersAndAllowsWithdrawShare(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens
+	h(ctx, newValidMsgBuy(2, 4000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	balanceBefore := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), balanceBefore.AmountOf(token))
+
+	// Place buy and sell orders that will not be performed
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgSell(1))
+	require.NoError(t, err)
+
+	// Close bond
+	_, err = h(ctx, newValidMsgCloseBond())
+	require.NoError(t, err)
+	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Orders were cancelled and user got back the reserve and bond tokens
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.True(t, batch.Buys[0].IsCancelled())
+	require.True(t, batch.Sells[0].IsCancelled())
+	require.Equal(t, balanceBefore, app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress))
+
+	// Batch is settled without performing any orders
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, sdk.NewInt(2), app.BondsKeeper.MustGetBond(ctx, token).CurrentSupply.Amount)
+
+	// User owns the whole supply, so the user gets the whole reserve
+	reserveBalance := app.BondsKeeper.GetReserveBalances(ctx, token)
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	userBalance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, balanceBefore.AmountOf(reserveToken).Add(
+		reserveBalance.AmountOf(reserveToken)), userBalance.AmountOf(reserveToken))
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
+
+	// Closing the bond again fails
+	_, err = h(ctx, newValidMsgCloseBond())
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+}
+
+func TestCloseBondMovesPartialOutcomePaymentToReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with 100k outcome payment
+	bondMsg := newValidMsgCreateBond()
+	bondMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	h(ctx, bondMsg)
+
+	// Make partial outcome payment, which is held in escrow
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 60000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgMakeOutcomePaymentOf(60000))
+	require.NoError(t, err)
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
+
+	// Closing the bond moves the partial outcome payment to the reserve
+	_, err = h(ctx, newValidMsgCloseBond())
+	require.NoError(t, err)
+	require.Equal(t, types.SettleState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	require.True(t, app.BondsKeeper.GetOutcomePaymentEscrowBalances(ctx, token).IsZero())
+	require.Equal(t, sdk.NewInt(60000), app.BondsKeeper.
+		GetReserveBalances(ctx, token).AmountOf(reserveToken))
+}
+
+func TestClosePausedBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create and pause bond
+	h(ctx, newValidMsgCreateBond())
+	_, err := h(ctx, newValidMsgPauseBond())
+	require.NoError(t, err)
+
+	// Close bond
+	_, err = h(ctx, newValidMsgCloseBond())
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.SettleState, bond.State)
+	require.Equal(t, "", bond.StateBeforePause)
 }
 
 func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
@@ -913,9 +2515,29 @@ func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
 	createMsg.BatchBlocks = two
 	h(ctx, createMsg)
 
-	require.Equal(t, two, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	// Batch does not count down while it has no orders
+	require.Equal(t, two, app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	require.Equal(t, two, app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+
+	// Add reserve tokens to user and buy 2 tokens
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 10000))
+	require.NoError(t, err)
+
+	// Batch is now scheduled; the current block is the first of two blocks
+	require.Equal(t, one, app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	require.Equal(t, sdk.ZeroUint(), app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 1)
+
+	// Batch is performed and a new batch is started
 	bonds.EndBlocker(ctx, app.BondsKeeper)
-	require.Equal(t, one, app.BondsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, token).Buys, 0)
+	require.Equal(t, two, app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
 }
 
 func TestEndBlockerDoesNotPerformOrdersBeforeASpecifiedNumberOfBlocks(t *testing.T) {
@@ -958,14 +2580,69 @@ func TestEndBlockerPerformsOrdersAfterASpecifiedNumberOfBlocks(t *testing.T) {
 
 	// Run EndBlocker for N times, where N = BatchBlocks
 	batchBlocksInt := int(createMsg.BatchBlocks.Uint64())
-	for i := 0; i <= batchBlocksInt; i++ {
+	for i := 0; i < batchBlocksInt; i++ {
+		require.Equal(t, 2, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
 		bonds.EndBlocker(ctx, app.BondsKeeper)
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
 	}
 
 	// Buys have been performed
 	require.Equal(t, 0, len(app.BondsKeeper.MustGetBatch(ctx, token).Buys))
 }
 
+func TestEndBlockerKeepsOrderIDsIncreasingAcrossBatches(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens twice in the first batch
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Buy 2 tokens in the next batch
+	h(ctx, newValidMsgBuy(2, 10000))
+
+	lastBatch := app.BondsKeeper.MustGetLastBatch(ctx, token)
+	batch := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, uint64(0), lastBatch.Buys[0].ID)
+	require.Equal(t, uint64(1), lastBatch.Buys[1].ID)
+	require.Equal(t, uint64(2), batch.Buys[0].ID)
+	require.Equal(t, uint64(3), batch.NextOrderID)
+}
+
+func TestEndBlockerAddsSettledBatchesToHistory(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 2 tokens in each of two batches settled at different heights
+	h(ctx, newValidMsgBuy(2, 10000))
+	bonds.EndBlocker(ctx.WithBlockHeight(1), app.BondsKeeper)
+	h(ctx, newValidMsgBuy(2, 10000))
+	bonds.EndBlocker(ctx.WithBlockHeight(2), app.BondsKeeper)
+
+	batchesHistory := app.BondsKeeper.GetBatchesHistory(ctx, token)
+	require.Len(t, batchesHistory, 2)
+	require.Equal(t, int64(1), batchesHistory[0].Height)
+	require.Equal(t, int64(2), batchesHistory[1].Height)
+	require.Equal(t, uint64(0), batchesHistory[0].Batch.Buys[0].ID)
+	require.Equal(t, uint64(1), batchesHistory[1].Batch.Buys[0].ID)
+	require.Equal(t, app.BondsKeeper.MustGetLastBatch(ctx, token), batchesHistory[1].Batch)
+}
+
 func TestEndBlockerAugmentedFunction(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
@@ -1010,14 +2687,14 @@ func TestEndBlockerAugmentedFunction(t *testing.T) {
 	require.Equal(t, types.OpenState, bond.State)
 
 	// Check user balance of tokens
-	balance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
+	balance := app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
 	require.Equal(t, int64(50000), balance)
 
 	// Can now sell tokens (all 50,000 of them)
 	_, err = h(ctx, newValidMsgSell(50000))
 	require.NoError(t, err)
 	bonds.EndBlocker(ctx, app.BondsKeeper)
-	balance = app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
+	balance = app.BondsKeeper.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
 	require.Equal(t, int64(0), balance)
 }
 
@@ -1069,6 +2746,57 @@ func TestEndBlockerAugmentedFunctionDecimalS0(t *testing.T) {
 	require.Equal(t, types.OpenState, bond.State)
 }
 
+func TestAugmentedInvariantInOpenPhase(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create bond with augmented function type and S0=10000.5 (d0/p0)
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("d0", sdk.MustNewDecFromStr("100005.0")),
+		types.NewFunctionParam("p0", sdk.MustNewDecFromStr("10.0")),
+		types.NewFunctionParam("theta", sdk.MustNewDecFromStr("0.5")),
+		types.NewFunctionParam("kappa", sdk.MustNewDecFromStr("3.0"))}
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy ceil(S0) tokens at the hatch price => state is now open
+	_, err = h(ctx, newValidMsgBuy(10001, 200000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+
+	// Invariant holds even though the reserve is slightly below Reserve(S)
+	require.True(t, bond.CurrentReserve.AmountOf(reserveToken).ToDec().LT(
+		bond.ReserveAtSupply(bond.CurrentSupply.Amount)))
+	_, broken := bonds.AugmentedInvariant(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Buy 5000 more tokens in the open phase
+	_, err = h(ctx, newValidMsgBuy(5000, 200000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, broken = bonds.AugmentedInvariant(app.BondsKeeper)(ctx)
+	require.False(t, broken)
+
+	// Halving the reserve breaks the invariant, even though the reserve is
+	// still above the amount raised at the hatch price, p0*S*(1-theta)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	halfReserve := bond.CurrentReserve.AmountOf(reserveToken).QuoRaw(2)
+	hatchReserve := sdk.NewDec(5).MulInt(
+		bond.CurrentSupply.Amount)
+	require.True(t, halfReserve.ToDec().GT(hatchReserve))
+	bond.CurrentReserve = sdk.NewCoins(sdk.NewCoin(reserveToken, halfReserve))
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, broken = bonds.AugmentedInvariant(app.BondsKeeper)(ctx)
+	require.True(t, broken)
+}
+
 func TestEndBlockerAugmentedFunctionSmallBuys(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := bonds.NewHandler(app.BondsKeeper)
@@ -1111,7 +2839,437 @@ func TestEndBlockerAugmentedFunctionSmallBuys(t *testing.T) {
 	require.Equal(t, int64(1), bond.CurrentReserve[0].Amount.Int64())
 
 	// Confirm fee address balance is d0*theta = 9
-	feeAddressBalance := app.BankKeeper.GetCoins(
+	feeAddressBalance := app.BondsKeeper.BankKeeper.GetCoins(
 		ctx, bond.FeeAddress).AmountOf(reserveToken).Int64()
 	require.Equal(t, int64(9), feeAddressBalance)
 }
+
+func TestCreateAugmentedBondWithHatchDeadlineAlreadyReachedFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(5)
+
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.HatchDeadline = 5
+	_, err := h(ctx, createMsg)
+	require.Error(t, err)
+	require.True(t, types.ErrHatchDeadlineAlreadyReached.Is(err))
+}
+
+func TestHatchDeadlineReachedWithoutS0FailsBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a hatch deadline at height 3
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.HatchDeadline = 3
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 20000 tokens for 200res, of which 80res (theta=0.4) are held in
+	// escrow instead of being sent to the fee address, plus a tx fee of 1res
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(20000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.HatchState, bond.State)
+	require.Equal(t, int64(120), bond.CurrentReserve.AmountOf(reserveToken).Int64())
+	escrow := app.BondsKeeper.GetHatchEscrowBalances(ctx, token)
+	require.Equal(t, int64(80), escrow.AmountOf(reserveToken).Int64())
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
+	require.Equal(t, int64(1), feeAddressBalance.AmountOf(reserveToken).Int64())
+
+	// Deadline reached without reaching S0, so state is now failed, and the
+	// escrow has been moved to the reserve
+	ctx = ctx.WithBlockHeight(3)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.FailedState, bond.State)
+	require.Equal(t, int64(200), bond.CurrentReserve.AmountOf(reserveToken).Int64())
+	require.True(t, app.BondsKeeper.GetHatchEscrowBalances(ctx, token).IsZero())
+
+	// Cannot buy tokens from a failed bond
+	_, err = h(ctx, newValidMsgBuy(1, 1000))
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+
+	// User reclaims full 200res contribution by burning their tokens
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	userBalance := app.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, int64(999), userBalance.AmountOf(reserveToken).Int64())
+	require.True(t, userBalance.AmountOf(token).IsZero())
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, token).IsZero())
+}
+
+func TestHatchDeadlineReachedWithMinRaiseOpensBond(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a hatch deadline at height 3 and
+	// a min raise of 100res
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.HatchDeadline = 3
+	createMsg.MinRaise = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 20000 tokens for 200res (i.e. raise of 200res)
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(20000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Deadline reached with min raise reached, so state is now open, with
+	// parameters recalculated for a d0 of 200res
+	ctx = ctx.WithBlockHeight(3)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+	require.True(t, bond.AllowSells)
+	params := bond.FunctionParameters.AsMap()
+	require.Equal(t, sdk.NewDec(200), params["d0"])
+	require.Equal(t, sdk.NewDec(120), params["R0"])
+	require.Equal(t, sdk.NewDec(20000), params["S0"])
+	require.Equal(t, types.Invariant(sdk.NewDec(120), sdk.NewDec(20000), 3), params["V0"])
+
+	// Escrow (80res) has been released to the fee address (which also has 1res tx fee)
+	require.True(t, app.BondsKeeper.GetHatchEscrowBalances(ctx, token).IsZero())
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
+	require.Equal(t, int64(81), feeAddressBalance.AmountOf(reserveToken).Int64())
+	require.Equal(t, int64(120), bond.CurrentReserve.AmountOf(reserveToken).Int64())
+}
+
+func TestHatchDeadlineAfterS0ReachedHasNoEffect(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a hatch deadline at height 3
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.HatchDeadline = 3
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 50000 tokens to reach S0, so state is now open and the escrow
+	// (200res) has been released to the fee address (with the 1res tx fee)
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(50000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+	require.True(t, app.BondsKeeper.GetHatchEscrowBalances(ctx, token).IsZero())
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
+	require.Equal(t, int64(201), feeAddressBalance.AmountOf(reserveToken).Int64())
+
+	// Deadline reached, but bond is already open
+	ctx = ctx.WithBlockHeight(3)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+}
+
+func TestSellUnvestedHatchTokensFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a vesting cliff of 5 blocks and a
+	// vesting duration of 10 blocks
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.VestingCliff = 5
+	createMsg.VestingDuration = 10
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 50000 tokens to reach S0, so state is now open, but all of the
+	// tokens bought in the hatch phase are held in the vesting escrow
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 2000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(50000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	require.Equal(t, int64(50000),
+		app.BondsKeeper.GetUnvested(ctx, token, userAddress).Amount.Int64())
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).IsZero())
+	require.Equal(t, int64(50000), app.BankKeeper.GetCoins(ctx,
+		types.GetVestingEscrowAddress(token)).AmountOf(token).Int64())
+
+	// Selling (or limit selling) any of the unvested tokens fails
+	ctx = ctx.WithBlockHeight(2)
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	_, err = h(ctx, newValidMsgLimitSell(1, 1, 0))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
+	// Tokens bought in the open phase are not locked, so can be sold
+	_, err = h(ctx, newValidMsgBuy(1000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	_, err = h(ctx, newValidMsgSell(1000))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Error(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Half of the tokens have vested 5 blocks after the cliff has passed
+	ctx = ctx.WithBlockHeight(11)
+	require.Equal(t, int64(25000),
+		app.BondsKeeper.GetUnvested(ctx, token, userAddress).Amount.Int64())
+	_, err = h(ctx, newValidMsgSell(25001))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	_, err = h(ctx, newValidMsgSell(25000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// All of the tokens have vested once the vesting duration has passed
+	ctx = ctx.WithBlockHeight(16)
+	require.True(t, app.BondsKeeper.GetUnvested(ctx, token, userAddress).IsZero())
+	_, err = h(ctx, newValidMsgSell(25000))
+	require.NoError(t, err)
+}
+
+func TestTransferUnvestedHatchTokensFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a vesting cliff of 5 blocks and a
+	// vesting duration of 10 blocks
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.VestingCliff = 5
+	createMsg.VestingDuration = 10
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 50000 tokens to reach S0, so state is now open
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 2000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(50000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	newMsgSellFromAnother := func(amount int64) types.MsgSell {
+		return types.NewMsgSell(anotherAddress, sdk.NewInt64Coin(token, amount), nil)
+	}
+
+	// Transferring any of the unvested tokens to another address fails, so
+	// selling these from the other address also fails
+	ctx = ctx.WithBlockHeight(2)
+	err = app.BankKeeper.SendCoins(ctx, userAddress, anotherAddress,
+		sdk.Coins{sdk.NewInt64Coin(token, 1)})
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	_, err = h(ctx, newMsgSellFromAnother(1))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+
+	// Nothing can be withdrawn from the vesting escrow before the cliff
+	_, err = h(ctx, types.NewMsgWithdrawVested(userAddress, token))
+	require.Error(t, err)
+	require.True(t, types.ErrInsufficientVestedTokens.Is(err))
+
+	// Half of the tokens have vested 5 blocks after the cliff has passed, and
+	// only these can be withdrawn and transferred
+	ctx = ctx.WithBlockHeight(11)
+	_, err = h(ctx, types.NewMsgWithdrawVested(userAddress, token))
+	require.NoError(t, err)
+	require.Equal(t, int64(25000),
+		app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64())
+	require.Equal(t, int64(25000), app.BankKeeper.GetCoins(ctx,
+		types.GetVestingEscrowAddress(token)).AmountOf(token).Int64())
+	err = app.BankKeeper.SendCoins(ctx, userAddress, anotherAddress,
+		sdk.Coins{sdk.NewInt64Coin(token, 25001)})
+	require.Error(t, err)
+	err = app.BankKeeper.SendCoins(ctx, userAddress, anotherAddress,
+		sdk.Coins{sdk.NewInt64Coin(token, 25000)})
+	require.NoError(t, err)
+	_, err = h(ctx, newMsgSellFromAnother(25000))
+	require.NoError(t, err)
+}
+
+func TestWithdrawFundingThroughFundingTap(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// Create augmented bond (S0=50000) with a funding tap of 100res per 10
+	// blocks, so the funding share of the hatch phase goes to the funding pool
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	createMsg.FundingTapBlocks = 10
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 50000 tokens to reach S0 (at p0=0.01) for 500res, of which 40%
+	// (theta) goes to the funding pool instead of the fee address
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 2000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(50000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 200)),
+		app.BondsKeeper.GetFundingPoolBalances(ctx, token))
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken)
+
+	newWithdrawMsg := func(amount int64) types.MsgWithdrawFunding {
+		return types.NewMsgWithdrawFunding(initFeeAddress, token,
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, amount)))
+	}
+
+	// Only the bond's fee address can withdraw funding
+	_, err = h(ctx, types.NewMsgWithdrawFunding(userAddress, token,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1))))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// Withdrawing more than the funding tap fails
+	_, err = h(ctx, newWithdrawMsg(101))
+	require.Error(t, err)
+	require.True(t, types.ErrFundingTapExceeded.Is(err))
+
+	// Withdrawals within the same period add up to at most the funding tap
+	_, err = h(ctx, newWithdrawMsg(60))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(10)
+	_, err = h(ctx, newWithdrawMsg(41))
+	require.Error(t, err)
+	require.True(t, types.ErrFundingTapExceeded.Is(err))
+	_, err = h(ctx, newWithdrawMsg(40))
+	require.NoError(t, err)
+	require.Equal(t, feeAddressBalance.AddRaw(100),
+		app.BankKeeper.GetCoins(ctx, initFeeAddress).AmountOf(reserveToken))
+
+	// Full funding tap is available again once the period has ended
+	ctx = ctx.WithBlockHeight(11)
+	_, err = h(ctx, newWithdrawMsg(100))
+	require.NoError(t, err)
+	require.True(t, app.BondsKeeper.GetFundingPoolBalances(ctx, token).IsZero())
+
+	// Withdrawing more than the funding pool's balance fails
+	ctx = ctx.WithBlockHeight(21)
+	_, err = h(ctx, newWithdrawMsg(1))
+	require.Error(t, err)
+}
+
+func TestWithdrawFundingFromBondWithoutFundingPoolFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	_, err := h(ctx, newValidMsgCreateAugmentedBond())
+	require.NoError(t, err)
+
+	_, err = h(ctx, types.NewMsgWithdrawFunding(initFeeAddress, token,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1))))
+	require.Error(t, err)
+	require.True(t, types.ErrBondHasNoFundingPool.Is(err))
+}
+
+func TestSetFundingTap(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	_, err := h(ctx, newValidMsgCreateAugmentedBond())
+	require.NoError(t, err)
+	fundingTap := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+
+	// Setting the funding tap requires the bond's signers
+	_, err = h(ctx, types.NewMsgSetFundingTap(token, fundingTap, 10,
+		userAddress, []sdk.AccAddress{userAddress}))
+	require.Error(t, err)
+
+	// Setting the funding tap in a non-reserve token fails
+	_, err = h(ctx, types.NewMsgSetFundingTap(token,
+		sdk.NewCoins(sdk.NewInt64Coin("othertoken", 100)), 10,
+		initCreator, initSigners))
+	require.Error(t, err)
+	require.True(t, types.ErrReserveDenomsMismatch.Is(err))
+
+	// Setting the funding tap by the bond's signers succeeds
+	_, err = h(ctx, types.NewMsgSetFundingTap(token, fundingTap, 10,
+		initCreator, initSigners))
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, fundingTap, bond.FundingTap)
+	require.Equal(t, int64(10), bond.FundingTapBlocks)
+	require.Equal(t, types.GetFundingPoolAddress(token), bond.GetFundingAddress())
+}
+
+func TestSetFundingTapForNonAugmentedBondFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	_, err := h(ctx, newValidMsgCreateBond())
+	require.NoError(t, err)
+
+	_, err = h(ctx, types.NewMsgSetFundingTap(token,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)), 10,
+		initCreator, initSigners))
+	require.Error(t, err)
+	require.True(t, types.ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestBuyInOpenPhaseChargesFundingTax(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+
+	// Create augmented bond (S0=50000) with a funding pool and a 10% funding
+	// tax, which is only charged on buys made in the open phase
+	createMsg := newValidMsgCreateAugmentedBond()
+	createMsg.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	createMsg.FundingTapBlocks = 10
+	createMsg.FundingTaxPercentage = sdk.NewDec(10)
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Buy 50000 tokens to reach S0 (at p0=0.01) for 500res, of which 200res
+	// (theta=0.4) goes to the funding pool, with no funding tax charged
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(50000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 200)),
+		app.BondsKeeper.GetFundingPoolBalances(ctx, token))
+
+	// Expected prices and funding tax of buying 10000 more tokens
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	reserveBefore := app.BondsKeeper.GetReserveBalances(ctx, token)
+	reservePrices, err := bond.GetPricesToMint(sdk.NewInt(10000), reserveBefore)
+	require.NoError(t, err)
+	expectedPrices := types.RoundReservePrices(reservePrices)
+	expectedTaxes := bond.GetFundingTaxes(reservePrices)
+	require.True(t, expectedTaxes.IsAllPositive())
+
+	// Buy 10000 tokens in the open phase
+	_, err = h(ctx, newValidMsgBuy(10000, 1000))
+	require.NoError(t, err)
+	bonds.EndBlocker(ctx, app.BondsKeeper)
+
+	// Funding tax went to the funding pool, while the reserve only increased
+	// by the curve prices, so that it still matches Reserve(S)
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 200)).Add(expectedTaxes...),
+		app.BondsKeeper.GetFundingPoolBalances(ctx, token))
+	require.Equal(t, reserveBefore.Add(expectedPrices...),
+		app.BondsKeeper.GetReserveBalances(ctx, token))
+	expectedReserve := bond.ReserveAtSupply(bond.CurrentSupply.Amount)
+	require.Equal(t, expectedReserve.Ceil().TruncateInt(),
+		app.BondsKeeper.GetReserveBalances(ctx, token).AmountOf(reserveToken))
+}
@@ -5,9 +5,26 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
 )
 
+// MustGetBatch returns the bond's current batch, including all of its orders.
+// Since the orders are stored separately from the batch, MustGetBatchHeader
+// should be used instead whenever the orders are not needed.
 func (k Keeper) MustGetBatch(ctx sdk.Context, token string) types.Batch {
+	batch := k.MustGetBatchHeader(ctx, token)
+	batch.Buys = k.GetBuyOrders(ctx, token)
+	batch.Sells = k.GetSellOrders(ctx, token)
+	batch.Swaps = k.GetSwapOrders(ctx, token)
+	batch.BuysWithReserve = k.GetBuyWithReserveOrders(ctx, token)
+	batch.AddLiquidity = k.GetAddLiquidityOrders(ctx, token)
+	batch.RemoveLiquidity = k.GetRemoveLiquidityOrders(ctx, token)
+	return batch
+}
+
+// MustGetBatchHeader returns the bond's current batch without its orders, i.e.
+// just the batch's totals, prices, blocks remaining, and next order ID.
+func (k Keeper) MustGetBatchHeader(ctx sdk.Context, token string) types.Batch {
 	store := ctx.KVStore(k.storeKey)
 	if !k.BatchExists(ctx, token) {
 		panic(fmt.Sprintf("batch not found for %s\n", token))
@@ -43,52 +60,464 @@ func (k Keeper) LastBatchExists(ctx sdk.Context, token string) bool {
 	return store.Has(types.GetLastBatchKey(token))
 }
 
+// SetBatch sets the bond's current batch, replacing the batch's header and
+// any orders that the bond's previous batch had with those of the new batch.
 func (k Keeper) SetBatch(ctx sdk.Context, token string, batch types.Batch) {
+	k.DeleteBatchOrders(ctx, token)
+	k.SetBatchHeader(ctx, token, batch)
+	for _, bo := range batch.Buys {
+		k.SetBuyOrder(ctx, token, bo)
+	}
+	for _, so := range batch.Sells {
+		k.SetSellOrder(ctx, token, so)
+	}
+	for _, so := range batch.Swaps {
+		k.SetSwapOrder(ctx, token, so)
+	}
+	for _, bo := range batch.BuysWithReserve {
+		k.SetBuyWithReserveOrder(ctx, token, bo)
+	}
+	for _, ao := range batch.AddLiquidity {
+		k.SetAddLiquidityOrder(ctx, token, ao)
+	}
+	for _, ro := range batch.RemoveLiquidity {
+		k.SetRemoveLiquidityOrder(ctx, token, ro)
+	}
+}
+
+// SetBatchHeader sets the bond's current batch, excluding any of its orders,
+// which are left untouched.
+func (k Keeper) SetBatchHeader(ctx sdk.Context, token string, batch types.Batch) {
+	batch.Buys = nil
+	batch.Sells = nil
+	batch.Swaps = nil
+	batch.BuysWithReserve = nil
+	batch.AddLiquidity = nil
+	batch.RemoveLiquidity = nil
+
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
 
+func (k Keeper) SetBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.BuyOrdersKeyPrefix, bo.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(bo))
+}
+
+func (k Keeper) SetSellOrder(ctx sdk.Context, token string, so types.SellOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.SellOrdersKeyPrefix, so.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(so))
+}
+
+func (k Keeper) SetSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.SwapOrdersKeyPrefix, so.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(so))
+}
+
+func (k Keeper) SetBuyWithReserveOrder(ctx sdk.Context, token string, bo types.BuyWithReserveOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.BuyWithReserveOrdersKeyPrefix, bo.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(bo))
+}
+
+func (k Keeper) SetAddLiquidityOrder(ctx sdk.Context, token string, ao types.AddLiquidityOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.AddLiquidityOrdersKeyPrefix, ao.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(ao))
+}
+
+func (k Keeper) SetRemoveLiquidityOrder(ctx sdk.Context, token string, ro types.RemoveLiquidityOrder) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.RemoveLiquidityOrdersKeyPrefix, ro.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(ro))
+}
+
+// GetBatchOrdersIterator returns an iterator over the orders of the specified
+// type in the bond's current batch, in the order that they were added.
+func (k Keeper) GetBatchOrdersIterator(ctx sdk.Context, token string, orderTypePrefix []byte) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store,
+		types.GetBatchOrdersOfTypePrefix(token, orderTypePrefix))
+}
+
+func (k Keeper) GetBuyOrders(ctx sdk.Context, token string) (orders []types.BuyOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.BuyOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var bo types.BuyOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bo)
+		orders = append(orders, bo)
+	}
+	return orders
+}
+
+func (k Keeper) GetSellOrders(ctx sdk.Context, token string) (orders []types.SellOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.SellOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var so types.SellOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &so)
+		orders = append(orders, so)
+	}
+	return orders
+}
+
+func (k Keeper) GetSwapOrders(ctx sdk.Context, token string) (orders []types.SwapOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.SwapOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var so types.SwapOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &so)
+		orders = append(orders, so)
+	}
+	return orders
+}
+
+func (k Keeper) GetBuyWithReserveOrders(ctx sdk.Context, token string) (orders []types.BuyWithReserveOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.BuyWithReserveOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var bo types.BuyWithReserveOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bo)
+		orders = append(orders, bo)
+	}
+	return orders
+}
+
+func (k Keeper) GetAddLiquidityOrders(ctx sdk.Context, token string) (orders []types.AddLiquidityOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.AddLiquidityOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var ao types.AddLiquidityOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &ao)
+		orders = append(orders, ao)
+	}
+	return orders
+}
+
+func (k Keeper) GetRemoveLiquidityOrders(ctx sdk.Context, token string) (orders []types.RemoveLiquidityOrder) {
+	iterator := k.GetBatchOrdersIterator(ctx, token, types.RemoveLiquidityOrdersKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var ro types.RemoveLiquidityOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &ro)
+		orders = append(orders, ro)
+	}
+	return orders
+}
+
+// BatchHasOrders returns true if the bond's current batch has any orders,
+// including cancelled ones.
+func (k Keeper) BatchHasOrders(ctx sdk.Context, token string) bool {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetBatchOrdersPrefix(token))
+	defer iterator.Close()
+	return iterator.Valid()
+}
+
+// DeleteBatchOrders deletes all of the orders of the bond's current batch.
+func (k Keeper) DeleteBatchOrders(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+
+	// Collect keys first, since the store should not be modified while
+	// iterating over it
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.GetBatchOrdersPrefix(token))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// MigrateBatchOrders moves the orders of any batch that still holds its orders
+// within the batch itself (i.e. from before orders were stored separately) to
+// their own keys.
+func (k Keeper) MigrateBatchOrders(ctx sdk.Context) {
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		batch := k.MustGetBatchHeader(ctx, bond.Token)
+		if batch.HasOrders() {
+			k.SetBatch(ctx, bond.Token, batch)
+
+			logger := k.Logger(ctx)
+			logger.Info(fmt.Sprintf("migrated batch orders of %s", bond.Token))
+		}
+	}
+}
+
 func (k Keeper) SetLastBatch(ctx sdk.Context, token string, batch types.Batch) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetLastBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
 
-func (k Keeper) AddBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder, buyPrices, sellPrices sdk.DecCoins) {
-	batch := k.MustGetBatch(ctx, token)
+func (k Keeper) GetBatchesHistoryIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetBatchesHistoryPrefix(token))
+}
+
+func (k Keeper) GetBatchesHistory(ctx sdk.Context, token string) (settledBatches []types.SettledBatch) {
+	iterator := k.GetBatchesHistoryIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var settledBatch types.SettledBatch
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settledBatch)
+		settledBatches = append(settledBatches, settledBatch)
+	}
+	return settledBatches
+}
+
+// GetBatchesHistoryPage returns a page of the batches history of the bond,
+// starting from the oldest settled batch. Only the settled batches in the
+// requested page are decoded, the ones in earlier pages are skipped.
+func (k Keeper) GetBatchesHistoryPage(ctx sdk.Context, token string, page, limit int) (settledBatches []types.SettledBatch) {
+	if page < 1 || limit < 1 {
+		return nil
+	}
+
+	iterator := k.GetBatchesHistoryIterator(ctx, token)
+	defer iterator.Close()
+	for skip := (page - 1) * limit; skip > 0 && iterator.Valid(); skip-- {
+		iterator.Next()
+	}
+	for ; iterator.Valid() && len(settledBatches) < limit; iterator.Next() {
+		var settledBatch types.SettledBatch
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settledBatch)
+		settledBatches = append(settledBatches, settledBatch)
+	}
+	return settledBatches
+}
+
+func (k Keeper) SetSettledBatch(ctx sdk.Context, settledBatch types.SettledBatch) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchHistoryKey(settledBatch.Batch.Token, settledBatch.Height)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(settledBatch))
+}
+
+// AddSettledBatch adds the batch to the batches history of the bond, at the
+// current block height, and prunes any settled batches that are older than
+// the batch history retention defined in the module params.
+func (k Keeper) AddSettledBatch(ctx sdk.Context, token string, batch types.Batch) {
+	retention := k.GetParams(ctx).BatchHistoryRetention
+	if retention > 0 {
+		k.SetSettledBatch(ctx, types.NewSettledBatch(ctx.BlockHeight(), batch))
+	}
+
+	// Collect expired keys first, since the store should not be modified
+	// while iterating over it
+	var expiredKeys [][]byte
+	iterator := k.GetBatchesHistoryIterator(ctx, token)
+	for ; iterator.Valid(); iterator.Next() {
+		var settledBatch types.SettledBatch
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settledBatch)
+		if uint64(ctx.BlockHeight()-settledBatch.Height) < retention {
+			break
+		}
+		expiredKeys = append(expiredKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range expiredKeys {
+		store.Delete(key)
+	}
+}
+
+// ScheduleBatch schedules the bond's batch to be performed at the end of the
+// block that is BlocksRemaining-1 blocks from the current one. In other words,
+// the current block counts as the first of the batch's remaining blocks.
+func (k Keeper) ScheduleBatch(ctx sdk.Context, token string) {
+	batch := k.MustGetBatchHeader(ctx, token)
+	blocks := int64(batch.BlocksRemaining.Uint64())
+	batch.ExecutionHeight = ctx.BlockHeight() + blocks - 1
+	k.SetBatchHeader(ctx, token, batch)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBatchScheduleKey(batch.ExecutionHeight, token), []byte(token))
+}
+
+// UnscheduleBatch removes the bond's batch from the schedule, keeping note of
+// its remaining blocks, so that it can be scheduled again at a later stage.
+func (k Keeper) UnscheduleBatch(ctx sdk.Context, token string) {
+	batch := k.MustGetBatchHeader(ctx, token)
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetBatchScheduleKey(batch.ExecutionHeight, token))
+
+	batch.BlocksRemaining = sdk.NewUint(uint64(batch.ExecutionHeight - ctx.BlockHeight() + 1))
+	batch.ExecutionHeight = 0
+	k.SetBatchHeader(ctx, token, batch)
+}
+
+func (k Keeper) BatchIsScheduled(ctx sdk.Context, token string) bool {
+	batch := k.MustGetBatchHeader(ctx, token)
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetBatchScheduleKey(batch.ExecutionHeight, token))
+}
+
+// GetBatchBlocksRemaining returns the number of blocks remaining, after the
+// current one, until the bond's batch is performed. Only batches with orders
+// (or of bonds with limit orders) are scheduled, so for a batch that is not
+// scheduled, this is the number of blocks that the batch will last for once
+// its first order is added.
+func (k Keeper) GetBatchBlocksRemaining(ctx sdk.Context, token string) sdk.Uint {
+	batch := k.MustGetBatchHeader(ctx, token)
+	if !k.BatchIsScheduled(ctx, token) {
+		return batch.BlocksRemaining
+	} else if batch.ExecutionHeight <= ctx.BlockHeight() {
+		return sdk.ZeroUint()
+	}
+	return sdk.NewUint(uint64(batch.ExecutionHeight - ctx.BlockHeight()))
+}
+
+// GetBatchesDue returns the tokens of the bonds whose batch is scheduled to be
+// performed at or before the current block height, and removes them from the
+// schedule.
+func (k Keeper) GetBatchesDue(ctx sdk.Context) (tokens []string) {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.GetBatchScheduleHeightPrefix(ctx.BlockHeight()))
+
+	// Collect keys first, since the store should not be modified while
+	// iterating over it
+	var keys [][]byte
+	iterator := store.Iterator(types.BatchScheduleKeyPrefix, end)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		tokens = append(tokens, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return tokens
+}
+
+// AddBuyOrder assigns an ID to the buy order, adds it to the batch, and
+// returns the assigned ID. The batch is scheduled if it is not already (i.e.
+// if this is its first order and the bond has no limit orders). The same
+// applies to the other Add*Order functions.
+func (k Keeper) AddBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder, buyPrices, sellPrices sdk.DecCoins) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	bo.ID = batch.NextOrderID
+	batch.NextOrderID++
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
 	batch.BuyPrices = buyPrices
 	batch.SellPrices = sellPrices
-	batch.Buys = append(batch.Buys, bo)
-	k.SetBatch(ctx, token, batch)
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetBuyOrder(ctx, token, bo)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added buy order %d for %s from %s", bo.ID, bo.Amount.String(), bo.Address.String()))
+	return bo.ID
+}
+
+func (k Keeper) AddBuyWithReserveOrder(ctx sdk.Context, token string, bo types.BuyWithReserveOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	bo.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetBuyWithReserveOrder(ctx, token, bo)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
 
 	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("added buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+	logger.Info(fmt.Sprintf("added buy with reserve order %d for %s with budget %s from %s", bo.ID, token, bo.Budget.String(), bo.Address.String()))
+	return bo.ID
 }
 
-func (k Keeper) AddSellOrder(ctx sdk.Context, token string, so types.SellOrder, buyPrices, sellPrices sdk.DecCoins) {
-	batch := k.MustGetBatch(ctx, token)
+func (k Keeper) AddSellOrder(ctx sdk.Context, token string, so types.SellOrder, buyPrices, sellPrices sdk.DecCoins) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	so.ID = batch.NextOrderID
+	batch.NextOrderID++
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
 	batch.BuyPrices = buyPrices
 	batch.SellPrices = sellPrices
-	batch.Sells = append(batch.Sells, so)
-	k.SetBatch(ctx, token, batch)
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetSellOrder(ctx, token, so)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
 
 	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("added sell order for %s from %s", so.Amount.String(), so.Address.String()))
+	logger.Info(fmt.Sprintf("added sell order %d for %s from %s", so.ID, so.Amount.String(), so.Address.String()))
+	return so.ID
 }
 
-func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) {
-	batch := k.MustGetBatch(ctx, token)
-	batch.Swaps = append(batch.Swaps, so)
-	k.SetBatch(ctx, token, batch)
+func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	so.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetSwapOrder(ctx, token, so)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added swap order %d for %s to %s from %s", so.ID, so.Amount.String(), so.ToToken, so.Address.String()))
+	return so.ID
+}
+
+func (k Keeper) AddAddLiquidityOrder(ctx sdk.Context, token string, ao types.AddLiquidityOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	ao.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetAddLiquidityOrder(ctx, token, ao)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
 
 	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("added swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
+	logger.Info(fmt.Sprintf("added add liquidity order %d for %s with deposit %s from %s", ao.ID, token, ao.Deposit.String(), ao.Address.String()))
+	return ao.ID
+}
+
+func (k Keeper) AddRemoveLiquidityOrder(ctx sdk.Context, token string, ro types.RemoveLiquidityOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	schedule := !k.BatchIsScheduled(ctx, token)
+	ro.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetRemoveLiquidityOrder(ctx, token, ro)
+	if schedule {
+		k.ScheduleBatch(ctx, token)
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added remove liquidity order %d for %s to %s from %s", ro.ID, ro.Amount.String(), ro.ToToken, ro.Address.String()))
+	return ro.ID
 }
 
 func (k Keeper) GetBatchBuySellPrices(ctx sdk.Context, token string, batch types.Batch) (buyPricesPT, sellPricesPT sdk.DecCoins, err error) {
 	bond := k.MustGetBond(ctx, token)
 
+	// If the bond allocates its remaining supply, any buys exceeding the max
+	// supply will not be filled, so these are not considered in the prices
+	if bond.AllocatesMaxSupply() {
+		remainingSupply := bond.MaxSupply.Sub(bond.CurrentSupply)
+		if remainingSupply.IsLT(batch.TotalBuyAmount) {
+			batch.TotalBuyAmount = remainingSupply
+		}
+	}
+
 	buyAmountDec := batch.TotalBuyAmount.Amount.ToDec()
 	sellAmountDec := batch.TotalSellAmount.Amount.ToDec()
 
@@ -139,12 +568,18 @@ func (k Keeper) GetBatchBuySellPrices(ctx sdk.Context, token string, batch types
 
 func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo types.BuyOrder) (buyPrices, sellPrices sdk.DecCoins, err error) {
 	bond := k.MustGetBond(ctx, token)
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 
-	// Max supply cannot be less than supply (max supply >= supply)
+	// Max supply cannot be less than supply (max supply >= supply), unless
+	// the bond allocates its remaining supply, in which case the buy is only
+	// rejected if the bond is already sold out
 	adjustedSupply := k.GetSupplyAdjustedForBuy(ctx, token)
 	adjustedSupplyWithBuy := adjustedSupply.Add(bo.Amount)
-	if bond.MaxSupply.IsLT(adjustedSupplyWithBuy) {
+	if bond.AllocatesMaxSupply() {
+		if !bond.CurrentSupply.IsLT(bond.MaxSupply) {
+			return nil, nil, sdkerrors.Wrap(types.ErrBondSoldOut, bond.MaxSupply.String())
+		}
+	} else if bond.MaxSupply.IsLT(adjustedSupplyWithBuy) {
 		return nil, nil, sdkerrors.Wrap(types.ErrCannotMintMoreThanMaxSupply, bond.MaxSupply.String())
 	}
 
@@ -179,7 +614,7 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo
 }
 
 func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so types.SellOrder) (buyPrices, sellPrices sdk.DecCoins, err error) {
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 
 	// Cannot burn more tokens than what exists
 	adjustedSupply := k.GetSupplyAdjustedForSell(ctx, token)
@@ -194,10 +629,85 @@ func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so
 		return nil, nil, err
 	}
 
+	err = k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, sellPrices)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return buyPrices, sellPrices, nil
 }
 
+func (k Keeper) GetBuyWithReserveAmount(ctx sdk.Context, token string, budget sdk.Coins) (amount sdk.Int, reservePrices sdk.DecCoins, err error) {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	return getBuyWithReserveAmount(bond, reserveBalances, budget)
+}
+
+// getBuyWithReserveAmount returns the largest amount of bond tokens that the
+// budget can buy given the specified reserve balances, together with the
+// reserve prices of this amount (excluding fees).
+func getBuyWithReserveAmount(bond types.Bond, reserveBalances sdk.Coins,
+	budget sdk.Coins) (amount sdk.Int, reservePrices sdk.DecCoins, err error) {
+	// The amount that can be bought is limited by the max supply, by any
+	// order quantity limit, and, for augmented in hatch phase, by S0 (ceil)
+	maxAmount := bond.MaxSupply.Amount.Sub(bond.CurrentSupply.Amount)
+	limit := bond.OrderQuantityLimits.AmountOf(bond.Token)
+	if limit.IsPositive() && limit.LT(maxAmount) {
+		maxAmount = limit
+	}
+	if bond.FunctionType == types.AugmentedFunction &&
+		bond.State == types.HatchState {
+		args := bond.FunctionParameters.AsMap()
+		hatchMaxAmount := args["S0"].Ceil().TruncateInt().Sub(bond.CurrentSupply.Amount)
+		if hatchMaxAmount.LT(maxAmount) {
+			maxAmount = hatchMaxAmount
+		}
+	}
+
+	// Checks whether the price of buying n tokens, plus any funding tax and
+	// the tx fee, fits budget
+	fitsBudget := func(n sdk.Int) (bool, sdk.DecCoins, error) {
+		prices, err := bond.GetPricesToMint(n, reserveBalances)
+		if err != nil {
+			return false, nil, err
+		}
+		fundingTaxes := bond.GetFundingTaxes(prices)
+		txFees := bond.GetTxFees(prices)
+		totalPrices := types.RoundReservePrices(prices).Add(fundingTaxes...).Add(txFees...)
+		return !totalPrices.IsAnyGT(budget), prices, nil
+	}
+
+	// Binary search for the largest amount whose total price fits the budget
+	low, high := sdk.ZeroInt(), maxAmount
+	for low.LT(high) {
+		mid := low.Add(high).AddRaw(1).QuoRaw(2)
+		fits, _, err := fitsBudget(mid)
+		if err != nil {
+			return sdk.ZeroInt(), nil, err
+		} else if fits {
+			low = mid
+		} else {
+			high = mid.SubRaw(1)
+		}
+	}
+
+	if !low.IsPositive() {
+		return sdk.ZeroInt(), nil, sdkerrors.Wrapf(types.ErrInsufficientReserveToBuy, "budget %s cannot buy any tokens", budget)
+	}
+
+	_, reservePrices, err = fitsBudget(low)
+	if err != nil {
+		return sdk.ZeroInt(), nil, err
+	}
+	return low, reservePrices, nil
+}
+
 func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) (err error) {
+	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
+	return k.PerformBuyAtReservePrices(ctx, token, bo, reservePrices, types.AttributeValueBuyOrder)
+}
+
+func (k Keeper) PerformBuyAtReservePrices(ctx sdk.Context, token string, bo types.BuyOrder, reservePrices sdk.DecCoins, orderType string) (err error) {
 	bond := k.MustGetBond(ctx, token)
 	var extraEventAttributes []sdk.Attribute
 
@@ -208,17 +718,24 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		return err
 	}
 
-	// Send bond tokens bought to buyer
+	// Send bond tokens bought to buyer, or hold them in the vesting escrow if
+	// bought in the hatch phase of a bond with vesting
+	recipient := bo.Address
+	if bond.FunctionType == types.AugmentedFunction &&
+		bond.State == types.HatchState && bond.HasVesting() {
+		recipient = types.GetVestingEscrowAddress(bond.Token)
+	}
 	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BondsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
+		types.BondsMintBurnAccount, recipient, sdk.Coins{bo.Amount})
 	if err != nil {
 		return err
 	}
 
-	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
+	fundingTaxes := bond.GetFundingTaxes(reservePrices)
+	chargedPrices := reservePricesRounded.Add(fundingTaxes...)
 	txFees := bond.GetTxFees(reservePrices)
-	totalPrices := reservePricesRounded.Add(txFees...)
+	totalPrices := chargedPrices.Add(txFees...)
 
 	if totalPrices.IsAnyGT(bo.MaxPrices) {
 		sdkerrors.Wrapf(types.ErrMaxPriceExceeded, "Actual prices %s exceed max prices %s", totalPrices, bo.MaxPrices)
@@ -267,9 +784,14 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 			return err
 		}
 
-		// Send reserve tokens to funding pool
+		// Send reserve tokens to funding pool (or fee address), or hold them
+		// in escrow until the hatch phase ends if the bond has a hatch deadline
+		fundingPoolAddress := bond.GetFundingAddress()
+		if bond.HasHatchDeadline() {
+			fundingPoolAddress = types.GetHatchEscrowAddress(bond.Token)
+		}
 		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, coinsToFundingPool)
+			types.BatchesIntermediaryAccount, fundingPoolAddress, coinsToFundingPool)
 		if err != nil {
 			return err
 		}
@@ -278,12 +800,34 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 			sdk.NewAttribute(types.AttributeKeyChargedPricesReserve, toInitialReserve.String()),
 			sdk.NewAttribute(types.AttributeKeyChargedPricesFunding, coinsToFundingPool.String()),
 		)
+
+		// Lock bond tokens bought in the hatch phase (held in the vesting
+		// escrow) until they have vested
+		if bond.HasVesting() {
+			k.AddVesting(ctx, token, bo.Address, bo.Amount)
+		}
 	} else {
 		err = k.DepositReserveFromModule(
 			ctx, bond.Token, types.BatchesIntermediaryAccount, reservePricesRounded)
 		if err != nil {
 			return err
 		}
+
+		// Send funding tax to funding pool (or fee address). This is charged
+		// on top of the reserve prices, such that the reserve still matches
+		// Reserve(S) and the bond's invariant V0 is unaffected by the tax.
+		if !fundingTaxes.IsZero() {
+			err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.BatchesIntermediaryAccount, bond.GetFundingAddress(), fundingTaxes)
+			if err != nil {
+				return err
+			}
+
+			extraEventAttributes = append(extraEventAttributes,
+				sdk.NewAttribute(types.AttributeKeyChargedPricesReserve, reservePricesRounded.String()),
+				sdk.NewAttribute(types.AttributeKeyChargedPricesFunding, fundingTaxes.String()),
+			)
+		}
 	}
 
 	// Add charged fee to fee address
@@ -308,8 +852,12 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 	// Update supply (max supply exceeded check done during MsgBuy)
 	k.SetCurrentSupply(ctx, token, bond.CurrentSupply.Add(bo.Amount))
 
+	// Update buyer's position
+	position := k.GetPosition(ctx, token, bo.Address)
+	k.SetPosition(ctx, position.AddBuy(bo.Amount, chargedPrices, txFees))
+
 	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("performed buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+	logger.Info(fmt.Sprintf("performed %s order for %s from %s", orderType, bo.Amount.String(), bo.Address.String()))
 
 	// Get new bond token balance
 	bondTokenBalance := k.BankKeeper.GetCoins(ctx, bo.Address).AmountOf(bond.Token)
@@ -317,14 +865,19 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 	event := sdk.NewEvent(
 		types.EventTypeOrderFulfill,
 		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
-		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderType, orderType),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(bo.ID, 10)),
 		sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensMinted, bo.Amount.Amount.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedPrices, reservePricesRounded.String()),
+		sdk.NewAttribute(types.AttributeKeyChargedPrices, chargedPrices.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, returnToBuyer.String()),
 		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
 	)
+	if orderType == types.AttributeValueBuyOrder {
+		extraEventAttributes = append(extraEventAttributes,
+			sdk.NewAttribute(types.AttributeKeyFillRatio, bo.GetFillRatio().String()))
+	}
 	if len(extraEventAttributes) > 0 {
 		event = event.AppendAttributes(extraEventAttributes...)
 	}
@@ -362,6 +915,10 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 	// Update supply (burn more than supply check done during MsgSell)
 	k.SetCurrentSupply(ctx, token, bond.CurrentSupply.Sub(so.Amount))
 
+	// Update seller's position
+	position := k.GetPosition(ctx, token, so.Address)
+	k.SetPosition(ctx, position.AddSell(so.Amount, totalReturns, totalFees))
+
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("performed sell order for %s from %s", so.Amount.String(), so.Address.String()))
 
@@ -372,6 +929,7 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 		types.EventTypeOrderFulfill,
 		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(so.ID, 10)),
 		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensBurned, so.Amount.Amount.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
@@ -382,36 +940,34 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 	return nil
 }
 
-func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (err error, ok bool) {
+// PerformSwap performs the swap order, giving the swapper the specified returns,
+// which are calculated for the whole batch by PerformSwapOrders, at the batch's
+// clearing rate for swaps in the direction of the swap order. Since the returns
+// of a swap can come out of the inputs of opposing swaps, the fee-adjusted
+// inputs of all of the batch's swaps are deposited into the reserve by
+// PerformSwapOrders before any swap is performed.
+//
+// If the swap order is part of a swap route, the returns are instead held in
+// the batches intermediary account and added as a swap order to the batch of
+// the next bond in the route, keeping the route's minimum output. Similarly,
+// the returns of a swap order for an add liquidity order are held in the
+// batches intermediary account and added to the add liquidity order's budget.
+func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder,
+	reserveReturns sdk.Coins, txFee sdk.Coin, clearingRate sdk.Dec) (err error) {
 	bond := k.MustGetBond(ctx, token)
-
-	// WARNING: do not return ok=true if money has already been transferred when error occurs
-
-	// Get return for swap
-	reserveBalances := k.GetReserveBalances(ctx, token)
-	reserveReturns, txFee, err := bond.GetReturnsForSwap(so.Amount, so.ToToken, reserveBalances)
-	if err != nil {
-		return err, true
-	}
-	adjustedInput := so.Amount.Sub(txFee) // same as during GetReturnsForSwap
-
-	// Check if new rates violate sanity rate
-	newReserveBalances := reserveBalances.Add(adjustedInput).Sub(reserveReturns)
-	if bond.ReservesViolateSanityRate(newReserveBalances) {
-		return sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String()), true
-	}
-
-	// Give resultant tokens to swapper (reserveReturns should never be zero)
-	err = k.WithdrawReserve(ctx, bond.Token, so.Address, reserveReturns)
-	if err != nil {
-		return err, false
+	adjustedInput := so.Amount.Sub(txFee)
+
+	// Give resultant tokens to swapper (reserveReturns should never be zero),
+	// or to the next hop of the swap route
+	if so.IsRouted() {
+		err = k.performSwapRouteHop(ctx, token, so, reserveReturns)
+	} else if so.ForAddLiquidity {
+		err = k.performAddLiquiditySwap(ctx, token, so, reserveReturns)
+	} else {
+		err = k.WithdrawReserve(ctx, bond.Token, so.Address, reserveReturns)
 	}
-
-	// Add fee-reduced coins to be swapped to reserve (adjustedInput should never be zero)
-	err = k.DepositReserveFromModule(
-		ctx, bond.Token, types.BatchesIntermediaryAccount, sdk.Coins{adjustedInput})
 	if err != nil {
-		return err, false
+		return err
 	}
 
 	// Add fee (taken from swapper) to fee address
@@ -419,10 +975,15 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
 			types.BatchesIntermediaryAccount, bond.FeeAddress, sdk.Coins{txFee})
 		if err != nil {
-			return err, false
+			return err
 		}
 	}
 
+	// Update swapper's position
+	position := k.GetPosition(ctx, token, so.Address)
+	k.SetPosition(ctx, position.AddSwap(
+		sdk.Coins{adjustedInput}, reserveReturns, sdk.Coins{txFee}))
+
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("performed swap order for %s to %s from %s",
 		so.Amount.String(), reserveReturns, so.Address.String()))
@@ -431,20 +992,22 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 		types.EventTypeOrderFulfill,
 		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(so.ID, 10)),
 		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensSwapped, adjustedInput.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFee.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
+		sdk.NewAttribute(types.AttributeKeyClearingRate, clearingRate.String()),
 	))
 
-	return nil, true
+	return nil
 }
 
 func (k Keeper) PerformBuyOrders(ctx sdk.Context, token string) {
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 
 	// Perform buys or return to buyer
-	for _, bo := range batch.Buys {
+	for _, bo := range k.GetBuyOrders(ctx, token) {
 		if !bo.IsCancelled() {
 			err := k.PerformBuyAtPrice(ctx, token, bo, batch.BuyPrices)
 			if err != nil {
@@ -454,16 +1017,66 @@ func (k Keeper) PerformBuyOrders(ctx sdk.Context, token string) {
 			}
 		}
 	}
+}
+
+func (k Keeper) PerformBuyWithReserveOrders(ctx sdk.Context, token string) {
+	logger := k.Logger(ctx)
+
+	// Perform buys with reserve or return budget to buyer
+	for _, bo := range k.GetBuyWithReserveOrders(ctx, token) {
+		if bo.IsCancelled() {
+			continue
+		}
+
+		amount, reservePrices, err := k.GetBuyWithReserveAmount(ctx, token, bo.Budget)
+		if err != nil {
+			// Cancel
+			bo.Cancelled = true
+			bo.CancelReason = err.Error()
+			k.SetBuyWithReserveOrder(ctx, token, bo)
+
+			logger.Info(fmt.Sprintf("cancelled buy with reserve order for %s with budget %s from %s", token, bo.Budget.String(), bo.Address.String()))
+			logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeOrderCancel,
+				sdk.NewAttribute(types.AttributeKeyBond, token),
+				sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyWithReserveOrder),
+				sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(bo.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
+				sdk.NewAttribute(types.AttributeKeyCancelReason, bo.CancelReason),
+			))
+
+			// Return budget to buyer
+			err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.BatchesIntermediaryAccount, bo.Address, bo.Budget)
+			if err != nil {
+				panic(err)
+			}
+			continue
+		}
 
-	// Update batch with any new changes (shouldn't be any)
-	k.SetBatch(ctx, token, batch)
+		// Perform buy, using the budget as the max prices so that any
+		// remainder of the budget is returned to the buyer
+		bo.Amount = sdk.NewCoin(token, amount)
+		k.SetBuyWithReserveOrder(ctx, token, bo)
+		buyOrder := types.NewBuyOrder(bo.Address, sdk.NewCoin(token, amount), bo.Budget)
+		buyOrder.ID = bo.ID
+		err = k.PerformBuyAtReservePrices(ctx, token, buyOrder, reservePrices,
+			types.AttributeValueBuyWithReserveOrder)
+		if err != nil {
+			// Panic here since all calculations should have been done
+			// correctly to prevent any errors during the buy
+			panic(err)
+		}
+	}
 }
 
 func (k Keeper) PerformSellOrders(ctx sdk.Context, token string) {
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 
 	// Perform sells or return to seller
-	for _, so := range batch.Sells {
+	for _, so := range k.GetSellOrders(ctx, token) {
 		if !so.IsCancelled() {
 			err := k.PerformSellAtPrice(ctx, token, so, batch.SellPrices)
 			if err != nil {
@@ -473,59 +1086,292 @@ func (k Keeper) PerformSellOrders(ctx sdk.Context, token string) {
 			}
 		}
 	}
+}
+
+// getSwapReturns returns the fee charged for the swap order and the swap's
+// share of the batch's total returns for swaps in the same direction, which is
+// proportional to the swap's fee-adjusted input. The rate at which the swap is
+// cleared is also returned.
+func getSwapReturns(bond types.Bond, so types.SwapOrder, totalIns sdk.Coins,
+	totalReturns sdk.DecCoins) (returns sdk.Coin, txFee sdk.Coin, rate sdk.Dec) {
+	txFee = bond.GetTxFee(sdk.NewDecCoinFromCoin(so.Amount))
+	adjustedInput := so.Amount.Amount.Sub(txFee.Amount)
+
+	totalIn := totalIns.AmountOf(so.Amount.Denom).ToDec()
+	totalReturn := totalReturns.AmountOf(so.ToToken)
+	if !totalIn.IsPositive() {
+		return sdk.NewInt64Coin(so.ToToken, 0), txFee, sdk.ZeroDec()
+	}
+
+	rate = totalReturn.QuoTruncate(totalIn)
+	returnAmount := adjustedInput.ToDec().Mul(totalReturn).QuoTruncate(totalIn)
+	return sdk.NewCoin(so.ToToken, returnAmount.TruncateInt()), txFee, rate
+}
+
+// cancelSwapOrder cancels the swap order, returning the from amount to the
+// swapper, or adding it back to the budget of the add liquidity order that the
+// swap order is for.
+func (k Keeper) cancelSwapOrder(ctx sdk.Context, token string, so types.SwapOrder, reason error) {
+	logger := k.Logger(ctx)
+
+	so.Cancelled = true
+	so.CancelReason = reason.Error()
+	k.SetSwapOrder(ctx, token, so)
 
-	// Update batch with any new changes (shouldn't be any)
-	k.SetBatch(ctx, token, batch)
+	logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason.Error()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(so.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, so.CancelReason),
+	))
+
+	// Return from amount to swapper or to the add liquidity order's budget
+	if so.ForAddLiquidity {
+		k.addToAddLiquidityBudget(ctx, token, so.LiquidityOrderID, sdk.Coins{so.Amount})
+		return
+	}
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+	if err != nil {
+		panic(err)
+	}
 }
 
+// PerformSwapOrders performs the swaps in the batch as a batch auction, such
+// that the position of a swap within the batch does not affect its price. The
+// swaps in the batch are netted against each other and only the imbalance is
+// swapped against the reserve (see GetSwapBatchReturns), with all of the swaps
+// in the same direction being cleared at the same rate.
+//
+// A swap is cancelled if its fee-adjusted input or its return is zero, or if
+// its return is less than the swap's minimum output. For a swap that is part
+// of a swap route, the return is first estimated through the rest of the route
+// (see checkSwapOutput), and the swap is also cancelled if any of the remaining
+// hops can no longer be performed. Since cancelling a swap
+// changes the clearing rates, which can make other swaps unfulfillable, swaps
+// keep getting cancelled until no more swaps get cancelled. If the resultant
+// reserve balances violate the sanity rate, the latest swap in the direction
+// that moves the reserve ratio is cancelled, and the process is repeated.
 func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
-	logger := ctx.Logger()
-	batch := k.MustGetBatch(ctx, token)
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
 
-	// Perform swaps
-	// TODO: implement swaps front-running prevention
-	for i, so := range batch.Swaps {
+	var swaps []types.SwapOrder
+	for _, so := range k.GetSwapOrders(ctx, token) {
 		if !so.IsCancelled() {
-			err, ok := k.PerformSwap(ctx, token, so)
+			swaps = append(swaps, so)
+		}
+	}
+
+	var totalIns sdk.Coins
+	var totalReturns sdk.DecCoins
+	for len(swaps) > 0 {
+		// Cancel swaps that do not have any input after fees
+		var remaining []types.SwapOrder
+		totalIns = sdk.NewCoins()
+		for _, so := range swaps {
+			txFee := bond.GetTxFee(sdk.NewDecCoinFromCoin(so.Amount))
+			adjustedInput := so.Amount.Sub(txFee)
+			if adjustedInput.IsZero() {
+				k.cancelSwapOrder(ctx, token, so, sdkerrors.Wrapf(
+					types.ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", so.Amount.Denom, so.ToToken))
+				continue
+			}
+			remaining = append(remaining, so)
+			totalIns = totalIns.Add(adjustedInput)
+		}
+		swaps = remaining
+		if len(swaps) == 0 {
+			break
+		}
+
+		// Get total returns for the swaps and cancel any swaps that cannot be
+		// fulfilled (if there is an error, all of the swaps are cancelled)
+		var err error
+		totalReturns, err = bond.GetSwapBatchReturns(totalIns, reserveBalances)
+		remaining = nil
+		totalOuts := sdk.NewCoins()
+		for _, so := range swaps {
+			returns, _, _ := getSwapReturns(bond, so, totalIns, totalReturns)
 			if err != nil {
-				if ok {
-					batch.Swaps[i].Cancelled = true
-					batch.Swaps[i].CancelReason = err.Error()
-
-					logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
-					logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
-
-					ctx.EventManager().EmitEvent(sdk.NewEvent(
-						types.EventTypeOrderCancel,
-						sdk.NewAttribute(types.AttributeKeyBond, token),
-						sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
-						sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
-						sdk.NewAttribute(types.AttributeKeyCancelReason, batch.Swaps[i].CancelReason),
-					))
-
-					// Return from amount to swapper
-					err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-						types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
-					if err != nil {
-						panic(err)
-					}
-				} else {
-					// Panic here since all calculations should have been done
-					// correctly to prevent any errors during the swap
-					panic(err)
+				k.cancelSwapOrder(ctx, token, so, err)
+			} else if returns.IsZero() {
+				k.cancelSwapOrder(ctx, token, so, sdkerrors.Wrapf(
+					types.ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", so.Amount.Denom, so.ToToken))
+			} else if outputErr := k.checkSwapOutput(ctx, so, returns); outputErr != nil {
+				k.cancelSwapOrder(ctx, token, so, outputErr)
+			} else {
+				remaining = append(remaining, so)
+				totalOuts = totalOuts.Add(returns)
+			}
+		}
+		if len(remaining) != len(swaps) {
+			swaps = remaining
+			continue
+		}
+
+		// Check if new rates violate sanity rate, in which case the latest swap
+		// from the token whose excess was swapped against the reserve is
+		// cancelled, given that this is the token that moved the reserve ratio
+		newReserveBalances := reserveBalances.Add(totalIns...).Sub(totalOuts)
+		if bond.ReservesViolateSanityRate(newReserveBalances) {
+			t1, t2 := bond.ReserveTokens[0], bond.ReserveTokens[1]
+			oldRatio := reserveBalances.AmountOf(t1).ToDec().Quo(reserveBalances.AmountOf(t2).ToDec())
+			newRatio := newReserveBalances.AmountOf(t1).ToDec().Quo(newReserveBalances.AmountOf(t2).ToDec())
+			excessToken := t2
+			if newRatio.GT(oldRatio) {
+				excessToken = t1
+			}
+			latest := len(swaps) - 1
+			for i := len(swaps) - 1; i >= 0; i-- {
+				if swaps[i].Amount.Denom == excessToken {
+					latest = i
+					break
 				}
 			}
+			k.cancelSwapOrder(ctx, token, swaps[latest], sdkerrors.Wrap(
+				types.ErrValuesViolateSanityRate, newReserveBalances.String()))
+			swaps = append(swaps[:latest], swaps[latest+1:]...)
+			continue
+		}
+		break
+	}
+
+	// Add fee-reduced coins to be swapped to reserve, and perform the swaps
+	if len(swaps) > 0 {
+		err := k.DepositReserveFromModule(
+			ctx, bond.Token, types.BatchesIntermediaryAccount, totalIns)
+		if err != nil {
+			panic(err)
+		}
+	}
+	for _, so := range swaps {
+		returns, txFee, rate := getSwapReturns(bond, so, totalIns, totalReturns)
+		err := k.PerformSwap(ctx, token, so, sdk.Coins{returns}, txFee, rate)
+		if err != nil {
+			// Panic here since all calculations should have been done
+			// correctly to prevent any errors during the swap
+			panic(err)
+		}
+	}
+
+	// Report the clearing rate of the swaps in each direction
+	if len(swaps) > 0 {
+		for _, from := range bond.ReserveTokens {
+			totalIn := totalIns.AmountOf(from)
+			if totalIn.IsZero() {
+				continue
+			}
+			to := bond.ReserveTokens[0]
+			if from == to {
+				to = bond.ReserveTokens[1]
+			}
+			totalReturn := totalReturns.AmountOf(to)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeSwapClearing,
+				sdk.NewAttribute(types.AttributeKeyBond, token),
+				sdk.NewAttribute(types.AttributeKeySwapFromToken, from),
+				sdk.NewAttribute(types.AttributeKeySwapToToken, to),
+				sdk.NewAttribute(types.AttributeKeyTotalSwapped, totalIn.String()),
+				sdk.NewAttribute(types.AttributeKeyTotalReturned, totalReturn.String()),
+				sdk.NewAttribute(types.AttributeKeyClearingRate, totalReturn.QuoTruncate(totalIn.ToDec()).String()),
+			))
+		}
+	}
+}
+
+// AllocateMaxSupply reduces the buy orders of the current batch such that they
+// do not exceed the remaining supply of the bond, if the bond allocates its
+// remaining supply. Buy orders allocated nothing are cancelled, while the max
+// prices of reduced buy orders are partially returned when they are performed.
+func (k Keeper) AllocateMaxSupply(ctx sdk.Context, token string) error {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
+
+	remainingSupply := bond.MaxSupply.Sub(bond.CurrentSupply)
+	if !bond.AllocatesMaxSupply() || !remainingSupply.IsLT(batch.TotalBuyAmount) {
+		return nil
+	}
+
+	var buyOrders []types.BuyOrder
+	var requested []sdk.Int
+	for _, bo := range k.GetBuyOrders(ctx, token) {
+		if !bo.IsCancelled() {
+			buyOrders = append(buyOrders, bo)
+			requested = append(requested, bo.Amount.Amount)
+		}
+	}
+
+	allocated := types.AllocateSupply(
+		bond.MaxSupplyAllocation, requested, remainingSupply.Amount)
+	for i, bo := range buyOrders {
+		if allocated[i].IsZero() {
+			// Cancel (returns max prices to buyer)
+			reason := sdkerrors.Wrap(types.ErrBondSoldOut, "no supply allocated to order")
+			key := types.GetBatchOrderKey(token, types.BuyOrdersKeyPrefix, bo.ID)
+			err := k.cancelOrderWithKey(ctx, token, types.AttributeValueBuyOrder,
+				key, bo.Address, reason.Error())
+			if err != nil {
+				return err
+			}
+		} else if allocated[i].LT(bo.Amount.Amount) {
+			// Reduce (remainder of max prices returned when performed)
+			reduction := bo.Amount.Amount.Sub(allocated[i])
+			bo.Amount = sdk.NewCoin(bo.Amount.Denom, allocated[i])
+			k.SetBuyOrder(ctx, token, bo)
+
+			batch = k.MustGetBatchHeader(ctx, token)
+			batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(
+				sdk.NewCoin(bo.Amount.Denom, reduction))
+			k.SetBatchHeader(ctx, token, batch)
+
+			logger.Info(fmt.Sprintf("allocated %s of buy order for %s to %s",
+				bo.Amount.String(), bo.RequestedAmount.String(), bo.Address.String()))
 		}
 	}
 
-	// Update batch with any new cancellations
-	k.SetBatch(ctx, token, batch)
+	return nil
 }
 
 func (k Keeper) PerformOrders(ctx sdk.Context, token string) {
+	// For swappers, note the product before performing orders, so that the
+	// swapper invariant can check that the orders did not decrease it
+	bond := k.MustGetBond(ctx, token)
+	if bond.FunctionType == types.SwapperFunction {
+		k.SetSwapperProduct(ctx, token, bond.GetSwapperProduct(bond.CurrentReserve))
+	}
+
+	// Allocate the remaining supply if the buys exceed the max supply
+	err := k.AllocateMaxSupply(ctx, token)
+	if err != nil {
+		panic(err)
+	}
+
 	k.PerformBuyOrders(ctx, token)
 	k.PerformSellOrders(ctx, token)
+	k.PrepareLiquidityOrders(ctx, token)
 	k.PerformSwapOrders(ctx, token)
+	k.PerformBuyWithReserveOrders(ctx, token)
+	k.PerformAddLiquidityOrders(ctx, token)
+
+	// If the orders took the bond's supply up to the max supply, the bond is
+	// sold out (at least until any tokens are sold back to the bond)
+	newSupply := k.MustGetBond(ctx, token).CurrentSupply
+	if bond.CurrentSupply.IsLT(bond.MaxSupply) && !newSupply.IsLT(bond.MaxSupply) {
+		k.Logger(ctx).Info(fmt.Sprintf("bond %s sold out", token))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeBondSoldOut,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyMaxSupply, bond.MaxSupply.String()),
+		))
+	}
 }
 
 func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) error {
@@ -533,8 +1379,9 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reserveRounded := types.RoundReservePrices(reservePrices)
+	fundingTaxes := bond.GetFundingTaxes(reservePrices)
 	txFees := bond.GetTxFees(reservePrices)
-	totalPrices := reserveRounded.Add(txFees...)
+	totalPrices := reserveRounded.Add(fundingTaxes...).Add(txFees...)
 
 	// Check that max prices not exceeded
 	if totalPrices.IsAnyGT(bo.MaxPrices) {
@@ -544,18 +1391,77 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 	return nil
 }
 
+// GetBuyOrderPartialFillAmount returns the largest amount, up to the amount of
+// the buy order, that can be bought at the specified prices without exceeding
+// the max prices of the buy order. This amount can be zero.
+func (k Keeper) GetBuyOrderPartialFillAmount(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) sdk.Int {
+	// Binary search for the largest amount that does not exceed max prices
+	low, high := sdk.ZeroInt(), bo.Amount.Amount
+	for low.LT(high) {
+		mid := low.Add(high).AddRaw(1).QuoRaw(2)
+		bo.Amount = sdk.NewCoin(bo.Amount.Denom, mid)
+		if k.CheckIfBuyOrderFulfillableAtPrice(ctx, token, bo, prices) == nil {
+			low = mid
+		} else {
+			high = mid.SubRaw(1)
+		}
+	}
+	return low
+}
+
+func (k Keeper) CheckIfSellOrderFulfillableAtPrice(ctx sdk.Context, token string, so types.SellOrder, prices sdk.DecCoins) error {
+	bond := k.MustGetBond(ctx, token)
+
+	reserveReturns := types.MultiplyDecCoinsByInt(prices, so.Amount.Amount)
+	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
+	txFees := bond.GetTxFees(reserveReturns)
+	exitFees := bond.GetExitFees(reserveReturns)
+
+	totalFees := types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	totalReturns := reserveReturnsRounded.Sub(totalFees)
+
+	// Check that min returns met
+	if !totalReturns.IsAllGTE(so.MinReturns) {
+		return sdkerrors.Wrapf(types.ErrMinReturnsNotMet, "Actual returns %s do not meet min returns %s", totalReturns, so.MinReturns)
+	}
+
+	return nil
+}
+
+// CancelUnfulfillableBuys cancels any buy orders that are unfulfillable at the
+// current batch buy prices. Buy orders that allow partial fills are instead
+// reduced to the largest amount that is fulfillable, and are only cancelled if
+// this amount is zero. Reduced orders are included in the returned count since,
+// just like cancellations, these change the batch prices.
 func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelledOrders int) {
 	logger := k.Logger(ctx)
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 
-	// Cancel unfulfillable buys
-	for i, bo := range batch.Buys {
+	// Cancel (or partially fill) unfulfillable buys
+	for _, bo := range k.GetBuyOrders(ctx, token) {
 		if !bo.IsCancelled() {
 			err := k.CheckIfBuyOrderFulfillableAtPrice(ctx, token, bo, batch.BuyPrices)
+			if err != nil && bo.AllowPartial {
+				fillAmount := k.GetBuyOrderPartialFillAmount(ctx, token, bo, batch.BuyPrices)
+				if fillAmount.IsPositive() {
+					// Reduce amount (max prices refunded in full when performed)
+					reduction := bo.Amount.Sub(sdk.NewCoin(bo.Amount.Denom, fillAmount))
+					bo.Amount = sdk.NewCoin(bo.Amount.Denom, fillAmount)
+					k.SetBuyOrder(ctx, token, bo)
+					batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(reduction)
+					cancelledOrders += 1
+
+					logger.Info(fmt.Sprintf("reduced buy order from %s to %s for %s",
+						bo.RequestedAmount.String(), bo.Amount.String(), bo.Address.String()))
+					logger.Debug(fmt.Sprintf("reduction reason: %s", err.Error()))
+					continue
+				}
+			}
 			if err != nil {
-				// Cancel (important to use batch.Buys[i] and not bo!)
-				batch.Buys[i].Cancelled = true
-				batch.Buys[i].CancelReason = err.Error()
+				// Cancel
+				bo.Cancelled = true
+				bo.CancelReason = err.Error()
+				k.SetBuyOrder(ctx, token, bo)
 				batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(bo.Amount)
 				cancelledOrders += 1
 
@@ -566,8 +1472,9 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 					types.EventTypeOrderCancel,
 					sdk.NewAttribute(types.AttributeKeyBond, token),
 					sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+					sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(bo.ID, 10)),
 					sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
-					sdk.NewAttribute(types.AttributeKeyCancelReason, batch.Buys[i].CancelReason),
+					sdk.NewAttribute(types.AttributeKeyCancelReason, bo.CancelReason),
 				))
 
 				// Return reserve to buyer
@@ -581,30 +1488,303 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 	}
 
 	// Save batch and return number of cancelled orders
-	k.SetBatch(ctx, token, batch)
+	k.SetBatchHeader(ctx, token, batch)
 	return cancelledOrders
 }
 
+func (k Keeper) CancelUnfulfillableSells(ctx sdk.Context, token string) (cancelledOrders int) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatchHeader(ctx, token)
+
+	// Cancel unfulfillable sells
+	for _, so := range k.GetSellOrders(ctx, token) {
+		if !so.IsCancelled() {
+			err := k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, batch.SellPrices)
+			if err != nil {
+				// Cancel
+				so.Cancelled = true
+				so.CancelReason = err.Error()
+				k.SetSellOrder(ctx, token, so)
+				batch.TotalSellAmount = batch.TotalSellAmount.Sub(so.Amount)
+				cancelledOrders += 1
+
+				logger.Info(fmt.Sprintf("cancelled sell order for %s from %s", so.Amount.String(), so.Address.String()))
+				logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+				ctx.EventManager().EmitEvent(sdk.NewEvent(
+					types.EventTypeOrderCancel,
+					sdk.NewAttribute(types.AttributeKeyBond, token),
+					sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+					sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(so.ID, 10)),
+					sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+					sdk.NewAttribute(types.AttributeKeyCancelReason, so.CancelReason),
+				))
+
+				// Re-mint bond tokens burned in handleMsgSell
+				err := k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
+					sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+
+				// Return bond tokens to seller
+				err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+					types.BondsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+	}
+
+	// Save batch and return number of cancelled orders
+	k.SetBatchHeader(ctx, token, batch)
+	return cancelledOrders
+}
+
+// getOrdersKeyPrefix returns the key prefix under which the orders of the
+// specified type are stored in a batch.
+func getOrdersKeyPrefix(orderType string) ([]byte, error) {
+	switch orderType {
+	case types.AttributeValueBuyOrder:
+		return types.BuyOrdersKeyPrefix, nil
+	case types.AttributeValueBuyWithReserveOrder:
+		return types.BuyWithReserveOrdersKeyPrefix, nil
+	case types.AttributeValueSellOrder:
+		return types.SellOrdersKeyPrefix, nil
+	case types.AttributeValueSwapOrder:
+		return types.SwapOrdersKeyPrefix, nil
+	case types.AttributeValueAddLiquidityOrder:
+		return types.AddLiquidityOrdersKeyPrefix, nil
+	case types.AttributeValueRemoveLiquidityOrder:
+		return types.RemoveLiquidityOrdersKeyPrefix, nil
+	default:
+		return nil, sdkerrors.Wrap(types.ErrUnrecognizedOrderType, orderType)
+	}
+}
+
+// CancelOrder cancels an uncancelled order of the specified type and with the
+// specified ID in the current batch, on behalf of the order's address. Any
+// reserve tokens held for the order are returned, any bond tokens burned for
+// the order are re-minted and returned, and the batch prices are updated.
+func (k Keeper) CancelOrder(ctx sdk.Context, token, orderType string, id uint64,
+	address sdk.AccAddress, reason string) error {
+	keyPrefix, err := getOrdersKeyPrefix(orderType)
+	if err != nil {
+		return err
+	}
+
+	key := types.GetBatchOrderKey(token, keyPrefix, id)
+	if !ctx.KVStore(k.storeKey).Has(key) {
+		return sdkerrors.Wrapf(types.ErrOrderDoesNotExist, "%s order %d", orderType, id)
+	}
+
+	return k.cancelOrderWithKey(ctx, token, orderType, key, address, reason)
+}
+
+func (k Keeper) cancelOrderWithKey(ctx sdk.Context, token, orderType string,
+	key []byte, address sdk.AccAddress, reason string) error {
+	logger := k.Logger(ctx)
+	store := ctx.KVStore(k.storeKey)
+	batch := k.MustGetBatchHeader(ctx, token)
+
+	var order interface{}
+	var baseOrder *types.BaseOrder
+	var toReturn sdk.Coins
+	var toReMint sdk.Coins
+	switch orderType {
+	case types.AttributeValueBuyOrder:
+		var bo types.BuyOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &bo)
+		order, baseOrder, toReturn = &bo, &bo.BaseOrder, bo.MaxPrices
+	case types.AttributeValueBuyWithReserveOrder:
+		var bo types.BuyWithReserveOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &bo)
+		order, baseOrder, toReturn = &bo, &bo.BaseOrder, bo.Budget
+	case types.AttributeValueSellOrder:
+		var so types.SellOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &so)
+		order, baseOrder, toReMint = &so, &so.BaseOrder, sdk.Coins{so.Amount}
+	case types.AttributeValueSwapOrder:
+		var so types.SwapOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &so)
+		order, baseOrder, toReturn = &so, &so.BaseOrder, sdk.Coins{so.Amount}
+	case types.AttributeValueAddLiquidityOrder:
+		var ao types.AddLiquidityOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &ao)
+		order, baseOrder, toReturn = &ao, &ao.BaseOrder, ao.Budget
+	case types.AttributeValueRemoveLiquidityOrder:
+		var ro types.RemoveLiquidityOrder
+		k.cdc.MustUnmarshalBinaryBare(store.Get(key), &ro)
+		order, baseOrder, toReMint = &ro, &ro.BaseOrder, sdk.Coins{ro.Amount}
+	default:
+		return sdkerrors.Wrap(types.ErrUnrecognizedOrderType, orderType)
+	}
+	orderAddress := baseOrder.Address
+
+	// The tokens held for a limit order added to the batch stay in escrow for
+	// the limit order, which remains in the limit order book
+	if k.LimitOrderExists(ctx, token, baseOrder.ID) {
+		toReturn, toReMint = nil, nil
+	}
+
+	// Check that the order belongs to the address and is not yet cancelled
+	if !orderAddress.Equals(address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the order's address", address.String())
+	} else if baseOrder.IsCancelled() {
+		return sdkerrors.Wrapf(types.ErrOrderAlreadyCancelled, "%s order %d", orderType, baseOrder.ID)
+	}
+
+	// Cancel (updates order since baseOrder is a pointer into order)
+	baseOrder.Cancelled = true
+	baseOrder.CancelReason = reason
+	store.Set(key, k.cdc.MustMarshalBinaryBare(order))
+	switch orderType {
+	case types.AttributeValueBuyOrder:
+		batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(baseOrder.Amount)
+	case types.AttributeValueSellOrder:
+		batch.TotalSellAmount = batch.TotalSellAmount.Sub(baseOrder.Amount)
+	}
+
+	// Update buy and sell prices since cancellation took place
+	buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+	if err != nil {
+		return err
+	}
+	batch.BuyPrices = buyPrices
+	batch.SellPrices = sellPrices
+	k.SetBatchHeader(ctx, token, batch)
+
+	// Return reserve tokens held for the order
+	if !toReturn.IsZero() {
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, orderAddress, toReturn)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Re-mint and return bond tokens burned in handleMsgSell (or in
+	// handleMsgRemoveLiquidity)
+	if !toReMint.IsZero() {
+		err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, toReMint)
+		if err != nil {
+			return err
+		}
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BondsMintBurnAccount, orderAddress, toReMint)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Info(fmt.Sprintf("cancelled %s order %d from %s", orderType, baseOrder.ID, orderAddress.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, orderType),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(baseOrder.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, orderAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, reason),
+	))
+
+	return nil
+}
+
+// CancelAllOrders cancels all of the orders in the current batch that are not
+// yet cancelled, returning any tokens held for the orders to their owners.
+func (k Keeper) CancelAllOrders(ctx sdk.Context, token, reason string) error {
+	cancel := func(orderType string, keyPrefix []byte, id uint64, address sdk.AccAddress) error {
+		key := types.GetBatchOrderKey(token, keyPrefix, id)
+		return k.cancelOrderWithKey(ctx, token, orderType, key, address, reason)
+	}
+
+	for _, bo := range k.GetBuyOrders(ctx, token) {
+		if !bo.IsCancelled() {
+			err := cancel(types.AttributeValueBuyOrder,
+				types.BuyOrdersKeyPrefix, bo.ID, bo.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, bo := range k.GetBuyWithReserveOrders(ctx, token) {
+		if !bo.IsCancelled() {
+			err := cancel(types.AttributeValueBuyWithReserveOrder,
+				types.BuyWithReserveOrdersKeyPrefix, bo.ID, bo.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, so := range k.GetSellOrders(ctx, token) {
+		if !so.IsCancelled() {
+			err := cancel(types.AttributeValueSellOrder,
+				types.SellOrdersKeyPrefix, so.ID, so.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, so := range k.GetSwapOrders(ctx, token) {
+		if !so.IsCancelled() {
+			err := cancel(types.AttributeValueSwapOrder,
+				types.SwapOrdersKeyPrefix, so.ID, so.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, ao := range k.GetAddLiquidityOrders(ctx, token) {
+		if !ao.IsCancelled() {
+			err := cancel(types.AttributeValueAddLiquidityOrder,
+				types.AddLiquidityOrdersKeyPrefix, ao.ID, ao.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, ro := range k.GetRemoveLiquidityOrders(ctx, token) {
+		if !ro.IsCancelled() {
+			err := cancel(types.AttributeValueRemoveLiquidityOrder,
+				types.RemoveLiquidityOrdersKeyPrefix, ro.ID, ro.Address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (k Keeper) CancelUnfulfillableOrders(ctx sdk.Context, token string) (cancelledOrders int) {
-	batch := k.MustGetBatch(ctx, token)
 	cancelledOrders = 0
 
-	cancelledOrders += k.CancelUnfulfillableBuys(ctx, token)
-	//cancelledOrders += k.CancelUnfulfillableSells(ctx, token) // Sells always fulfillable
-	//cancelledOrders += k.CancelUnfulfillableSwaps(ctx, token) // Swaps only cancelled while they are being performed
+	// Cancelling (or reducing) orders changes the batch prices, which can make
+	// other orders unfulfillable, so we keep cancelling until no more orders get
+	// cancelled. This terminates since every iteration either cancels an order
+	// or strictly reduces the amount of a buy order.
+	for {
+		cancelled := k.CancelUnfulfillableBuys(ctx, token)
+		cancelled += k.CancelUnfulfillableSells(ctx, token)
+		//cancelled += k.CancelUnfulfillableSwaps(ctx, token) // Swaps only cancelled while they are being performed
+		if cancelled == 0 {
+			break
+		}
+		cancelledOrders += cancelled
 
-	// Update buy and sell prices if any cancellation took place
-	if cancelledOrders > 0 {
-		batch = k.MustGetBatch(ctx, token) // get batch again
+		// Update buy and sell prices since cancellations took place
+		batch := k.MustGetBatchHeader(ctx, token)
 		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
 		if err != nil {
 			panic(err)
 		}
 		batch.BuyPrices = buyPrices
 		batch.SellPrices = sellPrices
+		k.SetBatchHeader(ctx, token, batch)
 	}
 
-	// Save batch and return number of cancelled orders
-	k.SetBatch(ctx, token, batch)
 	return cancelledOrders
 }
@@ -1,7 +1,9 @@
 package keeper_test
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -28,6 +30,57 @@ func TestBatchExistsSetGet(t *testing.T) {
 	require.Equal(t, batchAdded, batchFetched)
 }
 
+func TestBatchOrdersStoredSeparatelyFromHeader(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batch with orders
+	batchAdded := getValidBatch()
+	batchAdded.Buys = []types.BuyOrder{getValidBuyOrder()}
+	batchAdded.Sells = []types.SellOrder{getValidSellOrder()}
+	batchAdded.Swaps = []types.SwapOrder{getValidSwapOrder()}
+	batchAdded.Buys[0].ID = 0
+	batchAdded.Sells[0].ID = 1
+	batchAdded.Swaps[0].ID = 2
+	app.BondsKeeper.SetBatch(ctx, token, batchAdded)
+	require.True(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+
+	// Batch header does not include the orders
+	header := app.BondsKeeper.MustGetBatchHeader(ctx, token)
+	require.False(t, header.HasOrders())
+	require.Equal(t, batchAdded.TotalBuyAmount, header.TotalBuyAmount)
+
+	// Full batch includes the orders
+	require.Equal(t, batchAdded, app.BondsKeeper.MustGetBatch(ctx, token))
+
+	// Setting the header leaves the orders untouched
+	header.BlocksRemaining = sdk.NewUint(100)
+	app.BondsKeeper.SetBatchHeader(ctx, token, header)
+	batchAdded.BlocksRemaining = sdk.NewUint(100)
+	require.Equal(t, batchAdded, app.BondsKeeper.MustGetBatch(ctx, token))
+
+	// Setting a new batch replaces the orders
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+	require.Equal(t, getValidBatch(), app.BondsKeeper.MustGetBatch(ctx, token))
+}
+
+func TestBatchOrdersNotMixedUpBetweenBonds(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batches for two bonds, one token being a prefix of the other
+	app.BondsKeeper.SetBatch(ctx, token, types.NewBatch(token, batchBlocks))
+	app.BondsKeeper.SetBatch(ctx, token+"0", types.NewBatch(token+"0", batchBlocks))
+
+	// Add order to the bond with the longer token
+	bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(token+"0", 1), maxPrices)
+	app.BondsKeeper.AddBuyOrder(ctx, token+"0", bo, buyPrices, sellPrices)
+
+	// Order only belongs to the bond with the longer token
+	require.False(t, app.BondsKeeper.BatchHasOrders(ctx, token))
+	require.Len(t, app.BondsKeeper.GetBuyOrders(ctx, token), 0)
+	require.Len(t, app.BondsKeeper.GetBuyOrders(ctx, token+"0"), 1)
+}
+
 func TestLastBatchExistsSetGet(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -49,6 +102,156 @@ func TestLastBatchExistsSetGet(t *testing.T) {
 	require.Equal(t, batchAdded, batchFetched)
 }
 
+func TestAddSettledBatchPrunesExpiredBatches(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Set batch history retention to 10 blocks
+	params := app.BondsKeeper.GetParams(ctx)
+	params.BatchHistoryRetention = 10
+	app.BondsKeeper.SetParams(ctx, params)
+
+	// Initially empty history
+	require.Len(t, app.BondsKeeper.GetBatchesHistory(ctx, token), 0)
+
+	// Add settled batches at heights 1, 5, and 11
+	batch := getValidBatch()
+	for _, height := range []int64{1, 5, 11} {
+		app.BondsKeeper.AddSettledBatch(ctx.WithBlockHeight(height), token, batch)
+	}
+
+	// Batch at height 1 was pruned at height 11
+	expected := []types.SettledBatch{
+		types.NewSettledBatch(5, batch),
+		types.NewSettledBatch(11, batch),
+	}
+	require.Equal(t, expected, app.BondsKeeper.GetBatchesHistory(ctx, token))
+
+	// Batches history of another bond is not affected by this bond's history
+	require.Len(t, app.BondsKeeper.GetBatchesHistory(ctx, token1), 0)
+
+	// Disabling the batch history prunes all settled batches
+	params.BatchHistoryRetention = 0
+	app.BondsKeeper.SetParams(ctx, params)
+	app.BondsKeeper.AddSettledBatch(ctx.WithBlockHeight(12), token, batch)
+	require.Len(t, app.BondsKeeper.GetBatchesHistory(ctx, token), 0)
+}
+
+func TestGetBatchesHistoryPage(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add settled batches at heights 1 to 5
+	batch := getValidBatch()
+	for height := int64(1); height <= 5; height++ {
+		app.BondsKeeper.SetSettledBatch(ctx, types.NewSettledBatch(height, batch))
+	}
+
+	testCases := []struct {
+		page            int
+		limit           int
+		expectedHeights []int64
+	}{
+		{1, 2, []int64{1, 2}},
+		{2, 2, []int64{3, 4}},
+		{3, 2, []int64{5}},
+		{4, 2, nil},
+		{1, 10, []int64{1, 2, 3, 4, 5}},
+		{0, 2, nil},
+		{1, 0, nil},
+	}
+	for _, tc := range testCases {
+		var heights []int64
+		for _, sb := range app.BondsKeeper.GetBatchesHistoryPage(ctx, token, tc.page, tc.limit) {
+			heights = append(heights, sb.Height)
+		}
+		require.Equal(t, tc.expectedHeights, heights)
+	}
+}
+
+func TestScheduleAndUnscheduleBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(100)
+
+	// Add batch (5 blocks)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	require.False(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
+	require.Equal(t, batchBlocks, app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+
+	// Adding the first order schedules the batch, with the current block
+	// counting as the first of the batch's 5 blocks
+	app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	require.True(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
+	require.Equal(t, int64(104), app.BondsKeeper.MustGetBatch(ctx, token).ExecutionHeight)
+	require.Equal(t, sdk.NewUint(4), app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+
+	// Adding another order does not reschedule the batch
+	ctx = ctx.WithBlockHeight(102)
+	app.BondsKeeper.AddSellOrder(ctx, token, getValidSellOrder(), buyPrices, sellPrices)
+	require.Equal(t, int64(104), app.BondsKeeper.MustGetBatch(ctx, token).ExecutionHeight)
+	require.Equal(t, sdk.NewUint(2), app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+
+	// Unscheduling keeps note of the remaining blocks, counting the current one
+	app.BondsKeeper.UnscheduleBatch(ctx, token)
+	require.False(t, app.BondsKeeper.BatchIsScheduled(ctx, token))
+	require.Equal(t, sdk.NewUint(3), app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+
+	// Scheduling again continues from where the batch was left
+	ctx = ctx.WithBlockHeight(200)
+	app.BondsKeeper.ScheduleBatch(ctx, token)
+	require.Equal(t, int64(202), app.BondsKeeper.MustGetBatch(ctx, token).ExecutionHeight)
+	require.Equal(t, sdk.NewUint(2), app.BondsKeeper.GetBatchBlocksRemaining(ctx, token))
+}
+
+func TestGetBatchesDue(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batches and schedule them at heights 10, 11, and 10
+	for i, tkn := range []string{token1, token2, token3} {
+		batch := types.NewBatch(tkn, sdk.NewUint(uint64(i%2+1)))
+		app.BondsKeeper.SetBatch(ctx, tkn, batch)
+		app.BondsKeeper.ScheduleBatch(ctx.WithBlockHeight(10), tkn)
+	}
+
+	// Nothing due at height 9
+	require.Len(t, app.BondsKeeper.GetBatchesDue(ctx.WithBlockHeight(9)), 0)
+
+	// Two batches due at height 10, which are removed from the schedule
+	due := app.BondsKeeper.GetBatchesDue(ctx.WithBlockHeight(10))
+	require.Equal(t, []string{token1, token3}, due)
+	require.False(t, app.BondsKeeper.BatchIsScheduled(ctx, token1))
+	require.True(t, app.BondsKeeper.BatchIsScheduled(ctx, token2))
+
+	// Overdue batches are also returned
+	due = app.BondsKeeper.GetBatchesDue(ctx.WithBlockHeight(20))
+	require.Equal(t, []string{token2}, due)
+	require.Len(t, app.BondsKeeper.GetBatchesDue(ctx.WithBlockHeight(20)), 0)
+}
+
+// The cost of the EndBlocker should not depend on the number of idle bonds,
+// i.e. bonds whose batch does not have any orders
+func BenchmarkEndBlockerWithIdleBonds(b *testing.B) {
+	for _, noOfBonds := range []int{10, 100, 1000, 5000} {
+		b.Run(fmt.Sprintf("%d idle bonds", noOfBonds), func(b *testing.B) {
+			app, ctx := createTestApp(false)
+			for i := 0; i < noOfBonds; i++ {
+				bond := getValidBond()
+				bond.Token = fmt.Sprintf("idletoken%d", i)
+				app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+				app.BondsKeeper.SetBatch(ctx, bond.Token,
+					types.NewBatch(bond.Token, sdk.OneUint()))
+			}
+
+			// Flush the writes so that the benchmark is not dominated by the
+			// cache store having to sort its uncommitted writes when iterating
+			ctx.MultiStore().(sdk.CacheMultiStore).Write()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bonds.EndBlocker(ctx.WithBlockHeight(int64(i)), app.BondsKeeper)
+			}
+		})
+	}
+}
+
 func TestBatchAddBuyOrder(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -115,6 +318,60 @@ func TestBatchAddSwapOrder(t *testing.T) {
 	require.Equal(t, batchFetched.Swaps[0], swapOrder)
 }
 
+func TestBatchAddBuyWithReserveOrder(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batch
+	batchAdded := getValidBatch()
+	app.BondsKeeper.SetBatch(ctx, token, batchAdded)
+	require.True(t, app.BondsKeeper.BatchExists(ctx, token))
+
+	// Add buy with reserve order
+	budget := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	buyWithReserveOrder := types.NewBuyWithReserveOrder(buyerAddress, token, budget)
+	app.BondsKeeper.AddBuyWithReserveOrder(ctx, token, buyWithReserveOrder)
+
+	// Get and check batch
+	batchFetched := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, len(batchFetched.Buys), 0)
+	require.Equal(t, len(batchFetched.BuysWithReserve), 1)
+	require.Equal(t, batchFetched.TotalBuyAmount, sdk.NewCoin(token, sdk.ZeroInt()))
+	require.Equal(t, batchFetched.BuysWithReserve[0], buyWithReserveOrder)
+}
+
+func TestBatchAddOrdersAssignsIncreasingIDs(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add batch
+	batchAdded := getValidBatch()
+	app.BondsKeeper.SetBatch(ctx, token, batchAdded)
+
+	// Add orders of each type
+	budget := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	buyWithReserveOrder := types.NewBuyWithReserveOrder(buyerAddress, token, budget)
+	buyID := app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	sellID := app.BondsKeeper.AddSellOrder(ctx, token, getValidSellOrder(), buyPrices, sellPrices)
+	swapID := app.BondsKeeper.AddSwapOrder(ctx, token, getValidSwapOrder())
+	buyWithReserveID := app.BondsKeeper.AddBuyWithReserveOrder(ctx, token, buyWithReserveOrder)
+	secondBuyID := app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+
+	// Check returned IDs
+	require.Equal(t, uint64(0), buyID)
+	require.Equal(t, uint64(1), sellID)
+	require.Equal(t, uint64(2), swapID)
+	require.Equal(t, uint64(3), buyWithReserveID)
+	require.Equal(t, uint64(4), secondBuyID)
+
+	// Check IDs stored in batch
+	batchFetched := app.BondsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, buyID, batchFetched.Buys[0].ID)
+	require.Equal(t, sellID, batchFetched.Sells[0].ID)
+	require.Equal(t, swapID, batchFetched.Swaps[0].ID)
+	require.Equal(t, buyWithReserveID, batchFetched.BuysWithReserve[0].ID)
+	require.Equal(t, secondBuyID, batchFetched.Buys[1].ID)
+	require.Equal(t, uint64(5), batchFetched.NextOrderID)
+}
+
 func TestGetBatchBuySellPrices(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -173,7 +430,7 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 
 	// (Re)Create batch with sell order
 	batch = getValidBatch()
-	so := types.NewSellOrder(sellerAddress, fiveTokens)
+	so := types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Sells = append(batch.Sells, so)
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
 
@@ -194,7 +451,7 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 	batch = getValidBatch()
 	bo1 := types.NewBuyOrder(buyerAddress, fiveTokens, nil)
 	bo2 := types.NewBuyOrder(buyerAddress, fiveTokens, nil) // 5 more
-	so = types.NewSellOrder(sellerAddress, fiveTokens)
+	so = types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Buys = append(batch.Buys, bo1, bo2)
 	batch.Sells = append(batch.Sells, so)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo1.Amount).Add(bo2.Amount)
@@ -219,8 +476,8 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 	// (Re)Create batch with sell amount > buy amount
 	batch = getValidBatch()
 	bo = types.NewBuyOrder(buyerAddress, fiveTokens, nil)
-	so1 := types.NewSellOrder(sellerAddress, fiveTokens)
-	so2 := types.NewSellOrder(sellerAddress, fiveTokens)
+	so1 := types.NewSellOrder(sellerAddress, fiveTokens, nil)
+	so2 := types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Buys = append(batch.Buys, bo)
 	batch.Sells = append(batch.Sells, so1, so2)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo1.Amount)
@@ -277,6 +534,33 @@ func TestGetUpdatedBatchPricesAfterBuy(t *testing.T) {
 	require.Equal(t, expectedSellPrices, sellPrices)
 }
 
+func TestGetUpdatedBatchPricesAfterBuyWithMaxSupplyAllocation(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create bond (that allocates its remaining supply) and batch
+	bond := getValidBond()
+	bond.MaxSupplyAllocation = types.ProRataAllocation
+	bond.CurrentSupply = bond.MaxSupply.Sub(sdk.NewInt64Coin(bond.Token, 10))
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Buy order with buy amount greater than remaining supply is accepted,
+	// but the prices only consider the remaining supply
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(bond.ReserveTokens[0], 100000000000000))
+	bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(bond.Token, 20), maxPrices)
+	buyPrices, _, err := app.BondsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.Token, bo)
+	require.NoError(t, err)
+	expectedBuyPrices, _ := bond.GetPricesToMint(sdk.NewInt(10), nil)
+	require.Equal(t, types.DivideDecCoinsByDec(expectedBuyPrices, sdk.NewDec(10)), buyPrices)
+
+	// Buy order is rejected if the bond is sold out
+	bond.CurrentSupply = bond.MaxSupply
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	_, _, err = app.BondsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.Token, bo)
+	require.Error(t, err)
+	require.True(t, types.ErrBondSoldOut.Is(err))
+}
+
 func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -290,7 +574,7 @@ func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 	sellAmount := sdk.NewCoin(bond.Token, sdk.OneInt())
 
 	// Sell order when current supply is zero is not fulfillable
-	so := types.NewSellOrder(sellerAddress, sellAmount)
+	so := types.NewSellOrder(sellerAddress, sellAmount, nil)
 	_, _, err := app.BondsKeeper.GetUpdatedBatchPricesAfterSell(ctx, bond.Token, so)
 	require.Error(t, err)
 
@@ -304,7 +588,7 @@ func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 		ctx, bond.Token, types.BondsMintBurnAccount, reserveBalance)
 
 	// Check sell prices for fulfillable sell order
-	so = types.NewSellOrder(sellerAddress, sellAmount)
+	so = types.NewSellOrder(sellerAddress, sellAmount, nil)
 	buyPrices, sellPrices, err = app.BondsKeeper.GetUpdatedBatchPricesAfterSell(ctx, bond.Token, so)
 	expectedBuyPrices, _ := bond.GetCurrentPricesPT(nil)
 	expectedSellPrices := bond.GetReturnsForBurn(sellAmount.Amount, reserveBalance)
@@ -527,6 +811,43 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 	}
 }
 
+func TestGetBuyWithReserveAmount(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+
+	// Prices of buying n tokens (excl. fees) from power function bond
+	// n=1: 104, n=2: 232, n=3: 408, n=4: 656, n=5: 1000
+
+	testCases := []struct {
+		budget              int64
+		orderQuantityLimits sdk.Coins
+		expectedAmount      int64
+		expectedPrices      int64
+		expectError         bool
+	}{
+		{104, nil, 0, 0, true},    // 104 + 1 (fee) > 104
+		{105, nil, 1, 104, false}, // 104 + 1 (fee) <= 105
+		{656, nil, 3, 408, false}, // 656 + 1 (fee) > 656
+		{657, nil, 4, 656, false}, // 656 + 1 (fee) <= 657
+		{1000, sdk.Coins{sdk.NewInt64Coin(bond.Token, 2)}, 2, 232, false},
+	}
+	for _, tc := range testCases {
+		bond.OrderQuantityLimits = tc.orderQuantityLimits
+		app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+		budget := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, tc.budget))
+		amount, prices, err := app.BondsKeeper.GetBuyWithReserveAmount(ctx, bond.Token, budget)
+		if tc.expectError {
+			require.Error(t, err)
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewInt(tc.expectedAmount), amount)
+		require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, tc.expectedPrices)), prices)
+	}
+}
+
 func TestPerformSellAtPrice(t *testing.T) {
 	app, ctx := createTestApp(false)
 	bond := getValidBond()
@@ -561,7 +882,7 @@ func TestPerformSellAtPrice(t *testing.T) {
 
 	for _, tc := range testCases {
 		// Create sell order
-		so := types.NewSellOrder(sellerAddress, sellAmount)
+		so := types.NewSellOrder(sellerAddress, sellAmount, nil)
 
 		// Set transaction and exit fee and current supply
 		bond.TxFeePercentage = tc.txFee
@@ -675,7 +996,7 @@ func TestPerformSwap(t *testing.T) {
 		fromAmount := sdk.NewCoin(tc.fromToken, swapAmount)
 		fromAmounts := sdk.Coins{fromAmount}
 		fromAmountsDec := sdk.DecCoins{sdk.NewDecCoinFromCoin(fromAmount)}
-		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken)
+		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken, sdk.NewCoin(tc.toToken, sdk.ZeroInt()))
 
 		// Set transaction fee, sanity rates, and initial reserve balances
 		bond.TxFeePercentage = tc.txFee
@@ -707,16 +1028,20 @@ func TestPerformSwap(t *testing.T) {
 		prevFeeAddrBal := app.BankKeeper.GetCoins(ctx, bond.FeeAddress)
 		prevSwapperBal := app.BankKeeper.GetCoins(ctx, swapperAddress)
 
-		// Perform swap
-		err, ok := app.BondsKeeper.PerformSwap(ctx, bond.Token, so)
-		require.True(t, ok)
+		// Perform swap (as the only swap in the batch)
+		app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+		app.BondsKeeper.AddSwapOrder(ctx, bond.Token, so)
+		app.BondsKeeper.PerformSwapOrders(ctx, bond.Token)
 
-		// Check if error due to violated sanity rate
+		// Check if cancelled due to violated sanity rate
+		swap := app.BondsKeeper.GetSwapOrders(ctx, bond.Token)[0]
+		require.Equal(t, tc.sanityRateViolated, swap.IsCancelled())
 		if tc.sanityRateViolated {
-			require.Error(t, err)
-			continue // app would panic at this stage
-		} else {
-			require.NoError(t, err)
+			require.Contains(t, swap.CancelReason,
+				types.ErrValuesViolateSanityRate.Error())
+			require.Equal(t, prevSwapperBal.Add(fromAmounts...),
+				app.BankKeeper.GetCoins(ctx, swapperAddress))
+			continue
 		}
 
 		// New values
@@ -842,7 +1167,7 @@ func TestPerformSells(t *testing.T) {
 	for _, tc := range testCases {
 		// Create and add sell order
 		amount := sdk.NewCoin(bond.Token, tc.amount)
-		so := types.NewSellOrder(sellerAddress, amount)
+		so := types.NewSellOrder(sellerAddress, amount, nil)
 		app.BondsKeeper.AddSellOrder(ctx, token, so, blankBuyPrices, sellPrices)
 
 		// Calculate total return
@@ -891,9 +1216,9 @@ func TestPerformSwaps(t *testing.T) {
 	app.BondsKeeper.SetBatch(ctx, bond.Token, batch)
 
 	// Set initial reserves
-	initialInReserve := sdk.NewInt64Coin(reserveToken, 200)
-	initialOutReserve := sdk.NewInt64Coin(reserveToken2, 300)
-	initialReserves := sdk.NewCoins(initialInReserve, initialOutReserve)
+	initialReserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300))
 	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, initialReserves)
 	require.Nil(t, err)
 	err = app.BondsKeeper.DepositReserveFromModule(
@@ -901,78 +1226,107 @@ func TestPerformSwaps(t *testing.T) {
 	require.NoError(t, err)
 
 	testCases := []struct {
-		fromToken        string
+		swapper          sdk.AccAddress
+		from             sdk.Coin
 		toToken          string
-		amount           sdk.Int
+		expectedReturns  sdk.Coins
 		willGetCancelled bool
 	}{
 		{
-			reserveToken, reserveToken2, sdk.NewInt(100), false,
-		}, // 100res to rez
+			buyerAddress, sdk.NewInt64Coin(reserveToken, 100), reserveToken2,
+			sdk.Coins{sdk.NewInt64Coin(reserveToken2, 150)}, false,
+		}, // 100res to rez at 1.5rez/res
 		{
-			reserveToken2, reserveToken, sdk.NewInt(200), false,
-		}, // 200rez to res
+			sellerAddress, sdk.NewInt64Coin(reserveToken2, 200), reserveToken,
+			sdk.Coins{sdk.NewInt64Coin(reserveToken, 133)}, false,
+		}, // 200rez to res at 0.67res/rez (rounded down)
 		{
-			reserveToken, reserveToken2, sdk.NewInt(300), false,
-		}, // 300res to rez
+			swapperAddress, sdk.NewInt64Coin(reserveToken, 300), reserveToken2,
+			sdk.Coins{sdk.NewInt64Coin(reserveToken2, 450)}, false,
+		}, // 300res to rez at 1.5rez/res
 		{
-			reserveToken2, reserveToken, sdk.NewInt(400), false,
-		}, // 400rez to res
+			baseOrderAddress, sdk.NewInt64Coin(reserveToken2, 400), reserveToken,
+			sdk.Coins{sdk.NewInt64Coin(reserveToken, 266)}, false,
+		}, // 400rez to res at 0.67res/rez (rounded down)
 		{
-			reserveToken, reserveToken2, sdk.NewInt(1000), true,
-		}, // 1000res to rez, to violate sanity
+			initCreator, sdk.NewInt64Coin(reserveToken, 1000), reserveToken2,
+			sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)}, true,
+		}, // 1000res to rez, to violate sanity (so is returned back instead)
 	}
 
-	globalTotalReturns := sdk.NewCoins()
-	globalIncreaseInSwapperBal := sdk.NewCoins()
-	globalDecreaseInSwapperBal := sdk.NewCoins()
-	globalReserveBal := initialReserves
-
-	// Previous values
+	// Add swap orders and reserve tokens sent by swappers to module account
 	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
-
-	// Add swap orders
 	for _, tc := range testCases {
-		// Constant product
-		inReserve := sdk.NewCoin(tc.fromToken, globalReserveBal.AmountOf(tc.fromToken))
-		outReserve := sdk.NewCoin(tc.toToken, globalReserveBal.AmountOf(tc.toToken))
-		cp := inReserve.Amount.Mul(outReserve.Amount).ToDec()
-
-		// Create and add swap order
-		fromAmount := sdk.NewCoin(tc.fromToken, tc.amount)
-		fromAmounts := sdk.Coins{fromAmount}
-		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken)
+		so := types.NewSwapOrder(tc.swapper, tc.from, tc.toToken, sdk.NewCoin(tc.toToken, sdk.ZeroInt()))
 		app.BondsKeeper.AddSwapOrder(ctx, token, so)
-
-		// Add reserve tokens sent by swapper to module account address
-		_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), fromAmounts)
+		_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{tc.from})
 		require.NoError(t, err)
-
-		if tc.willGetCancelled {
-			// Reserve returned back to swapper
-			globalIncreaseInSwapperBal = globalIncreaseInSwapperBal.Add(fromAmounts...)
-		} else {
-			// Calculations
-			newInReserveDec := inReserve.Amount.Add(fromAmount.Amount).ToDec()
-			newOutReserveDec := sdk.NewDecCoinFromDec(tc.toToken, cp.Quo(newInReserveDec))
-			totalOuts := sdk.Coins{types.RoundReserveReturn(sdk.NewDecCoinFromCoin(outReserve).Sub(newOutReserveDec))} // out of reserves (i.e. returns)
-			globalIncreaseInSwapperBal = globalIncreaseInSwapperBal.Add(totalOuts...)
-			globalDecreaseInSwapperBal = globalDecreaseInSwapperBal.Add(fromAmounts...)
-			globalReserveBal = globalReserveBal.Add(fromAmounts...).Sub(totalOuts)
-		}
 	}
 
 	// Perform swaps
 	app.BondsKeeper.PerformSwapOrders(ctx, token)
 
-	// New balances
-	newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
-	newReserveBal := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
-	newSwapperBal := app.BankKeeper.GetCoins(ctx, sellerAddress)
+	// Swaps that were not cancelled are all matched against each other at
+	// the reserve ratio (200res:300rez), with the excess 1res (from rounding
+	// down the returns of the rez to res swaps) remaining in the reserve
+	for i, tc := range testCases {
+		require.Equal(t, tc.expectedReturns, app.BankKeeper.GetCoins(ctx, tc.swapper))
+		require.Equal(t, tc.willGetCancelled, app.BondsKeeper.GetSwapOrders(ctx, token)[i].IsCancelled())
+	}
+	require.Equal(t, sdk.Coins(nil), app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, initialReserves.Add(sdk.NewInt64Coin(reserveToken, 1)),
+		app.BondsKeeper.GetReserveBalances(ctx, bond.Token))
+}
 
-	require.Equal(t, sdk.Coins(nil), newModuleAccBal)
-	require.Equal(t, globalReserveBal, newReserveBal)
-	require.Equal(t, globalTotalReturns, newSwapperBal)
+func TestPerformSwapsSameRateRegardlessOfOrder(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create bond and batch (with no fees for simpler test)
+	bond := getValidSwapperBond()
+	bond.TxFeePercentage = sdk.ZeroDec()
+	bond.ExitFeePercentage = sdk.ZeroDec()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Set initial reserves
+	initialReserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 1000))
+	err := app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, initialReserves)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bond.Token, types.BondsMintBurnAccount, initialReserves)
+	require.NoError(t, err)
+
+	// Add two equal swaps in the same direction and an opposing swap
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	swaps := []types.SwapOrder{
+		types.NewSwapOrder(buyerAddress, sdk.NewInt64Coin(reserveToken, 200), reserveToken2, sdk.NewInt64Coin(reserveToken2, 0)),
+		types.NewSwapOrder(sellerAddress, sdk.NewInt64Coin(reserveToken2, 100), reserveToken, sdk.NewInt64Coin(reserveToken, 0)),
+		types.NewSwapOrder(swapperAddress, sdk.NewInt64Coin(reserveToken, 200), reserveToken2, sdk.NewInt64Coin(reserveToken2, 0)),
+	}
+	for _, so := range swaps {
+		app.BondsKeeper.AddSwapOrder(ctx, token, so)
+		_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{so.Amount})
+		require.NoError(t, err)
+	}
+
+	// Perform swaps
+	app.BondsKeeper.PerformSwapOrders(ctx, token)
+
+	// 100res of the 400res is matched with the 100rez at the reserve ratio of
+	// 1:1, and the excess 300res is swapped against the reserve, giving 300 *
+	// 1000 / (1000 + 300) = 230.77rez, so each res swapper gets 330.77/2 rez
+	expectedRez := sdk.Coins{sdk.NewInt64Coin(reserveToken2, 165)}
+	require.Equal(t, expectedRez, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.Equal(t, expectedRez, app.BankKeeper.GetCoins(ctx, swapperAddress))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100)},
+		app.BankKeeper.GetCoins(ctx, sellerAddress))
+
+	// Product did not decrease
+	newReserves := app.BondsKeeper.GetReserveBalances(ctx, bond.Token)
+	require.True(t, newReserves.AmountOf(reserveToken).Mul(newReserves.AmountOf(reserveToken2)).GTE(
+		sdk.NewInt(1000*1000)))
 }
 
 func TestOrderCancelled(t *testing.T) {
@@ -1122,6 +1476,83 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 	}
 }
 
+func TestCancelUnfulfillableSells(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	minReturns := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)}
+	zeroTokens := sdk.NewCoin(bond.Token, sdk.ZeroInt())
+
+	testCases := []struct {
+		amount           int64
+		minReturns       sdk.Coins
+		exitFee          sdk.Dec
+		orderFulfillable bool
+	}{
+		{
+			10, nil, sdk.ZeroDec(), true,
+		}, // no min returns
+		{
+			11, minReturns, sdk.ZeroDec(), true,
+		}, // (11 * 100) - (11 * FEE) = 1100 >= 1000, where FEE=0
+		{
+			10, minReturns, sdk.ZeroDec(), true,
+		}, // (10 * 100) - (10 * FEE) = 1000 >= 1000, where FEE=0
+		{
+			9, minReturns, sdk.ZeroDec(), false,
+		}, // (9 * 100) - (9 * FEE) = 900 < 1000, where FEE=0
+		{
+			10, minReturns, sdk.NewDec(10), false,
+		}, // (10 * 100) - (10 * FEE) = 900 < 1000, where FEE=10
+	}
+	for _, tc := range testCases {
+		// Set up bond (with exit fee and no tx fee)
+		bond.TxFeePercentage = sdk.ZeroDec()
+		bond.ExitFeePercentage = tc.exitFee
+		app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+		// Create new batch with sell order and sell prices
+		amount := sdk.NewCoin(bond.Token, sdk.NewInt(tc.amount))
+		so := types.NewSellOrder(sellerAddress, amount, tc.minReturns)
+		batch := getValidBatch()
+		batch.Sells = append(batch.Sells, so)
+		batch.TotalSellAmount = amount
+		batch.SellPrices = sellPrices
+		app.BondsKeeper.SetBatch(ctx, bond.Token, batch)
+
+		// Get account balance before possible cancellation
+		balanceBefore := app.BankKeeper.GetCoins(ctx, sellerAddress)
+
+		// Cancel unfulfillable sells and check amount of cancellations
+		cancelledOrders := app.BondsKeeper.CancelUnfulfillableSells(ctx, bond.Token)
+		if tc.orderFulfillable {
+			require.Equal(t, 0, cancelledOrders)
+		} else {
+			require.Equal(t, 1, cancelledOrders)
+		}
+
+		// Check that batch is (un)changed based on order (un)fulfillability
+		batch = app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+		if tc.orderFulfillable {
+			// Check that not cancelled
+			require.Equal(t, so.Amount, batch.TotalSellAmount)
+			require.False(t, batch.Sells[0].Cancelled)
+
+			// Check that balance unchanged
+			require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, sellerAddress))
+		} else {
+			// Check that cancelled
+			require.Equal(t, zeroTokens, batch.TotalSellAmount)
+			require.True(t, batch.Sells[0].Cancelled)
+
+			// Check that bond tokens returned to seller
+			newBalance := balanceBefore.Add(amount)
+			require.Equal(t, newBalance, app.BankKeeper.GetCoins(ctx, sellerAddress))
+		}
+	}
+}
+
 func TestCancelUnfulfillableOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	bond := getValidBond()
@@ -1211,3 +1642,218 @@ func TestCancelUnfulfillableOrders(t *testing.T) {
 		}
 	}
 }
+
+func TestCancelUnfulfillableBuysPartiallyFillsAllowPartialOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+
+	buyPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	maxPrices := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1100)}
+	blankSellPrices := sdk.NewDecCoinsFromCoins() // blank
+	zeroTokens := sdk.NewCoin(bond.Token, sdk.ZeroInt())
+
+	testCases := []struct {
+		amount     int64
+		maxPrices  sdk.Coins
+		txFee      sdk.Dec
+		fillAmount int64
+		fillRatio  sdk.Dec
+	}{
+		{
+			10, maxPrices, sdk.ZeroDec(), 10, sdk.OneDec(),
+		}, // (10 * 100) + (10 * FEE) = 1000 <= 1100, where FEE=0
+		{
+			12, maxPrices, sdk.ZeroDec(), 11, sdk.MustNewDecFromStr("0.916666666666666667"),
+		}, // (11 * 100) + (11 * FEE) = 1100 <= 1100, where FEE=0
+		{
+			12, maxPrices, sdk.NewDec(10), 10, sdk.MustNewDecFromStr("0.833333333333333333"),
+		}, // (10 * 100) + (10 * FEE) = 1100 <= 1100, where FEE=10
+		{
+			12, sdk.Coins{sdk.NewInt64Coin(reserveToken, 99)}, sdk.ZeroDec(), 0, sdk.ZeroDec(),
+		}, // (1 * 100) + (1 * FEE) = 100 > 99, where FEE=0
+	}
+	for _, tc := range testCases {
+		// Set up bond (with tx fee) and new batch
+		bond.TxFeePercentage = tc.txFee
+		app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+		app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+		// Create and add buy order that allows partial fills
+		amount := sdk.NewCoin(bond.Token, sdk.NewInt(tc.amount))
+		bo := types.NewPartialBuyOrder(buyerAddress, amount, tc.maxPrices)
+		app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, blankSellPrices)
+
+		// Add reserve tokens to module account address for return if cancel
+		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+		_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), tc.maxPrices)
+
+		// Get account balance before possible cancellation
+		balanceBefore := app.BankKeeper.GetCoins(ctx, buyerAddress)
+
+		// Cancel unfulfillable buys and check amount of cancellations/reductions
+		cancelledOrders := app.BondsKeeper.CancelUnfulfillableBuys(ctx, bond.Token)
+		if tc.fillAmount == tc.amount {
+			require.Equal(t, 0, cancelledOrders)
+		} else {
+			require.Equal(t, 1, cancelledOrders)
+		}
+
+		batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+		if tc.fillAmount > 0 {
+			// Check that reduced (or unchanged) but not cancelled
+			fillAmount := sdk.NewInt64Coin(bond.Token, tc.fillAmount)
+			require.Equal(t, fillAmount, batch.TotalBuyAmount)
+			require.Equal(t, fillAmount, batch.Buys[0].Amount)
+			require.Equal(t, amount, batch.Buys[0].RequestedAmount)
+			require.Equal(t, tc.fillRatio, batch.Buys[0].GetFillRatio())
+			require.False(t, batch.Buys[0].Cancelled)
+
+			// Check that balances unchanged (remainder returned when performed)
+			require.Equal(t, tc.maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, buyerAddress))
+		} else {
+			// Check that cancelled
+			require.Equal(t, zeroTokens, batch.TotalBuyAmount)
+			require.True(t, batch.Buys[0].Cancelled)
+
+			// Check that reserve tokens returned to buyer
+			newBalance := balanceBefore.Add(tc.maxPrices...)
+			require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+			require.Equal(t, newBalance, app.BankKeeper.GetCoins(ctx, buyerAddress))
+		}
+	}
+}
+
+func TestCancelUnfulfillableOrdersPartiallyFillsUntilPricesStable(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create bond and batch (with no fees for simpler test)
+	bond := getValidBond()
+	bond.TxFeePercentage = sdk.ZeroDec()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Price per token when buying n tokens (at zero supply) is 4n^2+100
+	//
+	// Buy 10 (partial), max 5000 => 10 * 500 = 5000 <= 5000
+	// Buy 2, max 2000 => total 12, so 2 * 676 = 1352 <= 2000
+	//                 => but 10 * 676 = 6760 > 5000, so 10 reduced to 7
+	// New total 9, so 7 * 424 = 2968 <= 5000 and 2 * 424 = 848 <= 2000
+	partialBuy := types.NewPartialBuyOrder(buyerAddress,
+		sdk.NewInt64Coin(bond.Token, 10), sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000)))
+	otherBuy := types.NewBuyOrder(sellerAddress,
+		sdk.NewInt64Coin(bond.Token, 2), sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 2000)))
+	for _, bo := range []types.BuyOrder{partialBuy, otherBuy} {
+		buyPrices, sellPrices, err := app.BondsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.Token, bo)
+		require.NoError(t, err)
+		app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, sellPrices)
+	}
+
+	// Add reserve tokens paid by buyers to module account address
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 7000)))
+
+	// Partial buy reduced (counted once), other buy not cancelled
+	cancelledOrders := app.BondsKeeper.CancelUnfulfillableOrders(ctx, bond.Token)
+	require.Equal(t, 1, cancelledOrders)
+
+	batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+	require.Equal(t, sdk.NewInt64Coin(bond.Token, 9), batch.TotalBuyAmount)
+	require.Equal(t, sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 424)}, batch.BuyPrices)
+	require.Equal(t, sdk.NewInt64Coin(bond.Token, 7), batch.Buys[0].Amount)
+	require.False(t, batch.Buys[0].Cancelled)
+	require.Equal(t, sdk.NewInt64Coin(bond.Token, 2), batch.Buys[1].Amount)
+	require.False(t, batch.Buys[1].Cancelled)
+
+	// Perform buys and check that the remainder is returned to the buyer
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	app.BondsKeeper.PerformBuyOrders(ctx, bond.Token)
+	expectedBalance := sdk.NewCoins(
+		sdk.NewInt64Coin(bond.Token, 7),
+		sdk.NewInt64Coin(reserveToken, 5000-2968))
+	require.Equal(t, expectedBalance, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Check that fill ratio reported in order_fulfill events
+	var fillRatios []string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != types.EventTypeOrderFulfill {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == types.AttributeKeyFillRatio {
+				fillRatios = append(fillRatios, string(attr.Value))
+			}
+		}
+	}
+	require.Equal(t, []string{"0.700000000000000000", "1.000000000000000000"}, fillRatios)
+}
+
+func TestPerformOrdersAllocatesMaxSupply(t *testing.T) {
+	testCases := []struct {
+		allocation string
+		allocated  []int64
+	}{
+		{types.FirstComeAllocation, []int64{6, 4, 0}},
+		{types.ProRataAllocation, []int64{4, 4, 2}}, // 6, 6, 3 scaled by 10/15
+	}
+	for _, tc := range testCases {
+		app, ctx := createTestApp(false)
+
+		// Create bond (max supply 10, no fees for simpler test) and batch
+		bond := getValidBond()
+		bond.TxFeePercentage = sdk.ZeroDec()
+		bond.MaxSupply = sdk.NewInt64Coin(bond.Token, 10)
+		bond.MaxSupplyAllocation = tc.allocation
+		app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+		app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+		// Add buys of 6, 6, and 3 tokens (15 > max supply of 10)
+		buyers := []sdk.AccAddress{buyerAddress, sellerAddress, swapperAddress}
+		requested := []int64{6, 6, 3}
+		maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+		for i, buyer := range buyers {
+			bo := types.NewBuyOrder(buyer, sdk.NewInt64Coin(bond.Token, requested[i]), maxPrices)
+			buyPrices, sellPrices, err := app.BondsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.Token, bo)
+			require.NoError(t, err)
+			app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, sellPrices)
+		}
+
+		// Price per token only considers the 10 tokens remaining: 4(10^2)+100
+		batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+		require.Equal(t, sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 500)}, batch.BuyPrices)
+
+		// Add reserve tokens paid by buyers to module account address
+		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+		_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(),
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 15000)))
+
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		app.BondsKeeper.PerformOrders(ctx, bond.Token)
+
+		// Check that buyers got their allocations and the rest of the max prices
+		for i, buyer := range buyers {
+			expectedBalance := sdk.NewCoins(
+				sdk.NewInt64Coin(bond.Token, tc.allocated[i]),
+				sdk.NewInt64Coin(reserveToken, 5000-500*tc.allocated[i]))
+			require.Equal(t, expectedBalance, app.BankKeeper.GetCoins(ctx, buyer))
+		}
+		require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+		require.Equal(t, bond.MaxSupply, app.BondsKeeper.MustGetBond(ctx, bond.Token).CurrentSupply)
+
+		// Check that buyers allocated nothing had their orders cancelled
+		batch = app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+		for i, bo := range batch.Buys {
+			require.Equal(t, tc.allocated[i] == 0, bo.Cancelled)
+		}
+
+		// Check that the bond was marked as sold out
+		soldOutEvents := 0
+		for _, event := range ctx.EventManager().Events() {
+			if event.Type == types.EventTypeBondSoldOut {
+				soldOutEvents += 1
+			}
+		}
+		require.Equal(t, 1, soldOutEvents)
+	}
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 )
 
@@ -53,41 +54,40 @@ func (k Keeper) SetBond(ctx sdk.Context, token string, bond types.Bond) {
 }
 
 func (k Keeper) DepositReserve(ctx sdk.Context, token string, from sdk.AccAddress, amount sdk.Coins) error {
-	// Send tokens to bonds reserve account
-	err := k.SupplyKeeper.SendCoinsFromAccountToModule(
-		ctx, from, types.BondsReserveAccount, amount)
+	// Send tokens to bond's reserve address
+	bond := k.MustGetBond(ctx, token)
+	err := k.BankKeeper.SendCoins(ctx, from, bond.ReserveAddress, amount)
 	if err != nil {
 		return err
 	}
 
 	// Update bond reserve
-	k.setReserveBalances(ctx, token,
-		k.MustGetBond(ctx, token).CurrentReserve.Add(amount...))
+	k.setReserveBalances(ctx, token, bond.CurrentReserve.Add(amount...))
 	return nil
 }
 
 func (k Keeper) DepositReserveFromModule(ctx sdk.Context, token string,
 	fromModule string, amount sdk.Coins) error {
 
-	// Send tokens to bonds reserve account
-	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
-		ctx, fromModule, types.BondsReserveAccount, amount)
+	// Send tokens to bond's reserve address
+	bond := k.MustGetBond(ctx, token)
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, fromModule, bond.ReserveAddress, amount)
 	if err != nil {
 		return err
 	}
 
 	// Update bond reserve
-	k.setReserveBalances(ctx, token,
-		k.MustGetBond(ctx, token).CurrentReserve.Add(amount...))
+	k.setReserveBalances(ctx, token, bond.CurrentReserve.Add(amount...))
 	return nil
 }
 
 func (k Keeper) WithdrawReserve(ctx sdk.Context, token string,
 	to sdk.AccAddress, amount sdk.Coins) error {
 
-	// Send tokens from bonds reserve account
-	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
-		ctx, types.BondsReserveAccount, to, amount)
+	// Send tokens from bond's reserve address
+	err := k.BankKeeper.SendCoins(
+		ctx, k.MustGetBond(ctx, token).ReserveAddress, to, amount)
 	if err != nil {
 		return err
 	}
@@ -108,18 +108,77 @@ func (k Keeper) GetReserveBalances(ctx sdk.Context, token string) sdk.Coins {
 	return k.MustGetBond(ctx, token).CurrentReserve
 }
 
+// MigrateReserveAccounts assigns a reserve address to any bond that does not
+// have one yet and moves the bond's reserve from the shared bonds reserve
+// module account to the bond's reserve address.
+func (k Keeper) MigrateReserveAccounts(ctx sdk.Context) error {
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if !bond.ReserveAddress.Empty() {
+			continue
+		}
+
+		bond.ReserveAddress = types.GetReserveAddress(bond.Token)
+		if !bond.CurrentReserve.IsZero() {
+			err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.BondsReserveAccount, bond.ReserveAddress, bond.CurrentReserve)
+			if err != nil {
+				return err
+			}
+		}
+		k.SetBond(ctx, bond.Token, bond)
+
+		logger := k.Logger(ctx)
+		logger.Info(fmt.Sprintf("migrated reserve of %s to %s",
+			bond.Token, bond.ReserveAddress.String()))
+	}
+	return nil
+}
+
+// GetSwapperProduct returns the product per supply squared last noted for
+// the swapper bond, which is used by the swapper invariant.
+func (k Keeper) GetSwapperProduct(ctx sdk.Context, token string) (product sdk.Dec, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetSwapperProductKey(token))
+	if bz == nil {
+		return sdk.Dec{}, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &product)
+	return product, true
+}
+
+func (k Keeper) SetSwapperProduct(ctx sdk.Context, token string, product sdk.Dec) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSwapperProductKey(token), k.cdc.MustMarshalBinaryBare(product))
+}
+
+func (k Keeper) DeleteSwapperProduct(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetSwapperProductKey(token))
+}
+
 func (k Keeper) GetSupplyAdjustedForBuy(ctx sdk.Context, token string) sdk.Coin {
 	bond := k.MustGetBond(ctx, token)
-	batch := k.MustGetBatch(ctx, token)
+	batch := k.MustGetBatchHeader(ctx, token)
 	supply := bond.CurrentSupply
 	return supply.Add(batch.TotalBuyAmount)
 }
 
+// GetSupplyAdjustedForSell returns the bond's current supply less the bond
+// tokens already burned for the current batch's sells and remove liquidity
+// orders, which are only deducted from the current supply once performed.
 func (k Keeper) GetSupplyAdjustedForSell(ctx sdk.Context, token string) sdk.Coin {
 	bond := k.MustGetBond(ctx, token)
-	batch := k.MustGetBatch(ctx, token)
-	supply := bond.CurrentSupply
-	return supply.Sub(batch.TotalSellAmount)
+	batch := k.MustGetBatchHeader(ctx, token)
+	supply := bond.CurrentSupply.Sub(batch.TotalSellAmount)
+	for _, ro := range k.GetRemoveLiquidityOrders(ctx, token) {
+		if !ro.IsCancelled() {
+			supply = supply.Sub(ro.Amount)
+		}
+	}
+	return supply
 }
 
 func (k Keeper) SetCurrentSupply(ctx sdk.Context, token string, currentSupply sdk.Coin) {
@@ -148,6 +207,56 @@ func (k Keeper) SetBondState(ctx sdk.Context, token string, newState string) {
 	))
 }
 
+// PauseBond moves a HATCH or OPEN bond into the PAUSED state, keeping note of
+// the state that the bond is to be resumed to and of who paused the bond
+// (PausedBySigners or PausedByGovernance).
+func (k Keeper) PauseBond(ctx sdk.Context, token, pausedBy string) error {
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	} else if bond.State != types.HatchState && bond.State != types.OpenState {
+		return sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	}
+
+	bond.StateBeforePause = bond.State
+	bond.PausedBy = pausedBy
+	k.SetBond(ctx, token, bond)
+	k.SetBondState(ctx, token, types.PausedState)
+
+	// Freeze the batch by removing it from the schedule
+	if k.BatchIsScheduled(ctx, token) {
+		k.UnscheduleBatch(ctx, token)
+	}
+	return nil
+}
+
+// ResumeBond moves a PAUSED bond back into the state that it was in before
+// being paused. A bond paused by governance can only be resumed by governance.
+func (k Keeper) ResumeBond(ctx sdk.Context, token, resumedBy string) error {
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	} else if bond.State != types.PausedState {
+		return sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	} else if bond.PausedBy == types.PausedByGovernance &&
+		resumedBy != types.PausedByGovernance {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized,
+			"bond was paused by governance and can only be resumed by governance")
+	}
+
+	k.SetBondState(ctx, token, bond.StateBeforePause)
+	bond = k.MustGetBond(ctx, token)
+	bond.StateBeforePause = ""
+	bond.PausedBy = ""
+	k.SetBond(ctx, token, bond)
+
+	// Schedule the batch again, for its remaining blocks
+	if k.BatchHasOrders(ctx, token) || k.HasLimitOrders(ctx, token) {
+		k.ScheduleBatch(ctx, token)
+	}
+	return nil
+}
+
 func (k Keeper) ReservedBondToken(ctx sdk.Context, bondToken string) bool {
 	reservedBondTokens := k.GetParams(ctx).ReservedBondTokens
 	for _, rbt := range reservedBondTokens {
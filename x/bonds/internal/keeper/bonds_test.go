@@ -67,9 +67,8 @@ func TestDepositReserve(t *testing.T) {
 	addressBalance := app.BankKeeper.GetCoins(ctx, address)
 	require.Empty(t, addressBalance)
 
-	// Also confirm that reserve module account has the actual amount
-	moduleAddr := app.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
-	addressBalance = app.BankKeeper.GetCoins(ctx, moduleAddr)
+	// Also confirm that bond's reserve address has the actual amount
+	addressBalance = app.BankKeeper.GetCoins(ctx, bond.ReserveAddress)
 	require.Equal(t, amount, addressBalance)
 }
 
@@ -102,9 +101,8 @@ func TestDepositReserveFromModule(t *testing.T) {
 	addressBalance := app.BankKeeper.GetCoins(ctx, moduleAddr)
 	require.Empty(t, addressBalance)
 
-	// Also confirm that reserve module account has the actual amount
-	moduleAddr = app.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
-	addressBalance = app.BankKeeper.GetCoins(ctx, moduleAddr)
+	// Also confirm that bond's reserve address has the actual amount
+	addressBalance = app.BankKeeper.GetCoins(ctx, bond.ReserveAddress)
 	require.Equal(t, amount, addressBalance)
 }
 
@@ -123,8 +121,8 @@ func TestWithdrawReserve(t *testing.T) {
 	require.Nil(t, err)
 	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, amount)
 	require.Nil(t, err)
-	err = app.SupplyKeeper.SendCoinsFromModuleToModule(
-		ctx, types.BondsMintBurnAccount, types.BondsReserveAccount, amount)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.BondsMintBurnAccount, bond.ReserveAddress, amount)
 	require.Nil(t, err)
 	bond.CurrentReserve = amount
 	app.BondsKeeper.SetBond(ctx, token, bond)
@@ -141,9 +139,8 @@ func TestWithdrawReserve(t *testing.T) {
 	addressBalance := app.BankKeeper.GetCoins(ctx, address)
 	require.Equal(t, amount, addressBalance)
 
-	// Also confirm that reserve module account is now empty
-	moduleAddr := app.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
-	addressBalance = app.BankKeeper.GetCoins(ctx, moduleAddr)
+	// Also confirm that bond's reserve address is now empty
+	addressBalance = app.BankKeeper.GetCoins(ctx, bond.ReserveAddress)
 	require.Empty(t, addressBalance)
 }
 
@@ -168,6 +165,91 @@ func TestGetReserveBalances(t *testing.T) {
 	require.Equal(t, bond.CurrentReserve, reserveBalances)
 }
 
+func TestReservesAreSegregated(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add two bonds
+	bond1 := getValidBond()
+	bond2 := getValidBond()
+	bond2.Token = "anothertoken"
+	bond2.ReserveAddress = types.GetReserveAddress(bond2.Token)
+	app.BondsKeeper.SetBond(ctx, bond1.Token, bond1)
+	app.BondsKeeper.SetBond(ctx, bond2.Token, bond2)
+	require.NotEqual(t, bond1.ReserveAddress, bond2.ReserveAddress)
+
+	// Mint tokens to a module and deposit them to the first bond's reserve
+	amount, err := sdk.ParseCoins("12res1,34res2")
+	require.Nil(t, err)
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, amount)
+	require.Nil(t, err)
+	err = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bond1.Token, types.BondsMintBurnAccount, amount)
+	require.Nil(t, err)
+
+	// Withdrawing from the second bond's reserve fails
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	err = app.BondsKeeper.WithdrawReserve(ctx, bond2.Token, address, amount)
+	require.Error(t, err)
+	require.Equal(t, amount, app.BankKeeper.GetCoins(ctx, bond1.ReserveAddress))
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, bond2.ReserveAddress))
+}
+
+func TestMigrateReserveAccounts(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bond with no reserve address and with its reserve in the module account
+	amount, err := sdk.ParseCoins("12res1,34res2")
+	require.Nil(t, err)
+	bond := getValidBond()
+	bond.ReserveAddress = nil
+	bond.CurrentReserve = amount
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	err = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, amount)
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, types.BondsMintBurnAccount, types.BondsReserveAccount, amount)
+	require.Nil(t, err)
+
+	// Migrate reserve accounts
+	err = app.BondsKeeper.MigrateReserveAccounts(ctx)
+	require.Nil(t, err)
+
+	// Bond now has a reserve address holding the reserve
+	bond = app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.GetReserveAddress(token), bond.ReserveAddress)
+	require.Equal(t, amount, app.BankKeeper.GetCoins(ctx, bond.ReserveAddress))
+	require.Equal(t, amount, app.BondsKeeper.GetReserveBalances(ctx, token))
+
+	// Reserve module account is now empty
+	moduleAddr := app.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAddr))
+
+	// Migrating again does nothing
+	err = app.BondsKeeper.MigrateReserveAccounts(ctx)
+	require.Nil(t, err)
+	require.Equal(t, amount, app.BankKeeper.GetCoins(ctx, bond.ReserveAddress))
+}
+
+func TestSwapperProductSetGetDelete(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Product not noted yet
+	_, found := app.BondsKeeper.GetSwapperProduct(ctx, token)
+	require.False(t, found)
+
+	// Note product
+	product := sdk.MustNewDecFromStr("12.5")
+	app.BondsKeeper.SetSwapperProduct(ctx, token, product)
+	productFetched, found := app.BondsKeeper.GetSwapperProduct(ctx, token)
+	require.True(t, found)
+	require.Equal(t, product, productFetched)
+
+	// Delete product
+	app.BondsKeeper.DeleteSwapperProduct(ctx, token)
+	_, found = app.BondsKeeper.GetSwapperProduct(ctx, token)
+	require.False(t, found)
+}
+
 func TestGetSupplyAdjustedForBuy(t *testing.T) {
 	app, ctx := createTestApp(false)
 
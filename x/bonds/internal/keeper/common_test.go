@@ -31,6 +31,7 @@ var (
 	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
 	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
 	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
+	initMaxSupplyAllocation    = types.NoAllocation
 	initOrderQuantityLimits    = sdk.Coins(nil)
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
@@ -38,6 +39,13 @@ var (
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.NewUint(10)
 	initOutcomePayment         = sdk.Coins(nil)
+	initHatchDeadline          = int64(0)
+	initMinRaise               = sdk.Coins(nil)
+	initVestingCliff           = int64(0)
+	initVestingDuration        = int64(0)
+	initFundingTap             = sdk.Coins(nil)
+	initFundingTapBlocks       = int64(0)
+	initFundingTaxPercentage   = sdk.ZeroDec()
 	initState                  = types.OpenState
 
 	buyPrices = sdk.NewDecCoinsFromCoins(sdk.NewCoins(
@@ -70,6 +78,7 @@ var (
 	swapperAddress = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	swapFrom       = sdk.NewCoin(reserveToken, sdk.OneInt())
 	swapTo         = reserveToken2
+	swapMinOutput  = sdk.NewCoin(reserveToken2, sdk.ZeroInt())
 
 	batchBlocks = sdk.NewUint(5)
 )
@@ -131,8 +140,10 @@ func getValidPowerFunctionBond() types.Bond {
 	return types.NewBond(initToken, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage, initState)
 }
 
 func getValidAugmentedFunctionBond() types.Bond {
@@ -142,8 +153,10 @@ func getValidAugmentedFunctionBond() types.Bond {
 	return types.NewBond(initToken, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage, initState)
 }
 
 func getValidSwapperBond() types.Bond {
@@ -153,8 +166,10 @@ func getValidSwapperBond() types.Bond {
 	return types.NewBond(initToken, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage, initState)
 }
 
 func getValidBond() types.Bond {
@@ -174,9 +189,9 @@ func getValidBuyOrder() types.BuyOrder {
 }
 
 func getValidSellOrder() types.SellOrder {
-	return types.NewSellOrder(sellerAddress, sellAmount)
+	return types.NewSellOrder(sellerAddress, sellAmount, nil)
 }
 
 func getValidSwapOrder() types.SwapOrder {
-	return types.NewSwapOrder(swapperAddress, swapFrom, swapTo)
+	return types.NewSwapOrder(swapperAddress, swapFrom, swapTo, swapMinOutput)
 }
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// GetFundingPoolBalances returns the funding held in the bond's funding pool,
+// which has not yet been withdrawn through the bond's funding tap.
+func (k Keeper) GetFundingPoolBalances(ctx sdk.Context, token string) sdk.Coins {
+	return k.BankKeeper.GetCoins(ctx, types.GetFundingPoolAddress(token))
+}
+
+// GetNextFundingWithdrawal returns the earliest height at which funding can be
+// withdrawn from the bond's funding pool, and the max amount that can be
+// withdrawn at that height given the funding pool's current balance.
+func (k Keeper) GetNextFundingWithdrawal(ctx sdk.Context, token string) (int64, sdk.Coins) {
+	bond := k.MustGetBond(ctx, token)
+	balance := k.GetFundingPoolBalances(ctx, token)
+
+	height := ctx.BlockHeight()
+	available := types.MinCoins(bond.GetFundingTapAvailable(height), balance)
+	if available.IsZero() && !bond.FundingPeriodEnded(height) {
+		height = bond.FundingPeriodStart + bond.FundingTapBlocks
+		available = types.MinCoins(bond.GetFundingTapAvailable(height), balance)
+	}
+	return height, available
+}
+
+// WithdrawFunding releases an amount from the bond's funding pool to the
+// bond's fee address, as long as the amount does not exceed what is still
+// available through the bond's funding tap in the current period.
+func (k Keeper) WithdrawFunding(ctx sdk.Context, token string, amount sdk.Coins) error {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.HasFundingPool() {
+		return sdkerrors.Wrap(types.ErrBondHasNoFundingPool, token)
+	}
+
+	available := bond.GetFundingTapAvailable(ctx.BlockHeight())
+	if !amount.IsAllLTE(available) {
+		return sdkerrors.Wrapf(types.ErrFundingTapExceeded,
+			"%s exceeds available %s", amount, available)
+	}
+
+	// Send funding to fee address (enforces amount <= funding pool balance)
+	err := k.BankKeeper.SendCoins(ctx,
+		types.GetFundingPoolAddress(token), bond.FeeAddress, amount)
+	if err != nil {
+		return err
+	}
+
+	k.SetBond(ctx, token, bond.AddFundingWithdrawal(ctx.BlockHeight(), amount))
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("withdrew %s from funding pool of bond %s",
+		amount.String(), token))
+
+	return nil
+}
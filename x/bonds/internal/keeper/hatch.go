@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+// SetHatchDeadline adds the bond to the hatch deadlines, so that its hatch
+// phase is ended at the end of the block at its hatch deadline, unless it has
+// already ended by then.
+func (k Keeper) SetHatchDeadline(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHatchDeadlineKey(bond.HatchDeadline, token), []byte(token))
+}
+
+// GetHatchDeadlinesDue returns the tokens of the bonds whose hatch deadline is
+// at or before the current block height, and removes them from the hatch
+// deadlines. Note that some of these bonds might have already ended their
+// hatch phase (e.g. by reaching S0 or by being closed).
+func (k Keeper) GetHatchDeadlinesDue(ctx sdk.Context) (tokens []string) {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.GetHatchDeadlinesHeightPrefix(ctx.BlockHeight()))
+
+	// Collect keys first, since the store should not be modified while
+	// iterating over it
+	var keys [][]byte
+	iterator := store.Iterator(types.HatchDeadlinesKeyPrefix, end)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		tokens = append(tokens, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return tokens
+}
+
+// GetHatchEscrowBalances returns the funding share of the hatch phase of the
+// bond that is held in escrow until the bond's hatch phase ends.
+func (k Keeper) GetHatchEscrowBalances(ctx sdk.Context, token string) sdk.Coins {
+	return k.BankKeeper.GetCoins(ctx, types.GetHatchEscrowAddress(token))
+}
+
+// MoveHatchEscrowToReserve moves the funding share of the hatch phase of the
+// bond that is held in escrow to the bond's reserve, so that it is returned
+// to the bond token holders when they withdraw their share of the reserve.
+func (k Keeper) MoveHatchEscrowToReserve(ctx sdk.Context, token string) error {
+	escrow := k.GetHatchEscrowBalances(ctx, token)
+	if escrow.IsZero() {
+		return nil
+	}
+	return k.DepositReserve(ctx, token, types.GetHatchEscrowAddress(token), escrow)
+}
+
+// EndHatch moves an augmented bond from the hatch phase to the open phase and
+// allows sells. Any funding share held in escrow is paid out to the bond's
+// funding pool, or to its fee address if the bond has no funding pool. A
+// paused bond stays paused, but is resumed to the open phase.
+func (k Keeper) EndHatch(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	if bond.State == types.PausedState {
+		bond.StateBeforePause = types.OpenState
+	} else {
+		k.SetBondState(ctx, token, types.OpenState)
+		bond = k.MustGetBond(ctx, token) // get bond again
+	}
+	bond.AllowSells = true // enable sells
+	k.SetBond(ctx, token, bond)
+
+	escrow := k.GetHatchEscrowBalances(ctx, token)
+	if !escrow.IsZero() {
+		err := k.BankKeeper.SendCoins(ctx,
+			types.GetHatchEscrowAddress(token), bond.GetFundingAddress(), escrow)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// EndHatchAtDeadline ends the hatch phase of an augmented bond that did not
+// reach S0 by its hatch deadline. If the bond raised at least its min raise,
+// its parameters are recalculated as if the amount raised was its d0, and the
+// bond moves to the open phase. Otherwise, the bond's hatch fails.
+func (k Keeper) EndHatchAtDeadline(ctx sdk.Context, token string) {
+	bond := k.MustGetBond(ctx, token)
+	if bond.MinRaise.Empty() || !bond.GetHatchRaise().IsAllGTE(bond.MinRaise) {
+		k.FailHatch(ctx, token)
+		return
+	}
+
+	args := bond.FunctionParameters.AsMap()
+	S0 := bond.CurrentSupply.Amount.ToDec()
+	d0 := args["p0"].Mul(S0)
+	R0 := d0.Mul(sdk.OneDec().Sub(args["theta"]))
+	V0 := types.Invariant(R0, S0, args["kappa"].TruncateInt64())
+	bond.FunctionParameters = bond.FunctionParameters.ReplaceValues(
+		map[string]sdk.Dec{"d0": d0, "R0": R0, "S0": S0, "V0": V0})
+	k.SetBond(ctx, token, bond)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s reached min raise %s by its hatch deadline",
+		token, bond.MinRaise.String()))
+
+	k.EndHatch(ctx, token)
+}
+
+// FailHatch moves an augmented bond whose hatch phase failed to the FAILED
+// state. Any pending orders and limit orders are cancelled and the funding
+// share held in escrow is moved to the reserve, so that bond token holders can
+// reclaim their contribution by withdrawing their share of the reserve.
+func (k Keeper) FailHatch(ctx sdk.Context, token string) {
+	err := k.CancelAllOrders(ctx, token, "hatch failed")
+	if err != nil {
+		panic(err)
+	}
+	err = k.CancelAllLimitOrders(ctx, token, "hatch failed")
+	if err != nil {
+		panic(err)
+	}
+	err = k.MoveHatchEscrowToReserve(ctx, token)
+	if err != nil {
+		panic(err)
+	}
+
+	bond := k.MustGetBond(ctx, token)
+	k.SetBondState(ctx, token, types.FailedState)
+	if bond.State == types.PausedState {
+		bond = k.MustGetBond(ctx, token)
+		bond.StateBeforePause = ""
+		bond.PausedBy = ""
+		k.SetBond(ctx, token, bond)
+
+		// Schedule the batch of the previously paused bond, if it has any
+		// (now cancelled) orders, so that the batch gets settled
+		if k.BatchHasOrders(ctx, token) {
+			k.ScheduleBatch(ctx, token)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetHatchDeadlinesDue(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add bonds with hatch deadlines at heights 10, 11, and 10
+	for i, tkn := range []string{token1, token2, token3} {
+		bond := getValidAugmentedFunctionBond()
+		bond.Token = tkn
+		bond.HatchDeadline = int64(10 + i%2)
+		app.BondsKeeper.SetBond(ctx, tkn, bond)
+		app.BondsKeeper.SetHatchDeadline(ctx, tkn)
+	}
+
+	// Nothing due at height 9
+	require.Len(t, app.BondsKeeper.GetHatchDeadlinesDue(ctx.WithBlockHeight(9)), 0)
+
+	// Two deadlines due at height 10, which are removed from the deadlines
+	due := app.BondsKeeper.GetHatchDeadlinesDue(ctx.WithBlockHeight(10))
+	require.Equal(t, []string{token1, token3}, due)
+
+	// Overdue deadlines are also returned
+	due = app.BondsKeeper.GetHatchDeadlinesDue(ctx.WithBlockHeight(20))
+	require.Equal(t, []string{token2}, due)
+	require.Len(t, app.BondsKeeper.GetHatchDeadlinesDue(ctx.WithBlockHeight(20)), 0)
+}
+
+func TestMoveHatchEscrowToReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	bond := getValidAugmentedFunctionBond()
+	bond.HatchDeadline = 10
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+
+	// Nothing to move
+	err := app.BondsKeeper.MoveHatchEscrowToReserve(ctx, bond.Token)
+	require.Nil(t, err)
+	require.True(t, app.BondsKeeper.GetReserveBalances(ctx, bond.Token).IsZero())
+
+	// Add 100res to escrow and move it to the reserve
+	escrow := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	_, err = app.BankKeeper.AddCoins(ctx, types.GetHatchEscrowAddress(bond.Token), escrow)
+	require.Nil(t, err)
+	require.Equal(t, escrow, app.BondsKeeper.GetHatchEscrowBalances(ctx, bond.Token))
+
+	err = app.BondsKeeper.MoveHatchEscrowToReserve(ctx, bond.Token)
+	require.Nil(t, err)
+	require.True(t, app.BondsKeeper.GetHatchEscrowBalances(ctx, bond.Token).IsZero())
+	require.Equal(t, escrow, app.BondsKeeper.GetReserveBalances(ctx, bond.Token))
+}
@@ -16,16 +16,34 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 		SupplyInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "bonds-reserve",
 		ReserveInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-reserve-accounts",
+		ReserveAccountsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-batches-account",
+		BatchesAccountInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-swapper",
+		SwapperInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-augmented",
+		AugmentedInvariant(k))
 }
 
 // AllInvariants runs all invariants of the bonds module.
 func AllInvariants(k Keeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
-		res, stop := SupplyInvariant(k)(ctx)
-		if stop {
-			return res, stop
+		invariants := []sdk.Invariant{
+			SupplyInvariant(k),
+			ReserveInvariant(k),
+			ReserveAccountsInvariant(k),
+			BatchesAccountInvariant(k),
+			SwapperInvariant(k),
+			AugmentedInvariant(k),
 		}
-		return ReserveInvariant(k)(ctx)
+		for _, invariant := range invariants {
+			res, stop := invariant(ctx)
+			if stop {
+				return res, stop
+			}
+		}
+		return "", false
 	}
 }
 
@@ -59,6 +77,15 @@ func SupplyInvariant(k Keeper) sdk.Invariant {
 				}
 			}
 
+			// Similarly for remove liquidity orders (already burned in
+			// handleMsgRemoveLiquidity)
+			for _, ro := range batch.RemoveLiquidity {
+				if !ro.Cancelled {
+					supplyInBondsAndBatches = supplyInBondsAndBatches.Sub(
+						ro.Amount)
+				}
+			}
+
 			// Check that amount matches supply in accounts
 			inAccounts := supplyInAccounts.AmountOf(bond.Token)
 			if !supplyInBondsAndBatches.Amount.Equal(inAccounts) {
@@ -89,7 +116,7 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 
 			if bond.FunctionType == types.AugmentedFunction ||
 				bond.FunctionType == types.SwapperFunction {
-				continue // Check does not apply to augmented/swapper functions
+				continue // Checked by the augmented/swapper invariants instead
 			}
 
 			expectedReserve := bond.ReserveAtSupply(bond.CurrentSupply.Amount)
@@ -113,3 +140,209 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			"%d Bonds reserve invariants broken\n%s", count, msg)), broken
 	}
 }
+
+func ReserveAccountsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		// Reserves of bonds not yet migrated to a reserve address
+		reservesInModuleAccount := sdk.Coins{}
+
+		iterator := k.GetBondIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			if bond.ReserveAddress.Empty() {
+				reservesInModuleAccount = reservesInModuleAccount.Add(
+					bond.CurrentReserve...)
+				continue
+			}
+
+			// Anyone can send coins to a reserve address, so the balance is
+			// allowed to be greater than the bond's reserve
+			balance := k.BankKeeper.GetCoins(ctx, bond.ReserveAddress)
+			if !balance.IsAllGTE(bond.CurrentReserve) {
+				count++
+				msg += fmt.Sprintf("%s reserve address invariance:\n"+
+					"\t%s reserve: %s\n"+
+					"\tbalance of reserve address %s: %s\n",
+					bond.Token, bond.Token, bond.CurrentReserve.String(),
+					bond.ReserveAddress.String(), balance.String())
+			}
+		}
+
+		// Check that the reserve module account holds exactly the reserves
+		// of the bonds that have not been migrated to a reserve address
+		moduleAddr := k.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
+		balance := k.BankKeeper.GetCoins(ctx, moduleAddr)
+		if !balance.IsAllGTE(reservesInModuleAccount) ||
+			!reservesInModuleAccount.IsAllGTE(balance) {
+			count++
+			msg += fmt.Sprintf("%s module account invariance:\n"+
+				"\tsum of reserves held by module account: %s\n"+
+				"\tbalance of module account: %s\n",
+				types.BondsReserveAccount, reservesInModuleAccount.String(),
+				balance.String())
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "reserve accounts", fmt.Sprintf(
+			"%d Bonds reserve accounts invariants broken\n%s", count, msg)), broken
+	}
+}
+
+func BatchesAccountInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+
+		// Sum up the coins held for pending (i.e. not cancelled) orders and
+		// for limit orders
+		expected := sdk.Coins{}
+		iterator := k.GetBondIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			batch := k.MustGetBatch(ctx, bond.Token)
+
+			for _, bo := range batch.Buys {
+				if !bo.IsCancelled() {
+					expected = expected.Add(bo.MaxPrices...)
+				}
+			}
+			for _, bo := range batch.BuysWithReserve {
+				if !bo.IsCancelled() {
+					expected = expected.Add(bo.Budget...)
+				}
+			}
+			for _, so := range batch.Swaps {
+				if !so.IsCancelled() {
+					expected = expected.Add(so.Amount)
+				}
+			}
+			for _, ao := range batch.AddLiquidity {
+				if !ao.IsCancelled() {
+					expected = expected.Add(ao.Budget...)
+				}
+			}
+
+			// Limit orders hold their escrow in the same module account
+			for _, lo := range k.GetLimitOrders(ctx, bond.Token) {
+				expected = expected.Add(lo.GetEscrow()...)
+			}
+		}
+
+		// Check that the batches module account holds exactly this sum
+		moduleAddr := k.SupplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount)
+		balance := k.BankKeeper.GetCoins(ctx, moduleAddr)
+		broken := !balance.IsAllGTE(expected) || !expected.IsAllGTE(balance)
+
+		return sdk.FormatInvariant(types.ModuleName, "batches account", fmt.Sprintf(
+			"\tsum of coins held for pending orders: %s\n"+
+				"\tbalance of %s module account: %s\n",
+			expected.String(), types.BatchesIntermediaryAccount, balance.String())), broken
+	}
+}
+
+func SwapperInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetBondIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			if bond.FunctionType != types.SwapperFunction ||
+				bond.CurrentSupply.IsZero() {
+				continue
+			}
+
+			// Check that the product has not decreased since it was last noted
+			// (i.e. since the last time that the bond's orders were performed)
+			lastProduct, found := k.GetSwapperProduct(ctx, bond.Token)
+			if !found {
+				continue
+			}
+			product := bond.GetSwapperProduct(bond.CurrentReserve)
+			if product.LT(lastProduct) {
+				count++
+				msg += fmt.Sprintf("%s swapper invariance:\n"+
+					"\tprevious %s product per supply squared: %s\n"+
+					"\tcurrent %s product per supply squared: %s\n",
+					bond.Token, bond.Token, lastProduct.String(),
+					bond.Token, product.String())
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "swapper", fmt.Sprintf(
+			"%d Bonds swapper invariants broken\n%s", count, msg)), broken
+	}
+}
+
+func AugmentedInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetBondIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			bond := k.MustGetBondByKey(ctx, iterator.Key())
+			if bond.FunctionType != types.AugmentedFunction {
+				continue
+			}
+
+			// During the hatch phase (or if the hatch failed), the reserve is a
+			// fraction (1-theta) of the raised amount, otherwise it is given
+			// by Reserve(S, kappa, V0), less a hatch boundary tolerance
+			args := bond.FunctionParameters.AsMap()
+			var expectedReserve sdk.Dec
+			switch bond.GetActiveState() {
+			case types.HatchState, types.FailedState:
+				oneMinusTheta := sdk.OneDec().Sub(args["theta"])
+				expectedReserve = args["p0"].Mul(
+					bond.CurrentSupply.Amount.ToDec()).Mul(oneMinusTheta)
+			default:
+				expectedReserve = bond.ReserveAtSupply(
+					bond.CurrentSupply.Amount).Sub(hatchBoundaryDeficit(bond))
+			}
+
+			// Truncate, to tolerate rounding errors in the calculation of V0
+			expectedRounded := expectedReserve.TruncateInt()
+
+			for _, rt := range bond.ReserveTokens {
+				actualReserve := bond.CurrentReserve.AmountOf(rt)
+				if actualReserve.LT(expectedRounded) {
+					count++
+					msg += fmt.Sprintf("%s augmented reserve invariance:\n"+
+						"\texpected(truncated) %s reserve: %s\n"+
+						"\tactual %s reserve: %s\n",
+						bond.Token, rt, expectedReserve.String(),
+						rt, actualReserve.String())
+				}
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "augmented", fmt.Sprintf(
+			"%d Bonds augmented invariants broken\n%s", count, msg)), broken
+	}
+}
+
+// hatchBoundaryDeficit returns the amount by which an augmented bond's reserve
+// can fall short of Reserve(S, kappa, V0) in the open phase. Hatch phase buys
+// are capped at ceil(S0) and charged p0, so if S0 has a decimal, the hatch can
+// end at ceil(S0) with a reserve of p0*ceil(S0)*(1-theta), which is less than
+// Reserve(ceil(S0), kappa, V0). The next buy is priced against the reserve
+// balance and thus covers this deficit. If S0 is a whole number (including S0
+// as recalculated at the hatch deadline), there is no deficit.
+func hatchBoundaryDeficit(bond types.Bond) sdk.Dec {
+	args := bond.FunctionParameters.AsMap()
+	S0Ceil := args["S0"].Ceil()
+	if S0Ceil.Equal(args["S0"]) {
+		return sdk.ZeroDec()
+	}
+
+	oneMinusTheta := sdk.OneDec().Sub(args["theta"])
+	hatchReserve := args["p0"].Mul(S0Ceil).Mul(oneMinusTheta)
+	curveReserve := types.Reserve(
+		S0Ceil, args["kappa"].TruncateInt64(), args["V0"])
+	return sdk.MaxDec(curveReserve.Sub(hatchReserve), sdk.ZeroDec())
+}
@@ -0,0 +1,263 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
+)
+
+func (k Keeper) GetLimitOrdersIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetLimitOrdersPrefix(token))
+}
+
+// GetLimitOrders returns the limit orders in the bond's limit order book, in
+// the order that they were placed.
+func (k Keeper) GetLimitOrders(ctx sdk.Context, token string) (orders []types.LimitOrder) {
+	iterator := k.GetLimitOrdersIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var lo types.LimitOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &lo)
+		orders = append(orders, lo)
+	}
+	return orders
+}
+
+func (k Keeper) GetLimitOrder(ctx sdk.Context, token string, id uint64) (lo types.LimitOrder, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetLimitOrderKey(token, id))
+	if bz == nil {
+		return lo, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &lo)
+	return lo, true
+}
+
+func (k Keeper) LimitOrderExists(ctx sdk.Context, token string, id uint64) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetLimitOrderKey(token, id))
+}
+
+// HasLimitOrders returns true if the bond's limit order book is not empty.
+func (k Keeper) HasLimitOrders(ctx sdk.Context, token string) bool {
+	iterator := k.GetLimitOrdersIterator(ctx, token)
+	defer iterator.Close()
+	return iterator.Valid()
+}
+
+func (k Keeper) SetLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetLimitOrderKey(token, lo.ID), k.cdc.MustMarshalBinaryBare(lo))
+}
+
+func (k Keeper) DeleteLimitOrder(ctx sdk.Context, token string, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetLimitOrderKey(token, id))
+}
+
+// AddLimitOrder assigns an ID to the limit order, adds it to the bond's limit
+// order book, and returns the assigned ID. The escrow of the limit order is
+// expected to already be held in the batches intermediary account. The batch
+// is scheduled if it is not already, so that the limit order is considered
+// when the batch is performed.
+func (k Keeper) AddLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	lo.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetLimitOrder(ctx, token, lo)
+	if !k.BatchIsScheduled(ctx, token) {
+		k.ScheduleBatch(ctx, token)
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added %s order %d for %s from %s", lo.OrderType, lo.ID, lo.Amount.String(), lo.Address.String()))
+	return lo.ID
+}
+
+// CancelLimitOrder removes the limit order with the specified ID from the
+// bond's limit order book on behalf of the order's address, returning the
+// tokens held in escrow for the order.
+func (k Keeper) CancelLimitOrder(ctx sdk.Context, token string, id uint64,
+	address sdk.AccAddress, reason string) error {
+	lo, found := k.GetLimitOrder(ctx, token, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrOrderDoesNotExist, "limit order %d", id)
+	} else if !lo.Address.Equals(address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the order's address", address.String())
+	}
+	return k.removeLimitOrder(ctx, token, lo, reason)
+}
+
+// CancelAllLimitOrders removes all of the limit orders in the bond's limit order
+// book, returning the tokens held in escrow for the orders to their owners.
+func (k Keeper) CancelAllLimitOrders(ctx sdk.Context, token, reason string) error {
+	for _, lo := range k.GetLimitOrders(ctx, token) {
+		err := k.removeLimitOrder(ctx, token, lo, reason)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k Keeper) removeLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder, reason string) error {
+	k.DeleteLimitOrder(ctx, token, lo.ID)
+
+	// Return tokens held in escrow for the order
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BatchesIntermediaryAccount, lo.Address, lo.GetEscrow())
+	if err != nil {
+		return err
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("cancelled %s order %d from %s", lo.OrderType, lo.ID, lo.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, lo.OrderType),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(lo.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, lo.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, reason),
+	))
+
+	return nil
+}
+
+// AddLimitOrdersToBatch removes any expired limit orders from the bond's limit
+// order book and adds the rest, in the order that they were placed, to the
+// current batch if they can be filled at the batch prices. A limit order is
+// only added if, at the resulting batch prices, no order already in the batch
+// has to be cancelled or reduced, so that limit orders never displace orders
+// submitted directly to the batch (or limit orders added before them).
+//
+// The limit orders added to the batch keep their ID and remain in the limit
+// order book, together with their escrow, until RemoveFilledLimitOrders is
+// called once the batch has been performed.
+func (k Keeper) AddLimitOrdersToBatch(ctx sdk.Context, token string) {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+
+	canBuy := bond.State == types.OpenState || bond.State == types.HatchState
+	canSell := bond.State == types.OpenState && bond.AllowSells
+
+	for _, lo := range k.GetLimitOrders(ctx, token) {
+		if lo.IsExpired(ctx.BlockHeight()) {
+			err := k.removeLimitOrder(ctx, token, lo, "limit order expired")
+			if err != nil {
+				panic(err)
+			}
+			continue
+		} else if (lo.IsBuy() && !canBuy) || (!lo.IsBuy() && !canSell) {
+			continue
+		}
+
+		// Try adding the order in a cached context, which is only written if
+		// the order did not make any of the batch's orders unfulfillable
+		cacheCtx, write := ctx.CacheContext()
+		cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+		err := k.addLimitOrderToBatch(cacheCtx, token, lo)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("%s order %d not added to batch: %s", lo.OrderType, lo.ID, err.Error()))
+			continue
+		} else if k.CancelUnfulfillableOrders(cacheCtx, token) != 0 {
+			logger.Debug(fmt.Sprintf("%s order %d not added to batch: would displace other orders", lo.OrderType, lo.ID))
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+		logger.Info(fmt.Sprintf("added %s order %d for %s from %s to batch", lo.OrderType, lo.ID, lo.Amount.String(), lo.Address.String()))
+	}
+}
+
+func (k Keeper) addLimitOrderToBatch(ctx sdk.Context, token string, lo types.LimitOrder) error {
+	if lo.IsBuy() {
+		bo := lo.ToBuyOrder()
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		if err != nil {
+			return err
+		}
+
+		batch := k.MustGetBatchHeader(ctx, token)
+		batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+		k.SetBatchHeader(ctx, token, batch)
+		k.SetBuyOrder(ctx, token, bo)
+	} else {
+		so := lo.ToSellOrder()
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, so)
+		if err != nil {
+			return err
+		}
+
+		batch := k.MustGetBatchHeader(ctx, token)
+		batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+		k.SetBatchHeader(ctx, token, batch)
+		k.SetSellOrder(ctx, token, so)
+	}
+	return nil
+}
+
+// RemoveFilledLimitOrders removes the limit orders that were filled as part of
+// the current batch from the bond's limit order book, and burns the bond tokens
+// held in escrow for filled limit sells. A limit order counts as filled even if
+// it was reduced (e.g. because of the bond's max supply allocation), in which
+// case the remainder of its escrow was returned when it was performed. Limit
+// orders that were not added to the batch, or whose batch order was cancelled,
+// remain in the book.
+func (k Keeper) RemoveFilledLimitOrders(ctx sdk.Context, token string) {
+	store := ctx.KVStore(k.storeKey)
+	for _, lo := range k.GetLimitOrders(ctx, token) {
+		var order types.BaseOrder
+		if lo.IsBuy() {
+			bz := store.Get(types.GetBatchOrderKey(token, types.BuyOrdersKeyPrefix, lo.ID))
+			if bz == nil {
+				continue
+			}
+			var bo types.BuyOrder
+			k.cdc.MustUnmarshalBinaryBare(bz, &bo)
+			order = bo.BaseOrder
+		} else {
+			bz := store.Get(types.GetBatchOrderKey(token, types.SellOrdersKeyPrefix, lo.ID))
+			if bz == nil {
+				continue
+			}
+			var so types.SellOrder
+			k.cdc.MustUnmarshalBinaryBare(bz, &so)
+			order = so.BaseOrder
+		}
+		if order.IsCancelled() {
+			continue
+		}
+
+		// Burn bond tokens sold (not burned when the limit sell was placed)
+		if !lo.IsBuy() {
+			err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+				types.BatchesIntermediaryAccount, types.BondsMintBurnAccount,
+				sdk.Coins{lo.Amount})
+			if err != nil {
+				panic(err)
+			}
+			err = k.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
+				sdk.Coins{lo.Amount})
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		k.DeleteLimitOrder(ctx, token, lo.ID)
+
+		logger := k.Logger(ctx)
+		logger.Info(fmt.Sprintf("filled %s order %d from %s", lo.OrderType, lo.ID, lo.Address.String()))
+	}
+}
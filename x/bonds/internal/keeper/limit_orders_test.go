@@ -0,0 +1,111 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAddLimitOrderAssignsIDAndSchedulesBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Add buy order followed by limit order
+	bo := getValidBuyOrder()
+	app.BondsKeeper.AddBuyOrder(ctx, bond.Token, bo, buyPrices, sellPrices)
+	pricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 100))
+	lo := types.NewLimitBuyOrder(bond, buyerAddress, buyAmount, pricesPerToken, 0)
+	id := app.BondsKeeper.AddLimitOrder(ctx, bond.Token, lo)
+
+	// Limit order ID follows the buy order ID
+	require.Equal(t, uint64(1), id)
+	require.Equal(t, uint64(2), app.BondsKeeper.MustGetBatchHeader(ctx, bond.Token).NextOrderID)
+	loFetched, found := app.BondsKeeper.GetLimitOrder(ctx, bond.Token, id)
+	require.True(t, found)
+	require.Equal(t, id, loFetched.ID)
+	require.True(t, app.BondsKeeper.HasLimitOrders(ctx, bond.Token))
+
+	// Limit order alone schedules the batch of a bond with no other orders
+	app.BondsKeeper.SetBond(ctx, token2, bond)
+	app.BondsKeeper.SetBatch(ctx, token2, types.NewBatch(token2, batchBlocks))
+	require.False(t, app.BondsKeeper.BatchIsScheduled(ctx, token2))
+	app.BondsKeeper.AddLimitOrder(ctx, token2, lo)
+	require.True(t, app.BondsKeeper.BatchIsScheduled(ctx, token2))
+
+	// Limit orders are not mixed up between bonds
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, bond.Token), 1)
+	require.Len(t, app.BondsKeeper.GetLimitOrders(ctx, token2), 1)
+}
+
+func TestAddLimitOrdersToBatch(t *testing.T) {
+	testCases := []struct {
+		pricePerToken int64
+		added         bool
+	}{
+		{499, false},
+		{500, true}, // 4(10^2)+100
+		{501, true},
+	}
+	for _, tc := range testCases {
+		app, ctx := createTestApp(false)
+
+		// Create bond (no fees for simpler test) and batch
+		bond := getValidBond()
+		bond.TxFeePercentage = sdk.ZeroDec()
+		app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+		app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+		// Add limit buy of 10 tokens
+		pricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, tc.pricePerToken))
+		amount := sdk.NewInt64Coin(bond.Token, 10)
+		lo := types.NewLimitBuyOrder(bond, buyerAddress, amount, pricesPerToken, 0)
+		id := app.BondsKeeper.AddLimitOrder(ctx, bond.Token, lo)
+
+		// Limit buy only added to batch if fillable at the batch buy prices
+		app.BondsKeeper.AddLimitOrdersToBatch(ctx, bond.Token)
+		batch := app.BondsKeeper.MustGetBatch(ctx, bond.Token)
+		if tc.added {
+			require.Len(t, batch.Buys, 1)
+			require.Equal(t, id, batch.Buys[0].ID)
+			require.Equal(t, amount, batch.TotalBuyAmount)
+			require.Equal(t, sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 500)}, batch.BuyPrices)
+		} else {
+			require.Len(t, batch.Buys, 0)
+			require.True(t, batch.TotalBuyAmount.IsZero())
+		}
+
+		// Limit buy remains in the limit order book either way
+		require.True(t, app.BondsKeeper.LimitOrderExists(ctx, bond.Token, id))
+	}
+}
+
+func TestAddLimitOrdersToBatchRemovesExpiredOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, bond.Token, bond)
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+
+	// Add limit buy expiring at height 5 and its escrow
+	pricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 1))
+	lo := types.NewLimitBuyOrder(bond, buyerAddress, buyAmount, pricesPerToken, 5)
+	id := app.BondsKeeper.AddLimitOrder(ctx, bond.Token, lo)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), lo.MaxPrices)
+
+	// Limit buy not yet expired
+	ctx = ctx.WithBlockHeight(4)
+	app.BondsKeeper.AddLimitOrdersToBatch(ctx, bond.Token)
+	require.True(t, app.BondsKeeper.LimitOrderExists(ctx, bond.Token, id))
+
+	// Limit buy expired; removed from the book and escrow returned
+	app.BondsKeeper.SetBatch(ctx, bond.Token, getValidBatch())
+	ctx = ctx.WithBlockHeight(5)
+	app.BondsKeeper.AddLimitOrdersToBatch(ctx, bond.Token)
+	require.False(t, app.BondsKeeper.LimitOrderExists(ctx, bond.Token, id))
+	require.Len(t, app.BondsKeeper.MustGetBatch(ctx, bond.Token).Buys, 0)
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, lo.MaxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+}
@@ -0,0 +1,376 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
+)
+
+// getRemoveLiquidityReturns returns the returns of burning the specified amount
+// of a swapper bond's tokens, after deducting the fees charged for the burn as
+// for a sell, together with these fees. The returns are split into the returns
+// in the to token and the returns in the bond's other reserve token (swap),
+// which are to be swapped for the to token.
+func getRemoveLiquidityReturns(bond types.Bond, amount sdk.Int, toToken string,
+	reserveBalances sdk.Coins) (toReturns, swap sdk.Coin, fees sdk.Coins, err error) {
+	swapToken, err := bond.GetSwapToToken(toToken)
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, nil, err
+	}
+
+	reserveReturns := bond.GetReturnsForBurn(amount, reserveBalances)
+	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
+	txFees := bond.GetTxFees(reserveReturns)
+	exitFees := bond.GetExitFees(reserveReturns)
+	fees = types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	totalReturns := reserveReturnsRounded.Sub(fees)
+
+	toReturns = sdk.NewCoin(toToken, totalReturns.AmountOf(toToken))
+	swap = sdk.NewCoin(swapToken, totalReturns.AmountOf(swapToken))
+	return toReturns, swap, fees, nil
+}
+
+// getRemoveLiquiditySwapReturns returns the returns in the to token, and the
+// fee charged, for swapping the returns of a remove liquidity order in the bond's
+// other reserve token (swap), using the reserves after the burn. An error is
+// returned if the swap cannot be performed (e.g. since it is too small) or if
+// the reserves after the swap violate the bond's sanity rate.
+func getRemoveLiquiditySwapReturns(bond types.Bond, toReturns, swap sdk.Coin,
+	fees sdk.Coins, reserveBalances sdk.Coins) (swapReturns, swapFee sdk.Coin, err error) {
+	swapReturns = sdk.NewCoin(toReturns.Denom, sdk.ZeroInt())
+	swapFee = sdk.NewCoin(swap.Denom, sdk.ZeroInt())
+	if !swap.IsPositive() {
+		return swapReturns, swapFee, nil
+	}
+
+	newReserveBalances := reserveBalances.Sub(
+		sdk.NewCoins(toReturns, swap).Add(fees...))
+	returns, swapFee, err := bond.GetReturnsForSwap(swap, toReturns.Denom, newReserveBalances)
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, err
+	}
+	swapReturns = sdk.NewCoin(toReturns.Denom, returns.AmountOf(toReturns.Denom))
+
+	// The fee-adjusted swap amount stays in the reserve
+	newReserveBalances = newReserveBalances.Add(swap.Sub(swapFee)).Sub(sdk.Coins{swapReturns})
+	if bond.ReservesViolateSanityRate(newReserveBalances) {
+		return sdk.Coin{}, sdk.Coin{}, sdkerrors.Wrap(
+			types.ErrValuesViolateSanityRate, newReserveBalances.String())
+	}
+	return swapReturns, swapFee, nil
+}
+
+// GetRemoveLiquidityOutput returns the amount of the to token that is returned
+// for burning the specified amount of a swapper bond's tokens, where the returns
+// in the bond's other reserve token are swapped for the to token using the
+// reserves after the burn. The returns in the other reserve token and the fees
+// charged for the burn and for the swap are also returned. An error is returned
+// if the returns in the other reserve token cannot be swapped.
+func (k Keeper) GetRemoveLiquidityOutput(ctx sdk.Context, token string, amount sdk.Int,
+	toToken string) (output sdk.Coin, swap sdk.Coin, fees sdk.Coins, err error) {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+
+	toReturns, swap, fees, err := getRemoveLiquidityReturns(
+		bond, amount, toToken, reserveBalances)
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, nil, err
+	}
+
+	swapReturns, swapFee, err := getRemoveLiquiditySwapReturns(
+		bond, toReturns, swap, fees, reserveBalances)
+	if err != nil {
+		return sdk.Coin{}, sdk.Coin{}, nil, err
+	}
+
+	output = toReturns.Add(swapReturns)
+	if swapFee.IsPositive() {
+		fees = fees.Add(swapFee)
+	}
+	return output, swap, fees, nil
+}
+
+// PrepareLiquidityOrders prepares the batch's add and remove liquidity orders
+// for the batch's swaps, so that the parts of these orders that need to be
+// swapped are swapped at the same rate as the batch's other swaps.
+//
+// Each remove liquidity order is performed in full (see performRemoveLiquidity)
+// before the batch's swaps, or cancelled if its output (see
+// GetRemoveLiquidityOutput) does not meet its minimum output.
+//
+// For each add liquidity order, the excess of one of the reserve tokens in its
+// deposit (see GetLiquidityDepositSwap) is added to the batch as a swap order,
+// whose returns are added to the order's budget once the swap is performed.
+func (k Keeper) PrepareLiquidityOrders(ctx sdk.Context, token string) {
+	for _, ro := range k.GetRemoveLiquidityOrders(ctx, token) {
+		if ro.IsCancelled() {
+			continue
+		}
+		err := k.performRemoveLiquidity(ctx, token, ro)
+		if err != nil {
+			k.cancelRemoveLiquidityOrder(ctx, token, ro, err)
+		}
+	}
+
+	for _, ao := range k.GetAddLiquidityOrders(ctx, token) {
+		if ao.IsCancelled() {
+			continue
+		}
+
+		bond := k.MustGetBond(ctx, token)
+		reserveBalances := k.GetReserveBalances(ctx, token)
+		swap, err := bond.GetLiquidityDepositSwap(ao.Budget, reserveBalances)
+		if err != nil {
+			k.cancelAddLiquidityOrder(ctx, token, ao, err)
+			continue
+		} else if swap.IsZero() {
+			continue
+		}
+
+		// Move the excess from the order's budget to a swap order
+		toToken, err := bond.GetSwapToToken(swap.Denom)
+		if err != nil {
+			panic(err)
+		}
+		ao.Budget = ao.Budget.Sub(sdk.Coins{swap})
+		k.SetAddLiquidityOrder(ctx, token, ao)
+		k.addLiquiditySwapOrder(ctx, token,
+			types.NewAddLiquiditySwapOrder(ao.Address, swap, toToken, ao.ID))
+	}
+}
+
+// PerformAddLiquidityOrders buys bond tokens for the batch's add liquidity
+// orders with the orders' budgets, as for buys with reserve, returning any
+// remainder of the budgets to the providers. An order is cancelled if the
+// amount of bond tokens that its budget can buy does not meet its minimum
+// amount, in which case its budget is returned to the provider.
+func (k Keeper) PerformAddLiquidityOrders(ctx sdk.Context, token string) {
+	for _, ao := range k.GetAddLiquidityOrders(ctx, token) {
+		if ao.IsCancelled() {
+			continue
+		}
+
+		amount, reservePrices, err := k.GetBuyWithReserveAmount(ctx, token, ao.Budget)
+		if err == nil && amount.LT(ao.MinAmount.Amount) {
+			err = sdkerrors.Wrapf(types.ErrMinAmountNotMet,
+				"Actual amount %s does not meet min amount %s", amount, ao.MinAmount)
+		}
+		if err != nil {
+			k.cancelAddLiquidityOrder(ctx, token, ao, err)
+			continue
+		}
+
+		// Perform buy, using the budget as the max prices so that any
+		// remainder of the budget is returned to the provider
+		ao.Amount = sdk.NewCoin(token, amount)
+		k.SetAddLiquidityOrder(ctx, token, ao)
+		buyOrder := types.NewBuyOrder(ao.Address, ao.Amount, ao.Budget)
+		buyOrder.ID = ao.ID
+		err = k.PerformBuyAtReservePrices(ctx, token, buyOrder, reservePrices,
+			types.AttributeValueAddLiquidityOrder)
+		if err != nil {
+			// Panic here since all calculations should have been done
+			// correctly to prevent any errors during the buy
+			panic(err)
+		}
+	}
+}
+
+// performRemoveLiquidity performs the remove liquidity order as a single step,
+// burning its bond tokens (already burned in handleMsgRemoveLiquidity) against
+// the reserve and swapping the returns in the reserve token other than the to
+// token for the to token, at the rate given by the reserves after the burn. The
+// total output is checked against the order's minimum output before anything
+// is paid out, so that if an error is returned (in which case the order gets
+// cancelled), the reserve is left untouched. The swap is performed directly
+// against the reserve rather than as part of the batch's swaps, given that the
+// provider is otherwise left with the returns in the other reserve token if
+// the swap gets cancelled.
+func (k Keeper) performRemoveLiquidity(ctx sdk.Context, token string, ro types.RemoveLiquidityOrder) error {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+
+	toReturns, swap, fees, err := getRemoveLiquidityReturns(
+		bond, ro.Amount.Amount, ro.ToToken, reserveBalances)
+	if err != nil {
+		return err
+	}
+	swapReturns, swapFee, err := getRemoveLiquiditySwapReturns(
+		bond, toReturns, swap, fees, reserveBalances)
+	if err != nil {
+		return err
+	}
+
+	// Check that the output meets the min output
+	output := toReturns.Add(swapReturns)
+	if output.Amount.LT(ro.MinOutput.Amount) {
+		return sdkerrors.Wrapf(types.ErrMinOutputNotMet,
+			"Actual output %s does not meet min output %s", output, ro.MinOutput)
+	}
+
+	// Send output to provider (the fee-adjusted returns in the other reserve
+	// token stay in the reserve, since these are swapped against it)
+	if output.IsPositive() {
+		err = k.WithdrawReserve(ctx, token, ro.Address, sdk.Coins{output})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Send total fee (for the burn and for the swap) to fee address
+	totalFees := fees
+	if swapFee.IsPositive() {
+		totalFees = totalFees.Add(swapFee)
+	}
+	if !totalFees.IsZero() {
+		err = k.WithdrawReserve(ctx, token, bond.FeeAddress, totalFees)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update supply (burn more than supply check done during
+	// MsgRemoveLiquidity) and provider's position
+	k.SetCurrentSupply(ctx, token, bond.CurrentSupply.Sub(ro.Amount))
+	position := k.GetPosition(ctx, token, ro.Address)
+	position = position.AddSell(ro.Amount, sdk.NewCoins(toReturns, swap), fees)
+	if swap.IsPositive() {
+		position = position.AddSwap(sdk.Coins{swap.Sub(swapFee)},
+			sdk.Coins{swapReturns}, sdk.Coins{swapFee})
+	}
+	k.SetPosition(ctx, position)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("performed remove liquidity order for %s from %s", ro.Amount.String(), ro.Address.String()))
+
+	// Get new bond token balance
+	bondTokenBalance := k.BankKeeper.GetCoins(ctx, ro.Address).AmountOf(token)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderFulfill,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueRemoveLiquidityOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(ro.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, ro.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyTokensBurned, ro.Amount.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyChargedFees, totalFees.String()),
+		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, output.String()),
+		sdk.NewAttribute(types.AttributeKeyTokensSwapped, swap.String()),
+		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
+	))
+
+	return nil
+}
+
+// performAddLiquiditySwap moves the returns of a swap order for an add
+// liquidity order from the bond's reserve to the batches intermediary account,
+// and adds them to the add liquidity order's budget.
+func (k Keeper) performAddLiquiditySwap(ctx sdk.Context, token string,
+	so types.SwapOrder, reserveReturns sdk.Coins) error {
+	moduleAddr := k.SupplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount)
+	err := k.WithdrawReserve(ctx, token, moduleAddr, reserveReturns)
+	if err != nil {
+		return err
+	}
+
+	k.addToAddLiquidityBudget(ctx, token, so.LiquidityOrderID, reserveReturns)
+	return nil
+}
+
+// addToAddLiquidityBudget adds the coins, which are expected to already be held
+// in the batches intermediary account, to the budget of the add liquidity order.
+func (k Keeper) addToAddLiquidityBudget(ctx sdk.Context, token string, orderID uint64, coins sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetBatchOrderKey(token, types.AddLiquidityOrdersKeyPrefix, orderID)
+	bz := store.Get(key)
+	if bz == nil {
+		panic(fmt.Sprintf("add liquidity order %d not found for %s", orderID, token))
+	}
+
+	var ao types.AddLiquidityOrder
+	k.cdc.MustUnmarshalBinaryBare(bz, &ao)
+	ao.Budget = ao.Budget.Add(coins...)
+	k.SetAddLiquidityOrder(ctx, token, ao)
+}
+
+// addLiquiditySwapOrder assigns an ID to a swap order for an add or remove
+// liquidity order and adds it to the batch that is being performed. Unlike
+// AddSwapOrder, the batch is not scheduled, since its orders are already being
+// performed.
+func (k Keeper) addLiquiditySwapOrder(ctx sdk.Context, token string, so types.SwapOrder) uint64 {
+	batch := k.MustGetBatchHeader(ctx, token)
+	so.ID = batch.NextOrderID
+	batch.NextOrderID++
+	k.SetBatchHeader(ctx, token, batch)
+	k.SetSwapOrder(ctx, token, so)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added liquidity swap order %d for %s to %s from %s", so.ID, so.Amount.String(), so.ToToken, so.Address.String()))
+	return so.ID
+}
+
+// cancelAddLiquidityOrder cancels the add liquidity order, returning its budget
+// to the provider.
+func (k Keeper) cancelAddLiquidityOrder(ctx sdk.Context, token string, ao types.AddLiquidityOrder, reason error) {
+	logger := k.Logger(ctx)
+
+	ao.Cancelled = true
+	ao.CancelReason = reason.Error()
+	k.SetAddLiquidityOrder(ctx, token, ao)
+
+	logger.Info(fmt.Sprintf("cancelled add liquidity order for %s with deposit %s from %s", token, ao.Deposit.String(), ao.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason.Error()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueAddLiquidityOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(ao.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, ao.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, ao.CancelReason),
+	))
+
+	// Return budget to provider
+	if !ao.Budget.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, ao.Address, ao.Budget)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// cancelRemoveLiquidityOrder cancels the remove liquidity order, re-minting and
+// returning the bond tokens burned in handleMsgRemoveLiquidity to the provider.
+func (k Keeper) cancelRemoveLiquidityOrder(ctx sdk.Context, token string, ro types.RemoveLiquidityOrder, reason error) {
+	logger := k.Logger(ctx)
+
+	ro.Cancelled = true
+	ro.CancelReason = reason.Error()
+	k.SetRemoveLiquidityOrder(ctx, token, ro)
+
+	logger.Info(fmt.Sprintf("cancelled remove liquidity order for %s to %s from %s", ro.Amount.String(), ro.ToToken, ro.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason.Error()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueRemoveLiquidityOrder),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(ro.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, ro.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, ro.CancelReason),
+	))
+
+	// Re-mint and return bond tokens
+	err := k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, sdk.Coins{ro.Amount})
+	if err != nil {
+		panic(err)
+	}
+	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsMintBurnAccount, ro.Address, sdk.Coins{ro.Amount})
+	if err != nil {
+		panic(err)
+	}
+}
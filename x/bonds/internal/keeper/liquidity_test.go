@@ -0,0 +1,63 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetRemoveLiquidityOutput(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000), sdk.NewInt64Coin(reserveToken2, 10000)))
+	bond := app.BondsKeeper.MustGetBond(ctx, token1)
+	bond.CurrentSupply = sdk.NewInt64Coin(token1, 100)
+	app.BondsKeeper.SetBond(ctx, token1, bond)
+
+	// Burning 10 of 100 tokens returns 1000res and 1000rez, less fees of
+	// 1+1 (tx+exit) of each. The 998rez are swapped using the reserves
+	// after the burn (9000res/9000rez), with a fee of 1rez:
+	// - 997rez*9000res/(9000rez+997rez) = 897res
+	output, swap, fees, err := app.BondsKeeper.GetRemoveLiquidityOutput(
+		ctx, token1, sdk.NewInt(10), reserveToken)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken, 998+897), output)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken2, 998), swap)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 2),
+		sdk.NewInt64Coin(reserveToken2, 3),
+	), fees)
+}
+
+func TestGetRemoveLiquidityOutputWithFailingSwapFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000), sdk.NewInt64Coin(reserveToken2, 10000)))
+	bond := app.BondsKeeper.MustGetBond(ctx, token1)
+	bond.CurrentSupply = sdk.NewInt64Coin(token1, 100)
+	bond.SanityRate = sdk.OneDec()
+	bond.SanityMarginPercentage = sdk.NewDec(10)
+	app.BondsKeeper.SetBond(ctx, token1, bond)
+
+	// Swapping the 998rez for 897res moves the reserve ratio to 0.81, which
+	// violates the sanity rate, so no output can be given
+	_, _, _, err := app.BondsKeeper.GetRemoveLiquidityOutput(
+		ctx, token1, sdk.NewInt(10), reserveToken)
+	require.Error(t, err)
+	require.True(t, types.ErrValuesViolateSanityRate.Is(err))
+}
+
+func TestGetRemoveLiquidityOutputInvalidToTokenFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000), sdk.NewInt64Coin(reserveToken2, 10000)))
+
+	_, _, _, err := app.BondsKeeper.GetRemoveLiquidityOutput(
+		ctx, token1, sdk.NewInt(1), reserveToken3)
+	require.Error(t, err)
+	require.True(t, types.ErrTokenIsNotAValidReserveToken.Is(err))
+}
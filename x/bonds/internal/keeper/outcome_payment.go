@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetOutcomePaymentContributionsIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetOutcomePaymentContributionsPrefix(token))
+}
+
+func (k Keeper) GetOutcomePaymentContributions(ctx sdk.Context, token string) (contributions []types.OutcomePaymentContribution) {
+	iterator := k.GetOutcomePaymentContributionsIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var contribution types.OutcomePaymentContribution
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &contribution)
+		contributions = append(contributions, contribution)
+	}
+	return contributions
+}
+
+// GetOutcomePaymentContribution returns the outcome payment contribution of
+// the address to the bond, or a new empty contribution if the address has not
+// made any outcome payments to the bond.
+func (k Keeper) GetOutcomePaymentContribution(ctx sdk.Context, token string, address sdk.AccAddress) types.OutcomePaymentContribution {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetOutcomePaymentContributionKey(token, address))
+	if bz == nil {
+		return types.NewOutcomePaymentContribution(token, address)
+	}
+
+	var contribution types.OutcomePaymentContribution
+	k.cdc.MustUnmarshalBinaryBare(bz, &contribution)
+	return contribution
+}
+
+func (k Keeper) SetOutcomePaymentContribution(ctx sdk.Context, contribution types.OutcomePaymentContribution) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetOutcomePaymentContributionKey(contribution.BondToken, contribution.Address)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(contribution))
+}
+
+// GetOutcomePaymentEscrowBalances returns the outcome payments made to the bond
+// that are held in escrow until the bond settles.
+func (k Keeper) GetOutcomePaymentEscrowBalances(ctx sdk.Context, token string) sdk.Coins {
+	return k.BankKeeper.GetCoins(ctx, types.GetOutcomePaymentEscrowAddress(token))
+}
+
+// MoveOutcomePaymentEscrowToReserve moves the outcome payments made to the bond
+// that are held in escrow to the bond's reserve, so that these are paid out to
+// the bond token holders when they withdraw their share of the reserve.
+func (k Keeper) MoveOutcomePaymentEscrowToReserve(ctx sdk.Context, token string) error {
+	escrow := k.GetOutcomePaymentEscrowBalances(ctx, token)
+	if escrow.IsZero() {
+		return nil
+	}
+	return k.DepositReserve(ctx, token, types.GetOutcomePaymentEscrowAddress(token), escrow)
+}
+
+// MakeOutcomePayment sends the outcome payment from the address to the bond's
+// outcome payment escrow, and records it as part of the address' contribution
+// and of the total outcome payment received by the bond. The payment is only
+// moved to the bond's reserve once the bond settles, so that it cannot be
+// taken out of the reserve by sells made while the bond is still open.
+func (k Keeper) MakeOutcomePayment(ctx sdk.Context, token string, address sdk.AccAddress, amount sdk.Coins) error {
+	err := k.BankKeeper.SendCoins(ctx,
+		address, types.GetOutcomePaymentEscrowAddress(token), amount)
+	if err != nil {
+		return err
+	}
+
+	contribution := k.GetOutcomePaymentContribution(ctx, token, address)
+	k.SetOutcomePaymentContribution(ctx, contribution.Add(amount))
+
+	bond := k.MustGetBond(ctx, token)
+	bond.OutcomePaymentReceived = bond.OutcomePaymentReceived.Add(amount...)
+	k.SetBond(ctx, token, bond)
+
+	return nil
+}
+
+// SettleBond moves an OPEN bond to the SETTLE state once its outcome payment
+// has been made, cancelling any pending orders and limit orders and moving the
+// outcome payment held in escrow to the reserve, so that bond token holders can
+// withdraw their share of the reserve.
+func (k Keeper) SettleBond(ctx sdk.Context, token string) error {
+	// Cancel any pending orders and limit orders, returning the tokens held
+	// for them
+	err := k.CancelAllOrders(ctx, token, "outcome payment made")
+	if err != nil {
+		return err
+	}
+	err = k.CancelAllLimitOrders(ctx, token, "outcome payment made")
+	if err != nil {
+		return err
+	}
+
+	// Move the outcome payment held in escrow to the reserve
+	err = k.MoveOutcomePaymentEscrowToReserve(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	k.SetBondState(ctx, token, types.SettleState)
+	return nil
+}
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetPositionsIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetPositionsPrefix(token))
+}
+
+func (k Keeper) GetPositions(ctx sdk.Context, token string) (positions []types.Position) {
+	iterator := k.GetPositionsIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var position types.Position
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &position)
+		positions = append(positions, position)
+	}
+	return positions
+}
+
+// GetPosition returns the position of the address in the bond, or a new empty
+// position if the address has not yet bought, sold, swapped, or withdrawn.
+func (k Keeper) GetPosition(ctx sdk.Context, token string, address sdk.AccAddress) types.Position {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPositionKey(token, address))
+	if bz == nil {
+		return types.NewPosition(token, address)
+	}
+
+	var position types.Position
+	k.cdc.MustUnmarshalBinaryBare(bz, &position)
+	return position
+}
+
+func (k Keeper) SetPosition(ctx sdk.Context, position types.Position) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetPositionKey(position.BondToken, position.Address)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(position))
+}
@@ -5,23 +5,39 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/ixoworld/bonds/x/bonds/client"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
+	"strings"
 )
 
 const (
-	QueryBonds          = "bonds"
-	QueryBond           = "bond"
-	QueryBatch          = "batch"
-	QueryLastBatch      = "last_batch"
-	QueryCurrentPrice   = "current_price"
-	QueryCurrentReserve = "current_reserve"
-	QueryCustomPrice    = "custom_price"
-	QueryBuyPrice       = "buy_price"
-	QuerySellReturn     = "sell_return"
-	QuerySwapReturn     = "swap_return"
-	QueryParams         = "params"
+	QueryBonds                 = "bonds"
+	QueryBond                  = "bond"
+	QueryBatch                 = "batch"
+	QueryLastBatch             = "last_batch"
+	QueryOrder                 = "order"
+	QueryBatchesHistory        = "batches_history"
+	QueryCandles               = "candles"
+	QueryPosition              = "position"
+	QueryVesting               = "vesting"
+	QueryFundingPool           = "funding_pool"
+	QueryNextFundingWithdrawal = "next_funding_withdrawal"
+	QueryLimitOrders           = "limit_orders"
+	QueryOutcomePayments       = "outcome_payments"
+	QueryCurrentPrice          = "current_price"
+	QueryCurrentReserve        = "current_reserve"
+	QueryCustomPrice           = "custom_price"
+	QueryBuyPrice              = "buy_price"
+	QuerySellReturn            = "sell_return"
+	QuerySwapReturn            = "swap_return"
+	QuerySwapRouteReturn       = "swap_route_return"
+	QueryBestSwapRoute         = "best_swap_route"
+	QueryAddLiquidityReturn    = "add_liquidity_return"
+	QueryRemoveLiquidityReturn = "remove_liquidity_return"
+	QueryParams                = "params"
 )
 
 // NewQuerier is the module level router for state queries
@@ -36,6 +52,24 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBatch(ctx, path[1:], keeper)
 		case QueryLastBatch:
 			return queryLastBatch(ctx, path[1:], keeper)
+		case QueryOrder:
+			return queryOrder(ctx, path[1:], keeper)
+		case QueryBatchesHistory:
+			return queryBatchesHistory(ctx, path[1:], keeper)
+		case QueryCandles:
+			return queryCandles(ctx, path[1:], keeper)
+		case QueryPosition:
+			return queryPosition(ctx, path[1:], keeper)
+		case QueryVesting:
+			return queryVesting(ctx, path[1:], keeper)
+		case QueryFundingPool:
+			return queryFundingPool(ctx, path[1:], keeper)
+		case QueryNextFundingWithdrawal:
+			return queryNextFundingWithdrawal(ctx, path[1:], keeper)
+		case QueryLimitOrders:
+			return queryLimitOrders(ctx, path[1:], keeper)
+		case QueryOutcomePayments:
+			return queryOutcomePayments(ctx, path[1:], keeper)
 		case QueryCurrentPrice:
 			return queryCurrentPrice(ctx, path[1:], keeper)
 		case QueryCurrentReserve:
@@ -48,6 +82,14 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return querySellReturn(ctx, path[1:], keeper)
 		case QuerySwapReturn:
 			return querySwapReturn(ctx, path[1:], keeper)
+		case QuerySwapRouteReturn:
+			return querySwapRouteReturn(ctx, path[1:], keeper)
+		case QueryBestSwapRoute:
+			return queryBestSwapRoute(ctx, path[1:], keeper)
+		case QueryAddLiquidityReturn:
+			return queryAddLiquidityReturn(ctx, path[1:], keeper)
+		case QueryRemoveLiquidityReturn:
+			return queryRemoveLiquidityReturn(ctx, path[1:], keeper)
 		case QueryParams:
 			return queryParams(ctx, keeper)
 		default:
@@ -119,6 +161,7 @@ func queryBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err
 	}
 
 	batch := keeper.MustGetBatch(ctx, bondToken)
+	batch.BlocksRemaining = keeper.GetBatchBlocksRemaining(ctx, bondToken)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, batch)
 	if err2 != nil {
@@ -145,6 +188,292 @@ func queryLastBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	return bz, nil
 }
 
+func queryBatchesHistory(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	pageStr := path[1]
+	limitStr := path[2]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	page, err2 := strconv.Atoi(pageStr)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err2.Error())
+	}
+	limit, err2 := strconv.Atoi(limitStr)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err2.Error())
+	}
+
+	if limit == 0 {
+		limit = rest.DefaultLimit
+	}
+
+	settledBatches := keeper.GetBatchesHistoryPage(ctx, bondToken, page, limit)
+	if settledBatches == nil {
+		settledBatches = []types.SettledBatch{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, settledBatches)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryCandles(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	intervalStr := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	interval, err2 := strconv.ParseInt(intervalStr, 10, 64)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err2.Error())
+	} else if interval <= 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "interval must be positive")
+	}
+
+	settledBatches := keeper.GetBatchesHistory(ctx, bondToken)
+	candles := types.GetCandles(bondToken, settledBatches, interval)
+	if candles == nil {
+		candles = []types.Candle{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, candles)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryPosition(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	addressStr := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	address, err2 := sdk.AccAddressFromBech32(addressStr)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err2.Error())
+	}
+
+	position := keeper.GetPosition(ctx, bondToken, address)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, position)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryVesting(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	addressStr := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	address, err2 := sdk.AccAddressFromBech32(addressStr)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err2.Error())
+	}
+
+	vesting := keeper.GetVesting(ctx, bondToken, address)
+	unvested := keeper.GetUnvested(ctx, bondToken, address)
+	vested := vesting.GetTotal().Sub(unvested)
+	withdrawable := keeper.GetWithdrawableVested(ctx, bondToken, address)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, types.QueryVesting{
+		Vesting: vesting, Vested: vested, Unvested: unvested, Withdrawable: withdrawable})
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryFundingPool(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	} else if !bond.HasFundingPool() {
+		return nil, sdkerrors.Wrap(types.ErrBondHasNoFundingPool, bondToken)
+	}
+
+	fundingPool := types.QueryFundingPool{
+		Address:          types.GetFundingPoolAddress(bondToken),
+		Balance:          keeper.GetFundingPoolBalances(ctx, bondToken),
+		FundingTap:       bond.FundingTap,
+		FundingTapBlocks: bond.FundingTapBlocks,
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, fundingPool)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryNextFundingWithdrawal(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	} else if !bond.HasFundingPool() {
+		return nil, sdkerrors.Wrap(types.ErrBondHasNoFundingPool, bondToken)
+	}
+
+	height, amount := keeper.GetNextFundingWithdrawal(ctx, bondToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc,
+		types.QueryNextFundingWithdrawal{Height: height, Amount: amount})
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryOutcomePayments(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	contributions := keeper.GetOutcomePaymentContributions(ctx, bondToken)
+	if contributions == nil {
+		contributions = []types.OutcomePaymentContribution{}
+	}
+
+	outcomePayments := types.QueryOutcomePayments{
+		OutcomePayment: bond.OutcomePayment,
+		Received:       bond.OutcomePaymentReceived,
+		Remaining:      bond.GetOutcomePaymentRemaining(),
+		Contributions:  contributions,
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, outcomePayments)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryLimitOrders(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
+	}
+
+	limitOrders := keeper.GetLimitOrders(ctx, bondToken)
+	if limitOrders == nil {
+		limitOrders = []types.LimitOrder{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, limitOrders)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func getOrderFromBatch(batch types.Batch, orderID uint64) (order types.QueryOrder, found bool) {
+	for i := range batch.Buys {
+		if batch.Buys[i].ID == orderID {
+			order.OrderType = types.AttributeValueBuyOrder
+			order.BuyOrder = &batch.Buys[i]
+			return order, true
+		}
+	}
+	for i := range batch.BuysWithReserve {
+		if batch.BuysWithReserve[i].ID == orderID {
+			order.OrderType = types.AttributeValueBuyWithReserveOrder
+			order.BuyWithReserveOrder = &batch.BuysWithReserve[i]
+			return order, true
+		}
+	}
+	for i := range batch.Sells {
+		if batch.Sells[i].ID == orderID {
+			order.OrderType = types.AttributeValueSellOrder
+			order.SellOrder = &batch.Sells[i]
+			return order, true
+		}
+	}
+	for i := range batch.Swaps {
+		if batch.Swaps[i].ID == orderID {
+			order.OrderType = types.AttributeValueSwapOrder
+			order.SwapOrder = &batch.Swaps[i]
+			return order, true
+		}
+	}
+	for i := range batch.AddLiquidity {
+		if batch.AddLiquidity[i].ID == orderID {
+			order.OrderType = types.AttributeValueAddLiquidityOrder
+			order.AddLiquidityOrder = &batch.AddLiquidity[i]
+			return order, true
+		}
+	}
+	for i := range batch.RemoveLiquidity {
+		if batch.RemoveLiquidity[i].ID == orderID {
+			order.OrderType = types.AttributeValueRemoveLiquidityOrder
+			order.RemoveLiquidityOrder = &batch.RemoveLiquidity[i]
+			return order, true
+		}
+	}
+	return types.QueryOrder{}, false
+}
+
+func queryOrder(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	orderIDStr := path[1]
+
+	if !keeper.BatchExists(ctx, bondToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "batch for '%s' does not exist", bondToken)
+	}
+
+	orderID, err2 := strconv.ParseUint(orderIDStr, 10, 64)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err2.Error())
+	}
+
+	// Look for the order in the current batch and then in the last batch
+	order, found := getOrderFromBatch(keeper.MustGetBatch(ctx, bondToken), orderID)
+	if !found && keeper.LastBatchExists(ctx, bondToken) {
+		order, found = getOrderFromBatch(keeper.MustGetLastBatch(ctx, bondToken), orderID)
+		order.InLastBatch = found
+	}
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrOrderDoesNotExist,
+			"order %d not found in current or last batch of '%s'", orderID, bondToken)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, order)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
 	bondToken := path[0]
 
@@ -176,7 +505,8 @@ func queryCurrentReserve(ctx sdk.Context, path []string, keeper Keeper) (res []b
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "bond '%s' does not exist", bondToken)
 	}
 
-	reserveBalances := zeroReserveTokensIfEmpty(bond.CurrentReserve, bond)
+	reserveBalances := keeper.BankKeeper.GetCoins(ctx, bond.ReserveAddress)
+	reserveBalances = zeroReserveTokensIfEmpty(reserveBalances, bond)
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, reserveBalances)
 	if err2 != nil {
 		panic("could not marshal result to JSON")
@@ -239,13 +569,15 @@ func queryBuyPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, e
 		return nil, err
 	}
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
+	fundingTaxes := bond.GetFundingTaxes(reservePrices)
 	txFee := bond.GetTxFees(reservePrices)
 
 	var result types.QueryBuyPrice
 	result.AdjustedSupply = adjustedSupply
 	result.Prices = zeroReserveTokensIfEmpty(reservePricesRounded, bond)
+	result.FundingTaxes = zeroReserveTokensIfEmpty(fundingTaxes, bond)
 	result.TxFees = zeroReserveTokensIfEmpty(txFee, bond)
-	result.TotalPrices = zeroReserveTokensIfEmpty(reservePricesRounded.Add(txFee...), bond)
+	result.TotalPrices = zeroReserveTokensIfEmpty(reservePricesRounded.Add(fundingTaxes...).Add(txFee...), bond)
 	result.TotalFees = zeroReserveTokensIfEmpty(txFee, bond)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
@@ -270,6 +602,15 @@ func querySellReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
 	}
 
+	// Min returns are optional
+	var minReturns sdk.Coins
+	if len(path) > 2 {
+		minReturns, err2 = sdk.ParseCoins(path[2])
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+	}
+
 	if !bond.AllowSells {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, bond.Name)
 	}
@@ -287,14 +628,17 @@ func querySellReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	txFees := bond.GetTxFees(reserveReturns)
 	exitFees := bond.GetExitFees(reserveReturns)
 	totalFees := types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	totalReturns := reserveReturnsRounded.Sub(totalFees)
 
 	var result types.QuerySellReturn
 	result.AdjustedSupply = adjustedSupply
 	result.Returns = zeroReserveTokensIfEmpty(reserveReturnsRounded, bond)
 	result.TxFees = zeroReserveTokensIfEmpty(txFees, bond)
 	result.ExitFees = zeroReserveTokensIfEmpty(exitFees, bond)
-	result.TotalReturns = zeroReserveTokensIfEmpty(reserveReturnsRounded.Sub(totalFees), bond)
+	result.TotalReturns = zeroReserveTokensIfEmpty(totalReturns, bond)
 	result.TotalFees = zeroReserveTokensIfEmpty(totalFees, bond)
+	result.MinReturns = minReturns
+	result.MinReturnsMet = totalReturns.IsAllGTE(minReturns)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
@@ -315,6 +659,15 @@ func querySwapReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
 	}
 
+	// Min output is optional
+	minOutput := sdk.Coin{Denom: toToken, Amount: sdk.ZeroInt()}
+	if len(path) > 4 {
+		minOutput, err2 = client.ParseTwoPartCoin(path[4], toToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+	}
+
 	bond, found := keeper.GetBond(ctx, bondToken)
 	if !found {
 		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
@@ -333,6 +686,190 @@ func querySwapReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	var result types.QuerySwapReturn
 	result.TotalFees = sdk.Coins{txFee}
 	result.TotalReturns = reserveReturns
+	result.MinOutput = minOutput
+	result.MinOutputMet = reserveReturns.AmountOf(toToken).GTE(minOutput.Amount)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySwapRouteReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	fromToken := path[0]
+	fromAmount := path[1]
+	bondTokens := strings.Split(path[2], ",")
+
+	fromCoin, err2 := client.ParseTwoPartCoin(fromAmount, fromToken)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	}
+
+	hopReturns, txFees, err := keeper.GetSwapRouteReturns(ctx, fromCoin, bondTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.QuerySwapRouteReturn
+	result.BondTokens = bondTokens
+	result.HopReturns = hopReturns
+	result.TotalReturns = sdk.Coins{hopReturns[len(hopReturns)-1]}
+	result.TotalFees = txFees
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBestSwapRoute(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	fromToken := path[0]
+	fromAmount := path[1]
+	toToken := path[2]
+
+	fromCoin, err2 := client.ParseTwoPartCoin(fromAmount, fromToken)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	}
+
+	bondTokens, _, err := keeper.FindBestSwapRoute(ctx, fromCoin, toToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quote the route to also get the fees charged along it
+	hopReturns, txFees, err := keeper.GetSwapRouteReturns(ctx, fromCoin, bondTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.QuerySwapRouteReturn
+	result.BondTokens = bondTokens
+	result.HopReturns = hopReturns
+	result.TotalReturns = sdk.Coins{hopReturns[len(hopReturns)-1]}
+	result.TotalFees = txFees
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryAddLiquidityReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+
+	deposit, err2 := sdk.ParseCoins(path[1])
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	}
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
+	}
+
+	// Swap the excess of the deposit, if any, using the current reserves
+	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
+	swap, err := bond.GetLiquidityDepositSwap(deposit, reserveBalances)
+	if err != nil {
+		return nil, err
+	}
+	budget := deposit
+	swapReturns := sdk.Coins{}
+	totalFees := sdk.Coins{}
+	if swap.IsPositive() {
+		toToken, err := bond.GetSwapToToken(swap.Denom)
+		if err != nil {
+			return nil, err
+		}
+		var swapFee sdk.Coin
+		swapReturns, swapFee, err = bond.GetReturnsForSwap(swap, toToken, reserveBalances)
+		if err != nil {
+			return nil, err
+		}
+		reserveBalances = reserveBalances.Add(swap.Sub(swapFee)).Sub(swapReturns)
+		budget = budget.Sub(sdk.Coins{swap}).Add(swapReturns...)
+		totalFees = totalFees.Add(swapFee)
+	}
+
+	// Buy as many bond tokens as possible with the rest of the deposit and
+	// the returns of the swap, using the reserves after the swap
+	amount, reservePrices, err := getBuyWithReserveAmount(bond, reserveBalances, budget)
+	if err != nil {
+		return nil, err
+	}
+	reservePricesRounded := types.RoundReservePrices(reservePrices)
+	txFees := bond.GetTxFees(reservePrices)
+	totalFees = totalFees.Add(txFees...)
+
+	var result types.QueryAddLiquidityReturn
+	result.Swap = swap
+	result.SwapReturns = swapReturns
+	result.Amount = sdk.NewCoin(bondToken, amount)
+	result.Prices = zeroReserveTokensIfEmpty(reservePricesRounded, bond)
+	result.TotalFees = zeroReserveTokensIfEmpty(totalFees, bond)
+	result.Returned = budget.Sub(reservePricesRounded.Add(txFees...))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryRemoveLiquidityReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	bondToken := path[0]
+	bondAmount := path[1]
+	toToken := path[2]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotExist, bondToken)
+	}
+
+	bondCoin, err2 := client.ParseTwoPartCoin(bondAmount, bondToken)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	}
+
+	// Min output is optional
+	minOutput := sdk.Coin{Denom: toToken, Amount: sdk.ZeroInt()}
+	if len(path) > 3 {
+		minOutput, err2 = client.ParseTwoPartCoin(path[3], toToken)
+		if err2 != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+		}
+	}
+
+	if !bond.AllowSells {
+		return nil, sdkerrors.Wrap(types.ErrBondDoesNotAllowSelling, bond.Name)
+	}
+
+	// Cannot burn more tokens than what exists
+	adjustedSupply := keeper.GetSupplyAdjustedForSell(ctx, bondToken)
+	if adjustedSupply.IsLT(bondCoin) {
+		return nil, sdkerrors.Wrap(types.ErrCannotBurnMoreThanSupply, adjustedSupply.String())
+	}
+
+	output, swap, fees, err := keeper.GetRemoveLiquidityOutput(
+		ctx, bondToken, bondCoin.Amount, toToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.QueryRemoveLiquidityReturn
+	result.Swap = swap
+	result.TotalReturns = sdk.Coins{output}
+	result.TotalFees = zeroReserveTokensIfEmpty(fees, bond)
+	result.MinOutput = minOutput
+	result.MinOutputMet = output.Amount.GTE(minOutput.Amount)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
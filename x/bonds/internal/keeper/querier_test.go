@@ -114,6 +114,367 @@ func TestQueryLastBatch(t *testing.T) {
 	require.Equal(t, queryResult, batch)
 }
 
+func TestQueryOrder(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryOrder
+
+	// Initially error since no batch
+	res, err := querier(ctx, []string{keeper.QueryOrder, token, "0"}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add batch with a buy order and move it to the last batch
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	buyID := app.BondsKeeper.AddBuyOrder(ctx, token, getValidBuyOrder(), buyPrices, sellPrices)
+	lastBatch := app.BondsKeeper.MustGetBatch(ctx, token)
+	app.BondsKeeper.SetLastBatch(ctx, token, lastBatch)
+	newBatch := getValidBatch()
+	newBatch.NextOrderID = lastBatch.NextOrderID
+	app.BondsKeeper.SetBatch(ctx, token, newBatch)
+
+	// Add a swap order to the current batch
+	swapID := app.BondsKeeper.AddSwapOrder(ctx, token, getValidSwapOrder())
+
+	// Buy order found in last batch
+	res, err = querier(ctx, []string{keeper.QueryOrder, token, "0"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.AttributeValueBuyOrder, queryResult.OrderType)
+	require.True(t, queryResult.InLastBatch)
+	require.Equal(t, buyID, queryResult.BuyOrder.ID)
+	require.Nil(t, queryResult.SwapOrder)
+
+	// Swap order found in current batch
+	queryResult = types.QueryOrder{}
+	res, err = querier(ctx, []string{keeper.QueryOrder, token, "1"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.AttributeValueSwapOrder, queryResult.OrderType)
+	require.False(t, queryResult.InLastBatch)
+	require.Equal(t, swapID, queryResult.SwapOrder.ID)
+	require.Nil(t, queryResult.BuyOrder)
+
+	// Error for non-existent and invalid order IDs
+	_, err = querier(ctx, []string{keeper.QueryOrder, token, "2"}, req)
+	require.Error(t, err)
+	_, err = querier(ctx, []string{keeper.QueryOrder, token, "abc"}, req)
+	require.Error(t, err)
+}
+
+func TestQueryBatchesHistory(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult []types.SettledBatch
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryBatchesHistory, token, "1", "2"}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond and three settled batches
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	batch := getValidBatch()
+	for height := int64(1); height <= 3; height++ {
+		app.BondsKeeper.SetSettledBatch(ctx, types.NewSettledBatch(height, batch))
+	}
+
+	// First page has two settled batches
+	res, err = querier(ctx, []string{keeper.QueryBatchesHistory, token, "1", "2"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 2)
+	require.Equal(t, int64(1), queryResult[0].Height)
+	require.Equal(t, int64(2), queryResult[1].Height)
+
+	// Second page has one settled batch
+	res, err = querier(ctx, []string{keeper.QueryBatchesHistory, token, "2", "2"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 1)
+	require.Equal(t, int64(3), queryResult[0].Height)
+
+	// Third page is empty
+	res, err = querier(ctx, []string{keeper.QueryBatchesHistory, token, "3", "2"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 0)
+}
+
+func TestQueryCandles(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult []types.Candle
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryCandles, token, "10"}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond; no candles since no settled batches
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	res, err = querier(ctx, []string{keeper.QueryCandles, token, "10"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 0)
+
+	// Add settled batches in two different intervals
+	batch := getValidBatch()
+	batch.TotalBuyAmount = sdk.NewInt64Coin(token, 2)
+	batch.BuyPrices = buyPrices
+	batch.SellPrices = sellPrices
+	for _, height := range []int64{1, 2, 15} {
+		app.BondsKeeper.SetSettledBatch(ctx, types.NewSettledBatch(height, batch))
+	}
+
+	// Two candles
+	res, err = querier(ctx, []string{keeper.QueryCandles, token, "10"}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 2)
+	require.Equal(t, int64(0), queryResult[0].StartHeight)
+	require.Equal(t, sdk.NewInt64Coin(token, 4), queryResult[0].Volume)
+	require.Equal(t, buyPrices, queryResult[0].BuyPrices.High)
+	require.Equal(t, int64(10), queryResult[1].StartHeight)
+	require.Equal(t, sdk.NewInt64Coin(token, 2), queryResult[1].Volume)
+
+	// Error for invalid intervals
+	_, err = querier(ctx, []string{keeper.QueryCandles, token, "0"}, req)
+	require.Error(t, err)
+	_, err = querier(ctx, []string{keeper.QueryCandles, token, "abc"}, req)
+	require.Error(t, err)
+}
+
+func TestQueryPosition(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.Position
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryPosition, token, buyerAddress.String()}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond; empty position since no activity
+	app.BondsKeeper.SetBond(ctx, token, getValidBond())
+	res, err = querier(ctx, []string{keeper.QueryPosition, token, buyerAddress.String()}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, buyerAddress, queryResult.Address)
+	require.True(t, queryResult.TokensBought.IsZero())
+
+	// Add to position
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	position := app.BondsKeeper.GetPosition(ctx, token, buyerAddress)
+	app.BondsKeeper.SetPosition(ctx, position.AddBuy(sdk.NewInt64Coin(token, 2), reserve, nil))
+
+	res, err = querier(ctx, []string{keeper.QueryPosition, token, buyerAddress.String()}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, sdk.NewInt64Coin(token, 2), queryResult.TokensBought)
+	require.Equal(t, reserve, queryResult.ReservePaid)
+
+	// Error for invalid address
+	_, err = querier(ctx, []string{keeper.QueryPosition, token, "abc"}, req)
+	require.Error(t, err)
+}
+
+func TestQueryVesting(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryVesting
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryVesting, token, buyerAddress.String()}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with a vesting cliff of 5 blocks and duration of 10 blocks;
+	// empty vesting since no tokens bought in hatch phase
+	bond := getValidAugmentedFunctionBond()
+	bond.VestingCliff = 5
+	bond.VestingDuration = 10
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	res, err = querier(ctx, []string{keeper.QueryVesting, token, buyerAddress.String()}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, buyerAddress, queryResult.Vesting.Address)
+	require.Empty(t, queryResult.Vesting.Entries)
+	require.True(t, queryResult.Vested.IsZero())
+	require.True(t, queryResult.Unvested.IsZero())
+	require.True(t, queryResult.Withdrawable.IsZero())
+
+	// Add 100 tokens to vesting at height 10, and query at height 20 (5/10
+	// blocks after the cliff has passed, so half of the tokens have vested)
+	app.BondsKeeper.AddVesting(ctx.WithBlockHeight(10), token, buyerAddress,
+		sdk.NewInt64Coin(token, 100))
+
+	res, err = querier(ctx.WithBlockHeight(20), []string{keeper.QueryVesting, token, buyerAddress.String()}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult.Vesting.Entries, 1)
+	require.Equal(t, sdk.NewInt64Coin(token, 50), queryResult.Vested)
+	require.Equal(t, sdk.NewInt64Coin(token, 50), queryResult.Unvested)
+	require.Equal(t, sdk.NewInt64Coin(token, 50), queryResult.Withdrawable)
+
+	// Error for invalid address
+	_, err = querier(ctx, []string{keeper.QueryVesting, token, "abc"}, req)
+	require.Error(t, err)
+}
+
+func TestQueryFundingPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryFundingPool
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Error since bond has no funding pool
+	bond := getValidAugmentedFunctionBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, err = querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrBondHasNoFundingPool.Is(err))
+
+	// Add funding pool with a funding tap of 100res per 10 blocks
+	bond.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	bond.FundingTapBlocks = 10
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	fundingPoolAddress := types.GetFundingPoolAddress(token)
+	balance := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 150))
+	_, err = app.BankKeeper.AddCoins(ctx, fundingPoolAddress, balance)
+	require.Nil(t, err)
+
+	res, err = querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, fundingPoolAddress, queryResult.Address)
+	require.Equal(t, balance, queryResult.Balance)
+	require.Equal(t, bond.FundingTap, queryResult.FundingTap)
+	require.Equal(t, bond.FundingTapBlocks, queryResult.FundingTapBlocks)
+}
+
+func TestQueryNextFundingWithdrawal(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryNextFundingWithdrawal
+	ctx = ctx.WithBlockHeight(5)
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryNextFundingWithdrawal, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with a funding tap of 100res per 10 blocks and a funding pool
+	// balance of 150res
+	bond := getValidAugmentedFunctionBond()
+	bond.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	bond.FundingTapBlocks = 10
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	_, err = app.BankKeeper.AddCoins(ctx, types.GetFundingPoolAddress(token),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 150)))
+	require.Nil(t, err)
+
+	// Full funding tap can be withdrawn at the current height
+	res, err = querier(ctx, []string{keeper.QueryNextFundingWithdrawal, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, int64(5), queryResult.Height)
+	require.Equal(t, bond.FundingTap, queryResult.Amount)
+
+	// After withdrawing the full funding tap, next withdrawal is at the end
+	// of the period (height 15) and is limited by the remaining 50res balance
+	err = app.BondsKeeper.WithdrawFunding(ctx, token, bond.FundingTap)
+	require.Nil(t, err)
+	res, err = querier(ctx, []string{keeper.QueryNextFundingWithdrawal, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, int64(15), queryResult.Height)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)), queryResult.Amount)
+}
+
+func TestQueryLimitOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult []types.LimitOrder
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryLimitOrders, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond and batch; no limit orders
+	bond := getValidBond()
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+	res, err = querier(ctx, []string{keeper.QueryLimitOrders, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult, 0)
+
+	// Add limit order
+	pricesPerToken := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 100))
+	lo := types.NewLimitBuyOrder(bond, buyerAddress, buyAmount, pricesPerToken, 10)
+	app.BondsKeeper.AddLimitOrder(ctx, token, lo)
+
+	res, err = querier(ctx, []string{keeper.QueryLimitOrders, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, []types.LimitOrder{lo}, queryResult)
+}
+
+func TestQueryOutcomePayments(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryOutcomePayments
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryOutcomePayments, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add bond with 100res outcome payment; no contributions
+	outcomePayment := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	bond := getValidBond()
+	bond.OutcomePayment = outcomePayment
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	res, err = querier(ctx, []string{keeper.QueryOutcomePayments, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, outcomePayment, queryResult.OutcomePayment)
+	require.True(t, queryResult.Received.IsZero())
+	require.Equal(t, outcomePayment, queryResult.Remaining)
+	require.Len(t, queryResult.Contributions, 0)
+
+	// Make 30res outcome payment
+	payment := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 30))
+	_, err = app.BankKeeper.AddCoins(ctx, buyerAddress, payment)
+	require.Nil(t, err)
+	err = app.BondsKeeper.MakeOutcomePayment(ctx, token, buyerAddress, payment)
+	require.Nil(t, err)
+
+	res, err = querier(ctx, []string{keeper.QueryOutcomePayments, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, payment, queryResult.Received)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 70)), queryResult.Remaining)
+	require.Equal(t, []types.OutcomePaymentContribution{
+		types.NewOutcomePaymentContribution(token, buyerAddress).Add(payment),
+	}, queryResult.Contributions)
+}
+
 func TestQueryCurrentPrice(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.BondsKeeper)
@@ -405,6 +766,7 @@ func TestQueryBuyPrice(t *testing.T) {
 	require.Equal(t, queryResult.AdjustedSupply, manualSupply)
 	require.Equal(t, queryResult.Prices, roundedPrices)
 	require.Equal(t, queryResult.Prices, manualPrices)
+	require.Equal(t, queryResult.FundingTaxes, sdk.Coins{sdk.NewInt64Coin(reserveToken, 0)})
 	require.Equal(t, queryResult.TxFees, txFees)
 	require.Equal(t, queryResult.TotalFees, txFees)
 	require.Equal(t, queryResult.TotalPrices, roundedTotalPrices)
@@ -448,6 +810,46 @@ func TestQueryBuyPrice(t *testing.T) {
 	require.Equal(t, queryResult.TotalPrices, roundedTotalPrices)
 }
 
+func TestQueryBuyPriceWithFundingTax(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryBuyPrice
+
+	// Add augmented bond in open phase with a 10% funding tax, and batch.
+	// R0, S0, V0 are added as they would be when the bond is created.
+	bond := getValidAugmentedFunctionBond()
+	R0, S0 := sdk.NewDec(300), sdk.NewDec(50000) // R0=d0*(1-theta), S0=d0/p0
+	bond.FunctionParameters = append(bond.FunctionParameters,
+		types.NewFunctionParam("R0", R0),
+		types.NewFunctionParam("S0", S0),
+		types.NewFunctionParam("V0", types.Invariant(R0, S0, 3)))
+	bond.State = types.OpenState
+	bond.FundingTaxPercentage = sdk.NewDec(10)
+	app.BondsKeeper.SetBond(ctx, token, bond)
+	app.BondsKeeper.SetBatch(ctx, token, getValidBatch())
+
+	// Get buy price and funding tax for 1000 tokens directly
+	buyAmount := sdk.NewInt(1000)
+	reserveBalances := app.BondsKeeper.GetReserveBalances(ctx, token)
+	buyPrices, _ := bond.GetPricesToMint(buyAmount, reserveBalances)
+	fundingTaxes := bond.GetFundingTaxes(buyPrices)
+	txFees := bond.GetTxFees(buyPrices)
+	roundedPrices := types.RoundReservePrices(buyPrices)
+	require.True(t, fundingTaxes.IsAllPositive())
+
+	// Check that funding tax is shown separately and included in total prices
+	res, err := querier(ctx,
+		[]string{keeper.QueryBuyPrice, token, buyAmount.String()}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, queryResult.Prices, roundedPrices)
+	require.Equal(t, queryResult.FundingTaxes, fundingTaxes)
+	require.Equal(t, queryResult.TxFees, txFees)
+	require.Equal(t, queryResult.TotalFees, txFees)
+	require.Equal(t, queryResult.TotalPrices, roundedPrices.Add(fundingTaxes...).Add(txFees...))
+}
+
 func TestQuerySellPrice(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.BondsKeeper)
@@ -581,3 +983,126 @@ func TestQuerySwapReturn(t *testing.T) {
 	require.Equal(t, queryResult.TotalReturns, manualSwapReturns)
 	require.Equal(t, queryResult.TotalFees, sdk.Coins{txFee})
 }
+
+func TestQuerySwapRouteReturnAndBestSwapRoute(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+
+	// Initially error since no bonds
+	res, err := querier(ctx, []string{keeper.QueryBestSwapRoute,
+		reserveToken, "100", reserveToken3}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add res/rez and rez/rex swappers
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken2, 1000)))
+	setInitialisedSwapperBond(app, ctx, token2, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 1000), sdk.NewInt64Coin(reserveToken3, 1000)))
+	expected := types.QuerySwapRouteReturn{
+		BondTokens:   []string{token1, token2},
+		HopReturns:   []sdk.Coin{sdk.NewInt64Coin(reserveToken2, 90), sdk.NewInt64Coin(reserveToken3, 81)},
+		TotalReturns: sdk.Coins{sdk.NewInt64Coin(reserveToken3, 81)},
+		TotalFees:    sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1), sdk.NewInt64Coin(reserveToken2, 1)),
+	}
+
+	// Quote route
+	var queryResult types.QuerySwapRouteReturn
+	res, err = querier(ctx, []string{keeper.QuerySwapRouteReturn,
+		reserveToken, "100", token1 + "," + token2}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, expected, queryResult)
+
+	// Best route is the same route
+	var bestResult types.QuerySwapRouteReturn
+	res, err = querier(ctx, []string{keeper.QueryBestSwapRoute,
+		reserveToken, "100", reserveToken3}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &bestResult)
+	require.Equal(t, expected, bestResult)
+}
+
+func TestQueryAddLiquidityReturn(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryAddLiquidityReturn
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryAddLiquidityReturn,
+		token1, "1000" + reserveToken}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add res/rez swapper with a supply of 100
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000), sdk.NewInt64Coin(reserveToken2, 10000)))
+	bond := app.BondsKeeper.MustGetBond(ctx, token1)
+	bond.CurrentSupply = sdk.NewInt64Coin(token1, 100)
+	app.BondsKeeper.SetBond(ctx, token1, bond)
+
+	// The 488res swapped (see TestGetLiquidityDepositSwap) return
+	// 487res*10000rez/(10000res+487res) = 464rez, and the remaining 512res
+	// and the 464rez buy 4 tokens at the reserves after the swap, costing
+	// 4*10487res/100 = 420res and 4*9536rez/100 = 382rez (rounded up) plus
+	// fees of 1 of each
+	res, err = querier(ctx, []string{keeper.QueryAddLiquidityReturn,
+		token1, "1000" + reserveToken}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.QueryAddLiquidityReturn{
+		Swap:        sdk.NewInt64Coin(reserveToken, 488),
+		SwapReturns: sdk.Coins{sdk.NewInt64Coin(reserveToken2, 464)},
+		Amount:      sdk.NewInt64Coin(token1, 4),
+		Prices: sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 420), sdk.NewInt64Coin(reserveToken2, 382)),
+		TotalFees: sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 2), sdk.NewInt64Coin(reserveToken2, 1)),
+		Returned: sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 91), sdk.NewInt64Coin(reserveToken2, 81)),
+	}, queryResult)
+}
+
+func TestQueryRemoveLiquidityReturn(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.BondsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryRemoveLiquidityReturn
+
+	// Initially error since no bond
+	res, err := querier(ctx, []string{keeper.QueryRemoveLiquidityReturn,
+		token1, "10", reserveToken}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add res/rez swapper with a supply of 100
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000), sdk.NewInt64Coin(reserveToken2, 10000)))
+	bond := app.BondsKeeper.MustGetBond(ctx, token1)
+	bond.CurrentSupply = sdk.NewInt64Coin(token1, 100)
+	app.BondsKeeper.SetBond(ctx, token1, bond)
+
+	// Check that returns are as in TestGetRemoveLiquidityOutput and that min
+	// output is met or not met accordingly
+	for _, tc := range []struct {
+		minOutput    string
+		minOutputMet bool
+	}{{"1895", true}, {"1896", false}} {
+		res, err = querier(ctx, []string{keeper.QueryRemoveLiquidityReturn,
+			token1, "10", reserveToken, tc.minOutput}, req)
+		require.NoError(t, err)
+		types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+		require.Equal(t, sdk.NewInt64Coin(reserveToken2, 998), queryResult.Swap)
+		require.Equal(t, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1895)}, queryResult.TotalReturns)
+		require.Equal(t, tc.minOutputMet, queryResult.MinOutputMet)
+	}
+
+	// Error if more than the supply is removed
+	res, err = querier(ctx, []string{keeper.QueryRemoveLiquidityReturn,
+		token1, "101", reserveToken}, req)
+	require.Error(t, err)
+	require.True(t, types.ErrCannotBurnMoreThanSupply.Is(err))
+	require.Nil(t, res)
+}
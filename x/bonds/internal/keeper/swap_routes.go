@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"strconv"
+)
+
+// GetSwapHopToToken checks that a swap of the specified amount can be added to
+// the batch of the specified bond as a hop of a swap route, and returns the
+// token that the swap is to. The bond has to be an OPEN swapper bond that has
+// the from token as one of its reserve tokens, and the amount has to be within
+// the bond's order quantity limits.
+func (k Keeper) GetSwapHopToToken(ctx sdk.Context, token string, from sdk.Coin) (string, error) {
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return "", sdkerrors.Wrap(types.ErrBondDoesNotExist, token)
+	} else if bond.FunctionType != types.SwapperFunction {
+		return "", sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if bond.State != types.OpenState {
+		return "", sdkerrors.Wrap(types.ErrInvalidStateForAction, bond.State)
+	}
+
+	toToken, err := bond.GetSwapToToken(from.Denom)
+	if err != nil {
+		return "", err
+	} else if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{from}) {
+		return "", sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, from.String())
+	}
+	return toToken, nil
+}
+
+// GetSwapRouteReturns returns the returns of each hop of a swap of the from
+// amount through the specified swapper bonds, in order, where the returns of
+// each hop are swapped in the next hop. The total fees charged along the route
+// are also returned. The returns are estimated using the current reserves of
+// each bond, so the actual returns depend on the other swaps in the batches.
+func (k Keeper) GetSwapRouteReturns(ctx sdk.Context, from sdk.Coin,
+	bondTokens []string) (hopReturns []sdk.Coin, txFees sdk.Coins, err error) {
+	if len(bondTokens) == 0 {
+		return nil, nil, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "BondTokens")
+	}
+
+	txFees = sdk.NewCoins()
+	for _, bondToken := range bondTokens {
+		toToken, err := k.GetSwapHopToToken(ctx, bondToken, from)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bond := k.MustGetBond(ctx, bondToken)
+		reserveBalances := k.GetReserveBalances(ctx, bondToken)
+		returns, txFee, err := bond.GetReturnsForSwap(from, toToken, reserveBalances)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		from = sdk.NewCoin(toToken, returns.AmountOf(toToken))
+		hopReturns = append(hopReturns, from)
+		txFees = txFees.Add(txFee)
+	}
+	return hopReturns, txFees, nil
+}
+
+// FindBestSwapRoute returns the route of swapper bonds, of at most
+// MaxSwapRouteHops hops, that gives the largest returns in the to token for a
+// swap of the from amount, together with the returns of each of its hops. Only
+// OPEN swapper bonds with a non-zero supply are considered, and a bond is used
+// at most once in a route. If two routes give the same returns, the one with
+// fewer hops is preferred.
+func (k Keeper) FindBestSwapRoute(ctx sdk.Context, from sdk.Coin,
+	toToken string) (bestRoute []string, bestReturns []sdk.Coin, err error) {
+	var swappers []types.Bond
+	iterator := k.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if bond.FunctionType == types.SwapperFunction &&
+			bond.State == types.OpenState && !bond.CurrentSupply.IsZero() {
+			swappers = append(swappers, bond)
+		}
+	}
+	iterator.Close()
+
+	used := make(map[string]bool)
+	var route []string
+	var returns []sdk.Coin
+
+	var search func(from sdk.Coin)
+	search = func(from sdk.Coin) {
+		for _, bond := range swappers {
+			if used[bond.Token] {
+				continue
+			}
+			hopToToken, err := k.GetSwapHopToToken(ctx, bond.Token, from)
+			if err != nil {
+				continue
+			}
+			reserveBalances := k.GetReserveBalances(ctx, bond.Token)
+			hopReturns, _, err := bond.GetReturnsForSwap(from, hopToToken, reserveBalances)
+			if err != nil {
+				continue
+			}
+			hopReturn := sdk.NewCoin(hopToToken, hopReturns.AmountOf(hopToToken))
+
+			used[bond.Token] = true
+			route = append(route, bond.Token)
+			returns = append(returns, hopReturn)
+
+			if hopToToken == toToken {
+				best := len(bestRoute) == 0
+				if !best {
+					bestReturn := bestReturns[len(bestReturns)-1]
+					best = hopReturn.Amount.GT(bestReturn.Amount) ||
+						(hopReturn.Amount.Equal(bestReturn.Amount) && len(route) < len(bestRoute))
+				}
+				if best {
+					bestRoute = append([]string{}, route...)
+					bestReturns = append([]sdk.Coin{}, returns...)
+				}
+			} else if len(route) < types.MaxSwapRouteHops {
+				search(hopReturn)
+			}
+
+			used[bond.Token] = false
+			route = route[:len(route)-1]
+			returns = returns[:len(returns)-1]
+		}
+	}
+	search(from)
+
+	if len(bestRoute) == 0 {
+		return nil, nil, sdkerrors.Wrapf(types.ErrNoSwapRouteFound,
+			"from %s to %s", from.String(), toToken)
+	}
+	return bestRoute, bestReturns, nil
+}
+
+// performSwapRouteHop moves the returns of a swap order that is part of a swap
+// route from the bond's reserve to the batches intermediary account, and adds
+// a swap order for the returns to the batch of the next bond in the route.
+func (k Keeper) performSwapRouteHop(ctx sdk.Context, token string,
+	so types.SwapOrder, reserveReturns sdk.Coins) error {
+	nextBond := so.NextHops[0]
+	from := sdk.NewCoin(so.ToToken, reserveReturns.AmountOf(so.ToToken))
+	toToken, err := k.GetSwapHopToToken(ctx, nextBond, from)
+	if err != nil {
+		return err
+	}
+
+	moduleAddr := k.SupplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount)
+	err = k.WithdrawReserve(ctx, token, moduleAddr, reserveReturns)
+	if err != nil {
+		return err
+	}
+
+	order := types.NewSwapRouteOrder(so.Address, from, toToken, so.MinOutput, so.NextHops[1:])
+	orderID := k.AddSwapOrder(ctx, nextBond, order)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("swap route of %s continues from %s to %s with order %d",
+		so.Address.String(), token, nextBond, orderID))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSwapRouteHop,
+		sdk.NewAttribute(types.AttributeKeyBond, nextBond),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(orderID, 10)),
+		sdk.NewAttribute(types.AttributeKeyPreviousBond, token),
+		sdk.NewAttribute(types.AttributeKeyPreviousOrderID, strconv.FormatUint(so.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, from.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeySwapFromToken, from.Denom),
+		sdk.NewAttribute(types.AttributeKeySwapToToken, toToken),
+		sdk.NewAttribute(types.AttributeKeyMinOutput, so.MinOutput.String()),
+	))
+
+	return nil
+}
+
+// checkSwapOutput checks that the returns of a swap order meet the order's
+// minimum output. For a swap order that is part of a swap route, the output
+// is estimated by swapping the returns through the remaining hops, using the
+// current reserves of the remaining bonds. An error is also returned if any of
+// the remaining hops can no longer be performed.
+func (k Keeper) checkSwapOutput(ctx sdk.Context, so types.SwapOrder, returns sdk.Coin) error {
+	output := returns
+	if so.IsRouted() {
+		hopReturns, _, err := k.GetSwapRouteReturns(ctx, returns, so.NextHops)
+		if err != nil {
+			return err
+		}
+		output = hopReturns[len(hopReturns)-1]
+	}
+
+	if output.Amount.LT(so.MinOutput.Amount) {
+		return sdkerrors.Wrapf(types.ErrMinOutputNotMet,
+			"Actual output %s does not meet min output %s", output, so.MinOutput)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simapp "github.com/ixoworld/bonds/x/bonds/app"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const reserveToken3 = "rex"
+
+// setInitialisedSwapperBond adds a swapper bond with the specified reserve
+// balances (and a non-zero supply) to the store.
+func setInitialisedSwapperBond(app *simapp.SimApp, ctx sdk.Context, bondToken string, reserve sdk.Coins) {
+	bond := getValidSwapperBond()
+	bond.Token = bondToken
+	bond.ReserveAddress = types.GetReserveAddress(bondToken)
+	bond.ReserveTokens = []string{reserve[0].Denom, reserve[1].Denom}
+	bond.CurrentSupply = sdk.NewInt64Coin(bondToken, 1)
+	app.BondsKeeper.SetBond(ctx, bondToken, bond)
+	app.BondsKeeper.SetBatch(ctx, bondToken, types.NewBatch(bondToken, batchBlocks))
+
+	_ = app.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, reserve)
+	_ = app.BondsKeeper.DepositReserveFromModule(
+		ctx, bondToken, types.BondsMintBurnAccount, reserve)
+}
+
+func TestGetSwapRouteReturns(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// res/rez and rez/rex swappers
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken2, 1000)))
+	setInitialisedSwapperBond(app, ctx, token2, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 1000), sdk.NewInt64Coin(reserveToken3, 1000)))
+
+	// Fee of 0.1% is rounded up to 1 at each hop:
+	// - hop 1: 99res*1000rez/(1000res+99res) = 90rez
+	// - hop 2: 89rez*1000rex/(1000rez+89rez) = 81rex
+	from := sdk.NewInt64Coin(reserveToken, 100)
+	hopReturns, txFees, err := app.BondsKeeper.GetSwapRouteReturns(
+		ctx, from, []string{token1, token2})
+	require.Nil(t, err)
+	require.Equal(t, []sdk.Coin{
+		sdk.NewInt64Coin(reserveToken2, 90),
+		sdk.NewInt64Coin(reserveToken3, 81),
+	}, hopReturns)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1),
+		sdk.NewInt64Coin(reserveToken2, 1),
+	), txFees)
+
+	// Single hop gives the same returns as a plain swap
+	hopReturns, _, err = app.BondsKeeper.GetSwapRouteReturns(
+		ctx, from, []string{token1})
+	require.Nil(t, err)
+	require.Equal(t, []sdk.Coin{sdk.NewInt64Coin(reserveToken2, 90)}, hopReturns)
+}
+
+func TestGetSwapRouteReturnsInvalidRouteFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken2, 1000)))
+	setInitialisedSwapperBond(app, ctx, token2, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 1000), sdk.NewInt64Coin(reserveToken3, 1000)))
+	from := sdk.NewInt64Coin(reserveToken, 100)
+
+	// Hops in the wrong order
+	_, _, err := app.BondsKeeper.GetSwapRouteReturns(ctx, from, []string{token2, token1})
+	require.Error(t, err)
+	require.True(t, types.ErrTokenIsNotAValidReserveToken.Is(err))
+
+	// Bond does not exist
+	_, _, err = app.BondsKeeper.GetSwapRouteReturns(ctx, from, []string{token1, token3})
+	require.Error(t, err)
+	require.True(t, types.ErrBondDoesNotExist.Is(err))
+
+	// Bond is not OPEN
+	bond := app.BondsKeeper.MustGetBond(ctx, token2)
+	bond.State = types.PausedState
+	app.BondsKeeper.SetBond(ctx, token2, bond)
+	_, _, err = app.BondsKeeper.GetSwapRouteReturns(ctx, from, []string{token1, token2})
+	require.Error(t, err)
+	require.True(t, types.ErrInvalidStateForAction.Is(err))
+
+	// Empty route
+	_, _, err = app.BondsKeeper.GetSwapRouteReturns(ctx, from, nil)
+	require.Error(t, err)
+}
+
+func TestFindBestSwapRoute(t *testing.T) {
+	testCases := []struct {
+		directRexReserve int64
+		expectedRoute    []string
+		expectedReturn   int64
+	}{
+		// 99res*500rex/(1000res+99res) = 45rex < 81rex through two hops
+		{500, []string{token1, token2}, 81},
+		// 99res*2000rex/(1000res+99res) = 180rex > 81rex through two hops
+		{2000, []string{token3}, 180},
+	}
+	for _, tc := range testCases {
+		app, ctx := createTestApp(false)
+
+		setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken2, 1000)))
+		setInitialisedSwapperBond(app, ctx, token2, sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken2, 1000), sdk.NewInt64Coin(reserveToken3, 1000)))
+		setInitialisedSwapperBond(app, ctx, token3, sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken3, tc.directRexReserve)))
+
+		from := sdk.NewInt64Coin(reserveToken, 100)
+		route, hopReturns, err := app.BondsKeeper.FindBestSwapRoute(ctx, from, reserveToken3)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedRoute, route)
+		require.Equal(t, sdk.NewInt64Coin(reserveToken3, tc.expectedReturn),
+			hopReturns[len(hopReturns)-1])
+	}
+}
+
+func TestFindBestSwapRouteNoRouteFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	setInitialisedSwapperBond(app, ctx, token1, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000), sdk.NewInt64Coin(reserveToken2, 1000)))
+	from := sdk.NewInt64Coin(reserveToken, 100)
+
+	// No bond has rex as a reserve token
+	_, _, err := app.BondsKeeper.FindBestSwapRoute(ctx, from, reserveToken3)
+	require.Error(t, err)
+	require.True(t, types.ErrNoSwapRouteFound.Is(err))
+
+	// Uninitialised swapper bonds are not considered
+	bond := app.BondsKeeper.MustGetBond(ctx, token1)
+	bond.CurrentSupply = sdk.NewInt64Coin(token1, 0)
+	app.BondsKeeper.SetBond(ctx, token1, bond)
+	_, _, err = app.BondsKeeper.FindBestSwapRoute(ctx, from, reserveToken2)
+	require.Error(t, err)
+	require.True(t, types.ErrNoSwapRouteFound.Is(err))
+}
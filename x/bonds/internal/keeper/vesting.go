@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+func (k Keeper) GetVestingsIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetVestingsPrefix(token))
+}
+
+func (k Keeper) GetVestings(ctx sdk.Context, token string) (vestings []types.Vesting) {
+	iterator := k.GetVestingsIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var vesting types.Vesting
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &vesting)
+		vestings = append(vestings, vesting)
+	}
+	return vestings
+}
+
+// GetVesting returns the vesting of the address in the bond, or a new empty
+// vesting if the address has not bought any bond tokens in the hatch phase.
+func (k Keeper) GetVesting(ctx sdk.Context, token string, address sdk.AccAddress) types.Vesting {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVestingKey(token, address))
+	if bz == nil {
+		return types.NewVesting(token, address)
+	}
+
+	var vesting types.Vesting
+	k.cdc.MustUnmarshalBinaryBare(bz, &vesting)
+	return vesting
+}
+
+func (k Keeper) SetVesting(ctx sdk.Context, vesting types.Vesting) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetVestingKey(vesting.BondToken, vesting.Address)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(vesting))
+}
+
+// AddVesting locks bond tokens bought by the address in the hatch phase of the
+// bond, such that these vest starting from the current block height. The bond
+// tokens are expected to be held at the bond's vesting escrow address.
+func (k Keeper) AddVesting(ctx sdk.Context, token string, address sdk.AccAddress, amount sdk.Coin) {
+	vesting := k.GetVesting(ctx, token, address)
+	k.SetVesting(ctx, vesting.AddEntry(ctx.BlockHeight(), amount))
+}
+
+// GetUnvested returns the amount of bond tokens of the address that have not
+// yet vested at the current block height.
+func (k Keeper) GetUnvested(ctx sdk.Context, token string, address sdk.AccAddress) sdk.Coin {
+	bond := k.MustGetBond(ctx, token)
+	vesting := k.GetVesting(ctx, token, address)
+	return vesting.GetUnvested(
+		ctx.BlockHeight(), bond.VestingCliff, bond.VestingDuration)
+}
+
+// GetWithdrawableVested returns the amount of bond tokens of the address held
+// in the bond's vesting escrow that have vested but not yet been withdrawn. If
+// the bond has settled or failed, all of the remaining tokens are withdrawable,
+// so that these can be used to withdraw a share of the reserve.
+func (k Keeper) GetWithdrawableVested(ctx sdk.Context, token string, address sdk.AccAddress) sdk.Coin {
+	bond := k.MustGetBond(ctx, token)
+	vesting := k.GetVesting(ctx, token, address)
+	if bond.State == types.SettleState || bond.State == types.FailedState {
+		return vesting.GetTotal().Sub(vesting.Withdrawn)
+	}
+	return vesting.GetWithdrawable(
+		ctx.BlockHeight(), bond.VestingCliff, bond.VestingDuration)
+}
+
+// WithdrawVested sends the bond tokens of the address that have vested but not
+// yet been withdrawn from the bond's vesting escrow to the address, and returns
+// the amount withdrawn, which is zero if there was nothing to withdraw.
+func (k Keeper) WithdrawVested(ctx sdk.Context, token string, address sdk.AccAddress) (sdk.Coin, error) {
+	amount := k.GetWithdrawableVested(ctx, token, address)
+	if amount.IsZero() {
+		return amount, nil
+	}
+
+	err := k.BankKeeper.SendCoins(ctx,
+		types.GetVestingEscrowAddress(token), address, sdk.Coins{amount})
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	vesting := k.GetVesting(ctx, token, address)
+	k.SetVesting(ctx, vesting.AddWithdrawal(amount))
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("withdrew %s vested bond tokens for %s",
+		amount.String(), address.String()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeWithdrawVested,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyAddress, address.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+	))
+
+	return amount, nil
+}
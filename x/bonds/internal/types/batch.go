@@ -5,21 +5,35 @@ import (
 )
 
 type Batch struct {
-	Token           string       `json:"token" yaml:"token"`
-	BlocksRemaining sdk.Uint     `json:"blocks_remaining" yaml:"blocks_remaining"`
-	TotalBuyAmount  sdk.Coin     `json:"total_buy_amount" yaml:"total_buy_amount"`
-	TotalSellAmount sdk.Coin     `json:"total_sell_amount" yaml:"total_sell_amount"`
-	BuyPrices       sdk.DecCoins `json:"buy_prices" yaml:"buy_prices"`
-	SellPrices      sdk.DecCoins `json:"sell_prices" yaml:"sell_prices"`
-	Buys            []BuyOrder   `json:"buys" yaml:"buys"`
-	Sells           []SellOrder  `json:"sells" yaml:"sells"`
-	Swaps           []SwapOrder  `json:"swaps" yaml:"swaps"`
+	Token           string                 `json:"token" yaml:"token"`
+	BlocksRemaining sdk.Uint               `json:"blocks_remaining" yaml:"blocks_remaining"`
+	TotalBuyAmount  sdk.Coin               `json:"total_buy_amount" yaml:"total_buy_amount"`
+	TotalSellAmount sdk.Coin               `json:"total_sell_amount" yaml:"total_sell_amount"`
+	BuyPrices       sdk.DecCoins           `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins           `json:"sell_prices" yaml:"sell_prices"`
+	Buys            []BuyOrder             `json:"buys" yaml:"buys"`
+	Sells           []SellOrder            `json:"sells" yaml:"sells"`
+	Swaps           []SwapOrder            `json:"swaps" yaml:"swaps"`
+	BuysWithReserve []BuyWithReserveOrder  `json:"buys_with_reserve" yaml:"buys_with_reserve"`
+	NextOrderID     uint64                 `json:"next_order_id" yaml:"next_order_id"`
+	ExecutionHeight int64                  `json:"execution_height" yaml:"execution_height"`
+	AddLiquidity    []AddLiquidityOrder    `json:"add_liquidity" yaml:"add_liquidity"`
+	RemoveLiquidity []RemoveLiquidityOrder `json:"remove_liquidity" yaml:"remove_liquidity"`
 }
 
 func (b Batch) MoreBuysThanSells() bool { return b.TotalSellAmount.IsLT(b.TotalBuyAmount) }
 func (b Batch) MoreSellsThanBuys() bool { return b.TotalBuyAmount.IsLT(b.TotalSellAmount) }
 func (b Batch) EqualBuysAndSells() bool { return b.TotalBuyAmount.IsEqual(b.TotalSellAmount) }
 
+// HasOrders returns true if the batch has any orders, including cancelled ones.
+func (b Batch) HasOrders() bool {
+	return len(b.Buys) != 0 || len(b.Sells) != 0 ||
+		len(b.Swaps) != 0 || len(b.BuysWithReserve) != 0 ||
+		len(b.AddLiquidity) != 0 || len(b.RemoveLiquidity) != 0
+}
+
+// NewBatch creates a new empty batch. The ID of the next order is zero, so it
+// should be carried over from the previous batch when replacing a batch.
 func NewBatch(token string, blocks sdk.Uint) Batch {
 	return Batch{
 		Token:           token,
@@ -29,7 +43,65 @@ func NewBatch(token string, blocks sdk.Uint) Batch {
 	}
 }
 
+// AllocateSupply splits the supply between the requested amounts, which are
+// expected to be in order of arrival. A FirstComeAllocation fills the amounts
+// in order until the supply runs out. A ProRataAllocation gives each amount
+// its (rounded down) share of the supply, with any supply left over from the
+// rounding allocated one token per amount in order of arrival. If the amounts
+// add up to no more than the supply, they are allocated in full.
+func AllocateSupply(allocation string, requested []sdk.Int, supply sdk.Int) []sdk.Int {
+	allocated := make([]sdk.Int, len(requested))
+
+	total := sdk.ZeroInt()
+	for _, r := range requested {
+		total = total.Add(r)
+	}
+
+	remaining := supply
+	for i, r := range requested {
+		if allocation == ProRataAllocation && total.GT(supply) {
+			allocated[i] = r.Mul(supply).Quo(total)
+		} else {
+			allocated[i] = sdk.ZeroInt()
+		}
+		remaining = remaining.Sub(allocated[i])
+	}
+
+	// Allocate any remaining supply in order of arrival. For pro-rata, this is
+	// less than one token per amount, since it is only left over from rounding
+	for i, r := range requested {
+		if !remaining.IsPositive() {
+			break
+		}
+		toAllocate := sdk.MinInt(r.Sub(allocated[i]), remaining)
+		if allocation == ProRataAllocation && total.GT(supply) {
+			toAllocate = sdk.MinInt(toAllocate, sdk.OneInt())
+		}
+		allocated[i] = allocated[i].Add(toAllocate)
+		remaining = remaining.Sub(toAllocate)
+	}
+
+	return allocated
+}
+
+// SettledBatch is a batch that was settled (i.e. whose orders were performed)
+// at the specified block height, as stored in the batches history of a bond.
+type SettledBatch struct {
+	Height int64 `json:"height" yaml:"height"`
+	Batch  Batch `json:"batch" yaml:"batch"`
+}
+
+func NewSettledBatch(height int64, batch Batch) SettledBatch {
+	return SettledBatch{
+		Height: height,
+		Batch:  batch,
+	}
+}
+
+// The ID of an order is assigned when the order is added to a batch and is
+// unique amongst all of the orders of a bond.
 type BaseOrder struct {
+	ID           uint64         `json:"id" yaml:"id"`
 	Address      sdk.AccAddress `json:"address" yaml:"address"`
 	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
 	Cancelled    bool           `json:"cancelled" yaml:"cancelled"`
@@ -49,36 +121,156 @@ func (bo BaseOrder) IsCancelled() bool {
 	return bo.Cancelled == true
 }
 
+// If AllowPartial is true, rather than cancelling the BuyOrder when its max
+// prices are exceeded, its Amount is reduced to the largest amount that fits
+// the max prices. The Amount can also be reduced if the bond runs out of supply.
+// The RequestedAmount keeps track of the original amount.
 type BuyOrder struct {
 	BaseOrder
-	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	MaxPrices       sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	AllowPartial    bool      `json:"allow_partial" yaml:"allow_partial"`
+	RequestedAmount sdk.Coin  `json:"requested_amount" yaml:"requested_amount"`
 }
 
 func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
 	return BuyOrder{
-		BaseOrder: NewBaseOrder(address, amount),
-		MaxPrices: maxPrices,
+		BaseOrder:       NewBaseOrder(address, amount),
+		MaxPrices:       maxPrices,
+		AllowPartial:    false,
+		RequestedAmount: amount,
+	}
+}
+
+func NewPartialBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
+	order := NewBuyOrder(address, amount, maxPrices)
+	order.AllowPartial = true
+	return order
+}
+
+// GetFillRatio returns the fraction of the requested amount that is currently
+// set to be filled, which is less than one only for partially filled orders.
+func (bo BuyOrder) GetFillRatio() sdk.Dec {
+	if !bo.RequestedAmount.IsValid() || !bo.RequestedAmount.IsPositive() {
+		return sdk.OneDec()
+	}
+	return bo.Amount.Amount.ToDec().Quo(bo.RequestedAmount.Amount.ToDec())
+}
+
+// The Amount of a BuyWithReserveOrder is the amount of bond tokens bought,
+// which is only known once the order is performed and is otherwise zero.
+type BuyWithReserveOrder struct {
+	BaseOrder
+	Budget sdk.Coins `json:"budget" yaml:"budget"`
+}
+
+func NewBuyWithReserveOrder(address sdk.AccAddress, bondToken string, budget sdk.Coins) BuyWithReserveOrder {
+	return BuyWithReserveOrder{
+		BaseOrder: NewBaseOrder(address, sdk.NewInt64Coin(bondToken, 0)),
+		Budget:    budget,
 	}
 }
 
 type SellOrder struct {
 	BaseOrder
+	MinReturns sdk.Coins `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewSellOrder(address sdk.AccAddress, amount sdk.Coin) SellOrder {
+func NewSellOrder(address sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins) SellOrder {
 	return SellOrder{
-		BaseOrder: NewBaseOrder(address, amount),
+		BaseOrder:  NewBaseOrder(address, amount),
+		MinReturns: minReturns,
 	}
 }
 
+// A SwapOrder that is part of a swap route has the swapper bonds through which
+// its returns are swapped next as its NextHops. The returns of such a swap are
+// not given to the swapper but are added as a swap order to the next bond's
+// batch, and its MinOutput is the minimum output of the whole route.
+//
+// A SwapOrder that swaps the excess of the deposit of an AddLiquidityOrder has
+// ForAddLiquidity set and the ID of the AddLiquidityOrder as LiquidityOrderID.
+// Its returns (or its amount, if it is cancelled) are added to the budget of
+// the AddLiquidityOrder rather than given to the swapper.
 type SwapOrder struct {
 	BaseOrder
-	ToToken string `json:"to_token" yaml:"to_token"`
+	ToToken          string   `json:"to_token" yaml:"to_token"`
+	MinOutput        sdk.Coin `json:"min_output" yaml:"min_output"`
+	NextHops         []string `json:"next_hops,omitempty" yaml:"next_hops,omitempty"`
+	ForAddLiquidity  bool     `json:"for_add_liquidity,omitempty" yaml:"for_add_liquidity,omitempty"`
+	LiquidityOrderID uint64   `json:"liquidity_order_id,omitempty" yaml:"liquidity_order_id,omitempty"`
 }
 
-func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string) SwapOrder {
+func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string, minOutput sdk.Coin) SwapOrder {
 	return SwapOrder{
 		BaseOrder: NewBaseOrder(address, from),
 		ToToken:   toToken,
+		MinOutput: minOutput,
+	}
+}
+
+func NewSwapRouteOrder(address sdk.AccAddress, from sdk.Coin, toToken string,
+	minOutput sdk.Coin, nextHops []string) SwapOrder {
+	so := NewSwapOrder(address, from, toToken, minOutput)
+	so.NextHops = nextHops
+	return so
+}
+
+// NewAddLiquiditySwapOrder creates a swap order for the excess of the deposit
+// of the add liquidity order with the specified ID. There is no minimum output,
+// given that the add liquidity order has its own minimum amount.
+func NewAddLiquiditySwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string,
+	liquidityOrderID uint64) SwapOrder {
+	so := NewSwapOrder(address, from, toToken, sdk.NewInt64Coin(toToken, 0))
+	so.ForAddLiquidity = true
+	so.LiquidityOrderID = liquidityOrderID
+	return so
+}
+
+// IsRouted returns true if the swap order's returns are to be swapped through
+// further swapper bonds rather than given to the swapper.
+func (so SwapOrder) IsRouted() bool {
+	return len(so.NextHops) != 0
+}
+
+// The Amount of an AddLiquidityOrder is the amount of bond tokens minted, which
+// is only known once the order is performed and is otherwise zero. The Deposit
+// can be in any ratio of the swapper's reserve tokens. Any excess of one of the
+// reserve tokens is swapped for the other in the batch's swaps, and the Budget
+// (i.e. the rest of the deposit plus the returns of the swap) is then used to
+// buy bond tokens, with any remainder being returned to the provider. The
+// Budget is initially the whole deposit, and is what is held for the order.
+type AddLiquidityOrder struct {
+	BaseOrder
+	Deposit   sdk.Coins `json:"deposit" yaml:"deposit"`
+	MinAmount sdk.Coin  `json:"min_amount" yaml:"min_amount"`
+	Budget    sdk.Coins `json:"budget" yaml:"budget"`
+}
+
+func NewAddLiquidityOrder(address sdk.AccAddress, deposit sdk.Coins, minAmount sdk.Coin) AddLiquidityOrder {
+	return AddLiquidityOrder{
+		BaseOrder: NewBaseOrder(address, sdk.NewInt64Coin(minAmount.Denom, 0)),
+		Deposit:   deposit,
+		MinAmount: minAmount,
+		Budget:    deposit,
+	}
+}
+
+// The Amount of a RemoveLiquidityOrder is the amount of bond tokens burned. The
+// returns of the burn in the reserve token other than the ToToken are swapped
+// for the ToToken directly against the reserve when the order is performed, and
+// the MinOutput is the minimum total amount of the ToToken returned to the
+// provider.
+type RemoveLiquidityOrder struct {
+	BaseOrder
+	ToToken   string   `json:"to_token" yaml:"to_token"`
+	MinOutput sdk.Coin `json:"min_output" yaml:"min_output"`
+}
+
+func NewRemoveLiquidityOrder(address sdk.AccAddress, amount sdk.Coin, toToken string,
+	minOutput sdk.Coin) RemoveLiquidityOrder {
+	return RemoveLiquidityOrder{
+		BaseOrder: NewBaseOrder(address, amount),
+		ToToken:   toToken,
+		MinOutput: minOutput,
 	}
 }
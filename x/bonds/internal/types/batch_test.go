@@ -88,15 +88,79 @@ func TestNewBuyOrderDefaultValues(t *testing.T) {
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
 	require.Equal(t, maxPrices, order.MaxPrices)
+	require.False(t, order.AllowPartial)
+	require.Equal(t, amount1, order.RequestedAmount)
+	require.Equal(t, sdk.OneDec(), order.GetFillRatio())
+}
+
+func TestBuyOrderGetFillRatio(t *testing.T) {
+	order := NewPartialBuyOrder(sdk.AccAddress{}, sdk.NewInt64Coin("token", 8), nil)
+	require.True(t, order.AllowPartial)
+	require.Equal(t, sdk.OneDec(), order.GetFillRatio())
+
+	order.Amount = sdk.NewInt64Coin("token", 2)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), order.GetFillRatio())
+
+	// Orders stored before the requested amount was recorded count as filled
+	order.RequestedAmount = sdk.Coin{}
+	require.Equal(t, sdk.OneDec(), order.GetFillRatio())
+}
+
+func TestAllocateSupply(t *testing.T) {
+	ints := func(values ...int64) []sdk.Int {
+		result := make([]sdk.Int, len(values))
+		for i, v := range values {
+			result[i] = sdk.NewInt(v)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		allocation string
+		requested  []sdk.Int
+		supply     int64
+		expected   []sdk.Int
+	}{
+		{FirstComeAllocation, ints(50, 30, 40), 100, ints(50, 30, 20)},
+		{FirstComeAllocation, ints(150, 30, 40), 100, ints(100, 0, 0)},
+		{FirstComeAllocation, ints(50, 30), 100, ints(50, 30)},
+		{ProRataAllocation, ints(100, 300), 100, ints(25, 75)},
+		{ProRataAllocation, ints(50, 30, 40), 100, ints(42, 25, 33)},     // 41.6, 25, 33.3 (+1 to first)
+		{ProRataAllocation, ints(10, 10, 10), 20, ints(7, 7, 6)},         // 6.6, 6.6, 6.6 (+1 to first two)
+		{ProRataAllocation, ints(1, 1, 1, 1, 1), 2, ints(1, 1, 0, 0, 0)}, // 0.4 each (+1 to first two)
+		{ProRataAllocation, ints(50, 30), 100, ints(50, 30)},
+	}
+	for i, tc := range testCases {
+		allocated := AllocateSupply(tc.allocation, tc.requested, sdk.NewInt(tc.supply))
+		require.Len(t, allocated, len(tc.expected), "test case #%d", i)
+		for j := range tc.expected {
+			require.True(t, tc.expected[j].Equal(allocated[j]),
+				"test case #%d: expected %s, got %s", i, tc.expected, allocated)
+		}
+	}
+}
+
+func TestNewBuyWithReserveOrderDefaultValues(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	budget := sdk.NewCoins(sdk.NewInt64Coin("res", 1000))
+	order := NewBuyWithReserveOrder(address, "token", budget)
+
+	require.Equal(t, address, order.Address)
+	require.Equal(t, sdk.NewInt64Coin("token", 0), order.Amount)
+	require.False(t, order.Cancelled)
+	require.Empty(t, order.CancelReason)
+	require.Equal(t, budget, order.Budget)
 }
 
 func TestNewSellOrderDefaultValues(t *testing.T) {
 	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount := sdk.NewInt64Coin("token", 1000)
-	order := NewSellOrder(address, amount)
+	minReturns := sdk.NewCoins(sdk.NewInt64Coin("res", 900))
+	order := NewSellOrder(address, amount, minReturns)
 
 	require.Equal(t, address, order.Address)
 	require.Equal(t, amount, order.Amount)
+	require.Equal(t, minReturns, order.MinReturns)
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
 }
@@ -105,11 +169,13 @@ func TestNewSwapOrderDefaultValues(t *testing.T) {
 	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	fromAmount := sdk.NewInt64Coin("token1", 1000)
 	toToken := "token2"
-	order := NewSwapOrder(address, fromAmount, toToken)
+	minOutput := sdk.NewInt64Coin(toToken, 900)
+	order := NewSwapOrder(address, fromAmount, toToken, minOutput)
 
 	require.Equal(t, address, order.Address)
 	require.Equal(t, fromAmount, order.Amount)
 	require.Equal(t, toToken, order.ToToken)
+	require.Equal(t, minOutput, order.MinOutput)
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
 }
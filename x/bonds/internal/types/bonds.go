@@ -17,10 +17,21 @@ const (
 	HatchState  = "HATCH"
 	OpenState   = "OPEN"
 	SettleState = "SETTLE"
+	PausedState = "PAUSED"
+	FailedState = "FAILED"
+
+	PausedBySigners    = "signers"
+	PausedByGovernance = "governance"
+
+	NoAllocation        = "none"
+	ProRataAllocation   = "pro_rata"
+	FirstComeAllocation = "first_come"
 
 	DoNotModifyField = "[do-not-modify]"
 
 	AnyNumberOfReserveTokens = -1
+
+	MaxSwapRouteHops = 4
 )
 
 type FunctionParamRestrictions func(paramsMap map[string]sdk.Dec) error
@@ -116,6 +127,19 @@ func (fps FunctionParams) AsMap() (paramsMap map[string]sdk.Dec) {
 	return paramsMap
 }
 
+// ReplaceValues returns a copy of the function parameters in which the value
+// of each parameter that is present in the specified map is replaced.
+func (fps FunctionParams) ReplaceValues(values map[string]sdk.Dec) FunctionParams {
+	result := make(FunctionParams, len(fps))
+	for i, fp := range fps {
+		if value, ok := values[fp.Param]; ok {
+			fp.Value = value
+		}
+		result[i] = fp
+	}
+	return result
+}
+
 func powerParameterRestrictions(paramsMap map[string]sdk.Dec) error {
 	// Power exception 1: n must be an integer, otherwise x^n loop does not work
 	val, ok := paramsMap["n"]
@@ -192,24 +216,41 @@ type Bond struct {
 	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
 	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
+	MaxSupplyAllocation    string           `json:"max_supply_allocation" yaml:"max_supply_allocation"`
 	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
 	CurrentSupply          sdk.Coin         `json:"current_supply" yaml:"current_supply"`
 	CurrentReserve         sdk.Coins        `json:"current_reserve" yaml:"current_reserve"`
+	ReserveAddress         sdk.AccAddress   `json:"reserve_address" yaml:"reserve_address"`
 	AllowSells             bool             `json:"allow_sells" yaml:"allow_sells"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	OutcomePaymentReceived sdk.Coins        `json:"outcome_payment_received" yaml:"outcome_payment_received"`
+	HatchDeadline          int64            `json:"hatch_deadline" yaml:"hatch_deadline"`
+	MinRaise               sdk.Coins        `json:"min_raise" yaml:"min_raise"`
+	VestingCliff           int64            `json:"vesting_cliff" yaml:"vesting_cliff"`
+	VestingDuration        int64            `json:"vesting_duration" yaml:"vesting_duration"`
+	FundingTap             sdk.Coins        `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks       int64            `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+	FundingPeriodStart     int64            `json:"funding_period_start" yaml:"funding_period_start"`
+	FundingWithdrawn       sdk.Coins        `json:"funding_withdrawn" yaml:"funding_withdrawn"`
+	FundingTaxPercentage   sdk.Dec          `json:"funding_tax_percentage" yaml:"funding_tax_percentage"`
 	State                  string           `json:"state" yaml:"state"`
+	StateBeforePause       string           `json:"state_before_pause" yaml:"state_before_pause"`
+	PausedBy               string           `json:"paused_by" yaml:"paused_by"`
 }
 
 func NewBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress,
-	maxSupply sdk.Coin, orderQuantityLimits sdk.Coins, sanityRate,
-	sanityMarginPercentage sdk.Dec, allowSells bool, signers []sdk.AccAddress,
-	batchBlocks sdk.Uint, outcomePayment sdk.Coins, state string) Bond {
+	maxSupply sdk.Coin, maxSupplyAllocation string, orderQuantityLimits sdk.Coins,
+	sanityRate, sanityMarginPercentage sdk.Dec, allowSells bool,
+	signers []sdk.AccAddress, batchBlocks sdk.Uint, outcomePayment sdk.Coins,
+	hatchDeadline int64, minRaise sdk.Coins, vestingCliff, vestingDuration int64,
+	fundingTap sdk.Coins, fundingTapBlocks int64, fundingTaxPercentage sdk.Dec,
+	state string) Bond {
 
 	// Ensure tokens and coins are sorted
 	sort.Strings(reserveTokens)
@@ -227,19 +268,135 @@ func NewBond(token, name, description string, creator sdk.AccAddress,
 		ExitFeePercentage:      exitFeePercentage,
 		FeeAddress:             feeAddress,
 		MaxSupply:              maxSupply,
+		MaxSupplyAllocation:    maxSupplyAllocation,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
 		SanityMarginPercentage: sanityMarginPercentage,
 		CurrentSupply:          sdk.NewCoin(token, sdk.ZeroInt()),
 		CurrentReserve:         nil,
+		ReserveAddress:         GetReserveAddress(token),
 		AllowSells:             allowSells,
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
 		OutcomePayment:         outcomePayment,
+		OutcomePaymentReceived: nil,
+		HatchDeadline:          hatchDeadline,
+		MinRaise:               minRaise,
+		VestingCliff:           vestingCliff,
+		VestingDuration:        vestingDuration,
+		FundingTap:             fundingTap,
+		FundingTapBlocks:       fundingTapBlocks,
+		FundingPeriodStart:     0,
+		FundingWithdrawn:       nil,
+		FundingTaxPercentage:   fundingTaxPercentage,
 		State:                  state,
 	}
 }
 
+// AllocatesMaxSupply returns true if, rather than rejecting buys that exceed
+// the max supply, the bond allocates the remaining supply between the buys of
+// the batch that reaches the max supply.
+func (bond Bond) AllocatesMaxSupply() bool {
+	return bond.MaxSupplyAllocation == ProRataAllocation ||
+		bond.MaxSupplyAllocation == FirstComeAllocation
+}
+
+// HasHatchDeadline returns true if the bond is an augmented bond whose hatch
+// phase has a deadline, in which case the funding share of the hatch phase is
+// held in escrow until the hatch phase ends.
+func (bond Bond) HasHatchDeadline() bool {
+	return bond.HatchDeadline != 0
+}
+
+// HasVesting returns true if the bond is an augmented bond whose tokens bought
+// in the hatch phase vest over time, in which case these cannot be sold until
+// they have vested.
+func (bond Bond) HasVesting() bool {
+	return bond.VestingCliff != 0 || bond.VestingDuration != 0
+}
+
+// GetOutcomePaymentRemaining returns the part of the bond's outcome payment
+// that has not yet been paid through outcome payment contributions.
+func (bond Bond) GetOutcomePaymentRemaining() sdk.Coins {
+	remaining, hasNeg := bond.OutcomePayment.SafeSub(bond.OutcomePaymentReceived)
+	if hasNeg {
+		return nil
+	}
+	return remaining
+}
+
+// OutcomePaymentReached returns true if the outcome payment contributions made
+// to the bond add up to the bond's outcome payment.
+func (bond Bond) OutcomePaymentReached() bool {
+	return !bond.OutcomePayment.Empty() &&
+		bond.OutcomePaymentReceived.IsAllGTE(bond.OutcomePayment)
+}
+
+// HasFundingPool returns true if the bond is an augmented bond whose funding
+// share of the hatch phase is sent to the bond's funding pool, from which it
+// is released to the fee address through the bond's funding tap.
+func (bond Bond) HasFundingPool() bool {
+	return !bond.FundingTap.Empty()
+}
+
+// GetFundingAddress returns the address to which the funding share of the
+// hatch phase is paid out, i.e. the bond's funding pool if it has one, or the
+// fee address otherwise.
+func (bond Bond) GetFundingAddress() sdk.AccAddress {
+	if bond.HasFundingPool() {
+		return GetFundingPoolAddress(bond.Token)
+	}
+	return bond.FeeAddress
+}
+
+// FundingPeriodEnded returns true if no funding has been withdrawn within the
+// last FundingTapBlocks blocks at the specified height, i.e. if the funding tap
+// is fully available again.
+func (bond Bond) FundingPeriodEnded(height int64) bool {
+	return bond.FundingWithdrawn.Empty() ||
+		height >= bond.FundingPeriodStart+bond.FundingTapBlocks
+}
+
+// GetFundingTapAvailable returns the max amount that can be withdrawn from
+// the bond's funding pool at the specified height, given the funding already
+// withdrawn in the current period, regardless of the funding pool's balance.
+func (bond Bond) GetFundingTapAvailable(height int64) sdk.Coins {
+	if bond.FundingPeriodEnded(height) {
+		return bond.FundingTap
+	}
+
+	available := sdk.NewCoins()
+	for _, c := range bond.FundingTap {
+		withdrawn := bond.FundingWithdrawn.AmountOf(c.Denom)
+		if c.Amount.GT(withdrawn) {
+			available = available.Add(sdk.NewCoin(c.Denom, c.Amount.Sub(withdrawn)))
+		}
+	}
+	return available
+}
+
+// AddFundingWithdrawal adds an amount withdrawn from the bond's funding pool
+// at the specified height to the funding withdrawn in the current period,
+// starting a new period if the previous one has ended.
+func (bond Bond) AddFundingWithdrawal(height int64, amount sdk.Coins) Bond {
+	if bond.FundingPeriodEnded(height) {
+		bond.FundingPeriodStart = height
+		bond.FundingWithdrawn = amount
+	} else {
+		bond.FundingWithdrawn = bond.FundingWithdrawn.Add(amount...)
+	}
+	return bond
+}
+
+// GetHatchRaise returns the amount raised by an augmented bond in its hatch
+// phase, i.e. p0 per bond token in each of the reserve tokens.
+func (bond Bond) GetHatchRaise() sdk.Coins {
+	args := bond.FunctionParameters.AsMap()
+	raise := args["p0"].Mul(bond.CurrentSupply.Amount.ToDec())
+	raiseCoins, _ := bond.GetNewReserveDecCoins(raise).TruncateDecimal()
+	return raiseCoins
+}
+
 //noinspection GoNilness
 func (bond Bond) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	for _, r := range bond.ReserveTokens {
@@ -277,7 +434,7 @@ func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err err
 	case AugmentedFunction:
 		// Note: during the hatch phase, this function returns the hatch price
 		// p0 even if the supply argument is greater than the initial supply S0
-		switch bond.State {
+		switch bond.GetActiveState() {
 		case HatchState:
 			result = bond.GetNewReserveDecCoins(args["p0"])
 		case OpenState:
@@ -421,9 +578,9 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 	}
 
 	// If hatch phase for augmented function, use fixed p0 price
-	if bond.FunctionType == AugmentedFunction && bond.State == HatchState {
+	if bond.FunctionType == AugmentedFunction && bond.GetActiveState() == HatchState {
 		args := bond.FunctionParameters.AsMap()
-		if bond.State == HatchState {
+		if bond.GetActiveState() == HatchState {
 			price := args["p0"].Mul(mint.ToDec())
 			return bond.GetNewReserveDecCoins(price), nil
 		}
@@ -557,6 +714,151 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 	}
 }
 
+// GetSwapToToken returns the reserve token that a swap from the specified
+// reserve token of a swapper bond is swapped to, i.e. its other reserve token.
+func (bond Bond) GetSwapToToken(fromToken string) (string, error) {
+	if bond.FunctionType != SwapperFunction {
+		return "", sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	} else if fromToken == bond.ReserveTokens[0] {
+		return bond.ReserveTokens[1], nil
+	} else if fromToken == bond.ReserveTokens[1] {
+		return bond.ReserveTokens[0], nil
+	}
+	return "", sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, fromToken)
+}
+
+// GetLiquidityDepositSwap returns the amount of a deposit of liquidity to a
+// swapper bond that should be swapped for the bond's other reserve token, such
+// that the rest of the deposit and the returns of the swap are in the reserve
+// ratio that results from the swap. The deposit can consist of any amounts of
+// the two reserve tokens, and the token in excess (relative to the current
+// reserve ratio) is the one swapped. The tx fee charged on the swap is taken
+// into account. A zero amount is returned if the deposit is already in the
+// current reserve ratio.
+func (bond Bond) GetLiquidityDepositSwap(deposit sdk.Coins, reserveBalances sdk.Coins) (sdk.Coin, error) {
+	if deposit.IsAnyNegative() {
+		panic(fmt.Sprintf("negative deposit for bond %s", bond.Token))
+	} else if reserveBalances.IsAnyNegative() {
+		panic(fmt.Sprintf("negative reserve balance for bond %s", bond.Token))
+	}
+
+	switch bond.FunctionType {
+	case PowerFunction:
+		fallthrough
+	case SigmoidFunction:
+		fallthrough
+	case AugmentedFunction:
+		return sdk.Coin{}, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	case SwapperFunction:
+		t1 := bond.ReserveTokens[0]
+		t2 := bond.ReserveTokens[1]
+		r1 := reserveBalances.AmountOf(t1)
+		r2 := reserveBalances.AmountOf(t2)
+		d1 := deposit.AmountOf(t1)
+		d2 := deposit.AmountOf(t2)
+
+		// Check that both reserves are non-empty, given that the reserve
+		// ratio is otherwise undefined
+		if !r1.IsPositive() || !r2.IsPositive() {
+			return sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", t1, t2)
+		}
+
+		// Identify the token in excess as x, and the other token as y
+		var x string
+		var dx, dy, rx, ry sdk.Int
+		if d1.Mul(r2).GT(d2.Mul(r1)) {
+			x, dx, dy, rx, ry = t1, d1, d2, r1, r2
+		} else if d2.Mul(r1).GT(d1.Mul(r2)) {
+			x, dx, dy, rx, ry = t2, d2, d1, r2, r1
+		} else {
+			return sdk.NewInt64Coin(t1, 0), nil
+		}
+
+		// Checks whether x is still in excess (or balanced) after swapping s
+		// of it, with the fee-adjusted input Δx = s-fee added to the reserve
+		// and Δy = (Δx*y)/(x+Δx) taken out of the reserve
+		stillInExcess := func(s sdk.Int) bool {
+			fee := bond.GetTxFee(sdk.NewDecCoinFromCoin(sdk.NewCoin(x, s)))
+			in := sdk.MaxInt(s.Sub(fee.Amount), sdk.ZeroInt())
+			out := in.Mul(ry).Quo(rx.Add(in))
+			return dx.Sub(s).Mul(ry.Sub(out)).GTE(dy.Add(out).Mul(rx.Add(in)))
+		}
+
+		// Binary search for the largest amount that keeps x in excess
+		low, high := sdk.ZeroInt(), dx
+		for low.LT(high) {
+			mid := low.Add(high).AddRaw(1).QuoRaw(2)
+			if stillInExcess(mid) {
+				low = mid
+			} else {
+				high = mid.SubRaw(1)
+			}
+		}
+		return sdk.NewCoin(x, low), nil
+	default:
+		panic("unrecognized function type")
+	}
+}
+
+// GetSwapBatchReturns returns the total returns for the swaps in a batch of a
+// swapper bond, given the total (fee-adjusted) amounts being swapped from each
+// of the bond's two reserve tokens. Opposing swaps are first matched against
+// each other at the current reserve ratio, and only the imbalance is swapped
+// against the constant product curve, so that all of the swaps in the same
+// direction get the same rate. The returns are denominated in the tokens being
+// swapped to and are rounded down, such that the product never decreases.
+func (bond Bond) GetSwapBatchReturns(totalIns sdk.Coins, reserveBalances sdk.Coins) (totalReturns sdk.DecCoins, err error) {
+	if totalIns.IsAnyNegative() {
+		panic(fmt.Sprintf("negative swap amount for bond %s", bond.Token))
+	} else if reserveBalances.IsAnyNegative() {
+		panic(fmt.Sprintf("negative reserve balance for bond %s", bond.Token))
+	}
+
+	switch bond.FunctionType {
+	case PowerFunction:
+		fallthrough
+	case SigmoidFunction:
+		fallthrough
+	case AugmentedFunction:
+		return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, bond.FunctionType)
+	case SwapperFunction:
+		t1 := bond.ReserveTokens[0]
+		t2 := bond.ReserveTokens[1]
+		r1 := reserveBalances.AmountOf(t1).ToDec()
+		r2 := reserveBalances.AmountOf(t2).ToDec()
+		in1 := totalIns.AmountOf(t1).ToDec()
+		in2 := totalIns.AmountOf(t2).ToDec()
+
+		// Check that both reserves are non-empty, given that the reserve
+		// ratio is otherwise undefined
+		if !r1.IsPositive() || !r2.IsPositive() {
+			return nil, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", t1, t2)
+		}
+
+		// If more t1 is being swapped (in value) than t2, all t2 swaps are
+		// matched at the reserve ratio r1/r2, and the excess of t1 is swapped
+		// against the curve using Uniswap formula: Δy = (Δx*y)/(x+Δx).
+		// Otherwise, the opposite applies.
+		var out1, out2 sdk.Dec
+		if in1.Mul(r2).GT(in2.Mul(r1)) {
+			out1 = in2.Mul(r1).QuoTruncate(r2)
+			excess1 := in1.Sub(out1)
+			out2 = in2.Add(excess1.Mul(r2).QuoTruncate(r1.Add(excess1)))
+		} else {
+			out2 = in1.Mul(r2).QuoTruncate(r1)
+			excess2 := in2.Sub(out2)
+			out1 = in1.Add(excess2.Mul(r1).QuoTruncate(r2.Add(excess2)))
+		}
+
+		return sdk.NewDecCoins(
+			sdk.NewDecCoinFromDec(t1, out1),
+			sdk.NewDecCoinFromDec(t2, out2),
+		), nil
+	default:
+		panic("unrecognized function type")
+	}
+}
+
 func (bond Bond) GetFee(reserveAmount sdk.DecCoin, percentage sdk.Dec) sdk.Coin {
 	feeAmount := percentage.QuoInt64(100).Mul(reserveAmount.Amount)
 	return RoundFee(sdk.NewDecCoinFromDec(reserveAmount.Denom, feeAmount))
@@ -587,6 +889,35 @@ func (bond Bond) GetExitFees(reserveAmounts sdk.DecCoins) (fees sdk.Coins) {
 	return bond.GetFees(reserveAmounts, bond.ExitFeePercentage)
 }
 
+// ChargesFundingTax returns true if buys of the bond are charged a funding
+// tax, i.e. if the bond is an augmented bond in its open phase with a positive
+// funding tax percentage.
+func (bond Bond) ChargesFundingTax() bool {
+	return bond.FunctionType == AugmentedFunction &&
+		bond.GetActiveState() == OpenState &&
+		bond.FundingTaxPercentage.IsPositive()
+}
+
+// GetFundingTaxes returns the funding tax charged on top of the reserve prices
+// of a buy, which is sent to the bond's funding pool (or fee address) rather
+// than to the reserve, such that the reserve remains equal to Reserve(S).
+//noinspection GoNilness
+func (bond Bond) GetFundingTaxes(reservePrices sdk.DecCoins) (taxes sdk.Coins) {
+	if !bond.ChargesFundingTax() {
+		return nil
+	}
+	return bond.GetFees(reservePrices, bond.FundingTaxPercentage)
+}
+
+// GetActiveState returns the state of the bond, or the state that the bond was
+// in before being paused if the bond is currently paused.
+func (bond Bond) GetActiveState() string {
+	if bond.State == PausedState {
+		return bond.StateBeforePause
+	}
+	return bond.State
+}
+
 func (bond Bond) SignersEqualTo(signers []sdk.AccAddress) bool {
 	if len(bond.Signers) != len(signers) {
 		return false
@@ -647,3 +978,21 @@ func (bond Bond) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 
 	return exchangeRate.LT(minRate) || exchangeRate.GT(maxRate)
 }
+
+// GetSwapperProduct returns the product of a swapper bond's two reserve
+// balances divided by the square of the bond's current supply. Buys, sells,
+// and share withdrawals change the reserve balances in proportion to the
+// supply, and swaps can only increase the product, so this value should never
+// decrease. Returns zero if the current supply is zero.
+func (bond Bond) GetSwapperProduct(reserveBalances sdk.Coins) sdk.Dec {
+	if bond.FunctionType != SwapperFunction {
+		panic("invalid function for function type")
+	} else if bond.CurrentSupply.IsZero() {
+		return sdk.ZeroDec()
+	}
+
+	supply := bond.CurrentSupply.Amount.ToDec()
+	r1 := reserveBalances.AmountOf(bond.ReserveTokens[0]).ToDec()
+	r2 := reserveBalances.AmountOf(bond.ReserveTokens[1]).ToDec()
+	return r1.Mul(r2).Quo(supply.Mul(supply))
+}
@@ -165,8 +165,10 @@ func TestNewBondDefaultValuesAndSorting(t *testing.T) {
 	bond := NewBond(initToken, initName, initDescription, initCreator,
 		PowerFunction, functionParametersPower(), customReserveTokens,
 		initTxFeePercentage, initExitFeePercentage, initFeeAddress, initMaxSupply,
-		customOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initMaxSupplyAllocation, customOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage, initState)
 
 	expectedCurrentSupply := sdk.NewInt64Coin(bond.Token, 0)
 
@@ -385,6 +387,30 @@ func TestGetReserveDeltaForLiquidityDelta(t *testing.T) {
 	}
 }
 
+func TestGetSwapperProduct(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.ReserveTokens = swapperReserves()
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300),
+	)
+
+	// Zero if current supply is zero
+	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.ZeroInt())
+	require.Equal(t, sdk.ZeroDec(), bond.GetSwapperProduct(reserveBalances))
+
+	// (200*300)/(10*10) = 600
+	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.NewInt(10))
+	require.Equal(t, sdk.NewDec(600), bond.GetSwapperProduct(reserveBalances))
+
+	// Doubling reserve and supply does not change the product
+	bond.CurrentSupply = sdk.NewCoin(bond.Token, sdk.NewInt(20))
+	reserveBalances = reserveBalances.Add(reserveBalances...)
+	require.Equal(t, sdk.NewDec(600), bond.GetSwapperProduct(reserveBalances))
+}
+
 func TestGetPricesToMint(t *testing.T) {
 	bond := getValidBond()
 	// TODO: add more test cases
@@ -607,6 +633,153 @@ func TestGetReturnsForSwapNonSwapperFunctionFails(t *testing.T) {
 	}
 }
 
+func TestGetSwapToToken(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.FunctionParameters = nil
+	bond.ReserveTokens = swapperReserves()
+
+	toToken, err := bond.GetSwapToToken(reserveToken)
+	require.Nil(t, err)
+	require.Equal(t, reserveToken2, toToken)
+
+	toToken, err = bond.GetSwapToToken(reserveToken2)
+	require.Nil(t, err)
+	require.Equal(t, reserveToken, toToken)
+
+	_, err = bond.GetSwapToToken(reserveToken3)
+	require.Error(t, err)
+	require.True(t, ErrTokenIsNotAValidReserveToken.Is(err))
+}
+
+func TestGetSwapToTokenNonSwapperFunctionFails(t *testing.T) {
+	bond := getValidPowerFunctionBond()
+
+	_, err := bond.GetSwapToToken(reserveToken)
+	require.Error(t, err)
+	require.True(t, ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestGetLiquidityDepositSwap(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.FunctionParameters = nil
+	bond.ReserveTokens = swapperReserves()
+	bond.TxFeePercentage = sdk.MustNewDecFromStr("0.1")
+
+	testCases := []struct {
+		reserve1     int64
+		reserve2     int64
+		deposit1     int64
+		deposit2     int64
+		expectedSwap sdk.Coin
+	}{
+		// Single-sided deposits
+		{10000, 10000, 1000, 0, sdk.NewInt64Coin(reserveToken, 488)},
+		{10000, 10000, 0, 1000, sdk.NewInt64Coin(reserveToken2, 488)},
+		{10000, 20000, 100, 0, sdk.NewInt64Coin(reserveToken, 50)},
+		{20000, 10000, 100, 0, sdk.NewInt64Coin(reserveToken, 51)},
+		// Deposits in any ratio
+		{10000, 10000, 1000, 500, sdk.NewInt64Coin(reserveToken, 236)},
+		{10000, 10000, 100, 1000, sdk.NewInt64Coin(reserveToken2, 436)},
+		// Deposits already in the reserve ratio
+		{10000, 10000, 1000, 1000, sdk.NewInt64Coin(reserveToken, 0)},
+		{10000, 20000, 1000, 2000, sdk.NewInt64Coin(reserveToken, 0)},
+	}
+	for _, tc := range testCases {
+		reserveBalances := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, tc.reserve1),
+			sdk.NewInt64Coin(reserveToken2, tc.reserve2),
+		)
+		deposit := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, tc.deposit1),
+			sdk.NewInt64Coin(reserveToken2, tc.deposit2),
+		)
+
+		swap, err := bond.GetLiquidityDepositSwap(deposit, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedSwap, swap)
+	}
+}
+
+func TestGetLiquidityDepositSwapEmptyReserveFails(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.FunctionParameters = nil
+	bond.ReserveTokens = swapperReserves()
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	reserveBalances := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+
+	_, err := bond.GetLiquidityDepositSwap(deposit, reserveBalances)
+	require.Error(t, err)
+	require.True(t, ErrSwapAmountCausesReserveDepletion.Is(err))
+}
+
+func TestGetLiquidityDepositSwapNonSwapperFunctionFails(t *testing.T) {
+	bond := getValidPowerFunctionBond()
+
+	_, err := bond.GetLiquidityDepositSwap(sdk.Coins{}, sdk.Coins{})
+	require.Error(t, err)
+	require.True(t, ErrFunctionNotAvailableForFunctionType.Is(err))
+}
+
+func TestGetSwapBatchReturns(t *testing.T) {
+	bond := getValidBond()
+	bond.FunctionType = SwapperFunction
+	bond.ReserveTokens = []string{reserveToken, reserveToken2}
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000))
+
+	testCases := []struct {
+		in1             int64
+		in2             int64
+		expectedReturn1 string
+		expectedReturn2 string
+	}{
+		{0, 0, "0", "0"},
+		{100, 0, "0", "181.818181818181818181"},     // 100 * 2000 / (1000 + 100)
+		{0, 200, "90.909090909090909090", "0"},      // 200 * 1000 / (2000 + 200)
+		{100, 200, "100", "200"},                    // perfectly matched at 1:2
+		{300, 200, "100", "533.333333333333333333"}, // 200 + 200 * 2000 / (1000 + 200)
+		{100, 600, "266.666666666666666666", "200"}, // 100 + 400 * 1000 / (2000 + 400)
+	}
+	for _, tc := range testCases {
+		totalIns := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, tc.in1),
+			sdk.NewInt64Coin(reserveToken2, tc.in2))
+		totalReturns, err := bond.GetSwapBatchReturns(totalIns, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr(tc.expectedReturn1),
+			totalReturns.AmountOf(reserveToken))
+		require.Equal(t, sdk.MustNewDecFromStr(tc.expectedReturn2),
+			totalReturns.AmountOf(reserveToken2))
+
+		// Product never decreases
+		newReserves := sdk.NewDecCoinsFromCoins(reserveBalances.Add(totalIns...)...).Sub(totalReturns)
+		newProduct := newReserves.AmountOf(reserveToken).Mul(newReserves.AmountOf(reserveToken2))
+		require.True(t, newProduct.GTE(sdk.NewDec(1000*2000)))
+	}
+
+	// Empty reserve gives an error
+	totalIns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	_, err := bond.GetSwapBatchReturns(totalIns, reserveBalances[:1])
+	require.Error(t, err)
+}
+
+func TestGetSwapBatchReturnsNonSwapperFunctionFails(t *testing.T) {
+	bond := getValidBond()
+	testCases := []string{PowerFunction, SigmoidFunction, AugmentedFunction}
+
+	for _, tc := range testCases {
+		bond.FunctionType = tc
+		_, err := bond.GetSwapBatchReturns(sdk.Coins{}, sdk.Coins{})
+		require.Error(t, err)
+	}
+}
+
 func TestBondGetTxFee(t *testing.T) {
 	bond := Bond{}
 	zeroPointOne := sdk.MustNewDecFromStr("0.1")
@@ -836,3 +1009,100 @@ func TestReservesViolateSanityRateReturnsFalseWhenSanityRateIsZero(t *testing.T)
 		require.Equal(t, tc.violates, actualResult)
 	}
 }
+
+func TestBondGetFundingTapAvailable(t *testing.T) {
+	bond := getValidBond()
+	bond.FundingTap = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100),
+		sdk.NewInt64Coin(reserveToken2, 100),
+	)
+	bond.FundingTapBlocks = 10
+
+	// Full funding tap is available before anything is withdrawn
+	require.True(t, bond.HasFundingPool())
+	require.Equal(t, bond.FundingTap, bond.GetFundingTapAvailable(5))
+
+	// Withdrawing at height 5 starts a new period that ends at height 15
+	bond = bond.AddFundingWithdrawal(5, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 60)))
+	require.Equal(t, int64(5), bond.FundingPeriodStart)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 40),
+		sdk.NewInt64Coin(reserveToken2, 100),
+	), bond.GetFundingTapAvailable(14))
+
+	// Withdrawing again within the period adds to the withdrawn amount
+	bond = bond.AddFundingWithdrawal(10, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 40),
+		sdk.NewInt64Coin(reserveToken2, 10),
+	))
+	require.Equal(t, int64(5), bond.FundingPeriodStart)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 90)),
+		bond.GetFundingTapAvailable(14))
+
+	// Full funding tap is available again once the period has ended
+	require.True(t, bond.FundingPeriodEnded(15))
+	require.Equal(t, bond.FundingTap, bond.GetFundingTapAvailable(15))
+
+	// Withdrawing after the period has ended starts a new period
+	bond = bond.AddFundingWithdrawal(20, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)))
+	require.Equal(t, int64(20), bond.FundingPeriodStart)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)), bond.FundingWithdrawn)
+}
+
+func TestBondGetFundingAddress(t *testing.T) {
+	bond := getValidBond()
+	require.False(t, bond.HasFundingPool())
+	require.Equal(t, bond.FeeAddress, bond.GetFundingAddress())
+
+	bond.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	bond.FundingTapBlocks = 10
+	require.Equal(t, GetFundingPoolAddress(bond.Token), bond.GetFundingAddress())
+}
+
+func TestBondGetFundingTaxes(t *testing.T) {
+	reservePrices := sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 1000))
+	expectedTaxes := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 20))
+
+	bond := getValidBond()
+	bond.FundingTaxPercentage = sdk.NewDec(2)
+	require.False(t, bond.ChargesFundingTax()) // not augmented
+	require.Nil(t, bond.GetFundingTaxes(reservePrices))
+
+	// Augmented bonds are not taxed in the hatch phase
+	bond.FunctionType = AugmentedFunction
+	bond.State = HatchState
+	require.False(t, bond.ChargesFundingTax())
+	require.Nil(t, bond.GetFundingTaxes(reservePrices))
+
+	// Augmented bonds are taxed in the open phase (including if paused)
+	bond.State = OpenState
+	require.True(t, bond.ChargesFundingTax())
+	require.Equal(t, expectedTaxes, bond.GetFundingTaxes(reservePrices))
+	bond.State = PausedState
+	bond.StateBeforePause = OpenState
+	require.Equal(t, expectedTaxes, bond.GetFundingTaxes(reservePrices))
+
+	// No tax is charged if the funding tax percentage is zero
+	bond.FundingTaxPercentage = sdk.ZeroDec()
+	require.False(t, bond.ChargesFundingTax())
+	require.Nil(t, bond.GetFundingTaxes(reservePrices))
+}
+
+func TestBondGetOutcomePaymentRemaining(t *testing.T) {
+	bond := getValidBond()
+	require.True(t, bond.GetOutcomePaymentRemaining().IsZero())
+	require.False(t, bond.OutcomePaymentReached()) // no outcome payment
+
+	bond.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	require.Equal(t, bond.OutcomePayment, bond.GetOutcomePaymentRemaining())
+	require.False(t, bond.OutcomePaymentReached())
+
+	bond.OutcomePaymentReceived = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 40))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 60)),
+		bond.GetOutcomePaymentRemaining())
+	require.False(t, bond.OutcomePaymentReached())
+
+	bond.OutcomePaymentReceived = bond.OutcomePayment
+	require.True(t, bond.GetOutcomePaymentRemaining().IsZero())
+	require.True(t, bond.OutcomePaymentReached())
+}
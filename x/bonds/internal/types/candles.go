@@ -0,0 +1,116 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"sort"
+)
+
+// OHLC holds the open, high, low and close prices per reserve token.
+type OHLC struct {
+	Open  sdk.DecCoins `json:"open" yaml:"open"`
+	High  sdk.DecCoins `json:"high" yaml:"high"`
+	Low   sdk.DecCoins `json:"low" yaml:"low"`
+	Close sdk.DecCoins `json:"close" yaml:"close"`
+}
+
+// Update updates the OHLC with the next prices. The open price of a reserve
+// token is the first price seen for that reserve token.
+func (ohlc OHLC) Update(prices sdk.DecCoins) OHLC {
+	return OHLC{
+		Open:  mergeDecCoins(ohlc.Open, prices, func(open, _ sdk.Dec) sdk.Dec { return open }),
+		High:  mergeDecCoins(ohlc.High, prices, sdk.MaxDec),
+		Low:   mergeDecCoins(ohlc.Low, prices, sdk.MinDec),
+		Close: mergeDecCoins(ohlc.Close, prices, func(_, next sdk.Dec) sdk.Dec { return next }),
+	}
+}
+
+// Candle holds the buy and sell OHLC prices and the volume (the amount of bond
+// tokens bought and sold) of the batches settled between the start and end
+// heights of the candle (inclusive).
+type Candle struct {
+	StartHeight int64    `json:"start_height" yaml:"start_height"`
+	EndHeight   int64    `json:"end_height" yaml:"end_height"`
+	BuyPrices   OHLC     `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices  OHLC     `json:"sell_prices" yaml:"sell_prices"`
+	Volume      sdk.Coin `json:"volume" yaml:"volume"`
+}
+
+func NewCandle(startHeight, endHeight int64, token string) Candle {
+	return Candle{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Volume:      sdk.NewInt64Coin(token, 0),
+	}
+}
+
+// Update updates the candle's prices and volume with the settled batch. Batch
+// prices that were never set (i.e. the batch had no orders) are ignored.
+func (c Candle) Update(batch Batch) Candle {
+	if !batch.BuyPrices.Empty() {
+		c.BuyPrices = c.BuyPrices.Update(batch.BuyPrices)
+	}
+	if !batch.SellPrices.Empty() {
+		c.SellPrices = c.SellPrices.Update(batch.SellPrices)
+	}
+
+	c.Volume = c.Volume.Add(batch.TotalBuyAmount).Add(batch.TotalSellAmount)
+	for _, bo := range batch.BuysWithReserve {
+		if !bo.IsCancelled() {
+			c.Volume = c.Volume.Add(bo.Amount)
+		}
+	}
+	for _, ao := range batch.AddLiquidity {
+		if !ao.IsCancelled() {
+			c.Volume = c.Volume.Add(ao.Amount)
+		}
+	}
+	for _, ro := range batch.RemoveLiquidity {
+		if !ro.IsCancelled() {
+			c.Volume = c.Volume.Add(ro.Amount)
+		}
+	}
+	return c
+}
+
+// GetCandles aggregates settled batches, ordered by height, into candles that
+// each span the specified number of blocks. Intervals without any settled
+// batches do not have a candle.
+func GetCandles(token string, settledBatches []SettledBatch, interval int64) (candles []Candle) {
+	for _, sb := range settledBatches {
+		startHeight := sb.Height - sb.Height%interval
+		if len(candles) == 0 || candles[len(candles)-1].StartHeight != startHeight {
+			endHeight := startHeight + interval - 1
+			candles = append(candles, NewCandle(startHeight, endHeight, token))
+		}
+		candles[len(candles)-1] = candles[len(candles)-1].Update(sb.Batch)
+	}
+	return candles
+}
+
+// mergeDecCoins returns the union of two sets of coins, where the amount of
+// any denomination that is present in both sets is chosen using choose.
+func mergeDecCoins(a, b sdk.DecCoins, choose func(x, y sdk.Dec) sdk.Dec) sdk.DecCoins {
+	amounts := make(map[string]sdk.Dec)
+	for _, coin := range a {
+		amounts[coin.Denom] = coin.Amount
+	}
+	for _, coin := range b {
+		if amount, ok := amounts[coin.Denom]; ok {
+			amounts[coin.Denom] = choose(amount, coin.Amount)
+		} else {
+			amounts[coin.Denom] = coin.Amount
+		}
+	}
+
+	denoms := make([]string, 0, len(amounts))
+	for denom := range amounts {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	merged := make(sdk.DecCoins, len(denoms))
+	for i, denom := range denoms {
+		merged[i] = sdk.NewDecCoinFromDec(denom, amounts[denom])
+	}
+	return merged
+}
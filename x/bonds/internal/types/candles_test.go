@@ -0,0 +1,93 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestSettledBatch(height int64, buyPrice, sellPrice string, buys, sells int64) SettledBatch {
+	batch := NewBatch(initToken, sdk.OneUint())
+	batch.TotalBuyAmount = sdk.NewInt64Coin(initToken, buys)
+	batch.TotalSellAmount = sdk.NewInt64Coin(initToken, sells)
+	batch.BuyPrices = sdk.DecCoins{sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr(buyPrice))}
+	batch.SellPrices = sdk.DecCoins{sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr(sellPrice))}
+	return NewSettledBatch(height, batch)
+}
+
+func decCoins(amount string) sdk.DecCoins {
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr(amount))}
+}
+
+func TestOHLCUpdate(t *testing.T) {
+	ohlc := OHLC{}
+	ohlc = ohlc.Update(decCoins("2"))
+	ohlc = ohlc.Update(decCoins("5"))
+	ohlc = ohlc.Update(decCoins("1"))
+	ohlc = ohlc.Update(decCoins("3"))
+
+	require.Equal(t, decCoins("2"), ohlc.Open)
+	require.Equal(t, decCoins("5"), ohlc.High)
+	require.Equal(t, decCoins("1"), ohlc.Low)
+	require.Equal(t, decCoins("3"), ohlc.Close)
+}
+
+func TestOHLCUpdateWithMultipleReserveTokens(t *testing.T) {
+	prices1 := sdk.DecCoins{sdk.NewDecCoinFromDec(reserveToken, sdk.NewDec(2))}
+	prices2 := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(reserveToken, sdk.NewDec(1)),
+		sdk.NewDecCoinFromDec(reserveToken2, sdk.NewDec(7)),
+	}
+
+	ohlc := OHLC{}.Update(prices1).Update(prices2)
+
+	require.Equal(t, sdk.DecCoins{
+		sdk.NewDecCoinFromDec(reserveToken, sdk.NewDec(2)),
+		sdk.NewDecCoinFromDec(reserveToken2, sdk.NewDec(7)),
+	}, ohlc.Open)
+	require.Equal(t, sdk.DecCoins{
+		sdk.NewDecCoinFromDec(reserveToken, sdk.NewDec(2)),
+		sdk.NewDecCoinFromDec(reserveToken2, sdk.NewDec(7)),
+	}, ohlc.High)
+	require.Equal(t, prices2, ohlc.Low)
+	require.Equal(t, prices2, ohlc.Close)
+}
+
+func TestGetCandles(t *testing.T) {
+	settledBatches := []SettledBatch{
+		newTestSettledBatch(3, "2", "1", 10, 0),
+		newTestSettledBatch(5, "4", "3", 5, 5),
+		newTestSettledBatch(9, "3", "2", 0, 1),
+		newTestSettledBatch(25, "6", "5", 2, 2),
+	}
+	emptyBatch := NewSettledBatch(27, NewBatch(initToken, sdk.OneUint()))
+	settledBatches = append(settledBatches, emptyBatch)
+
+	candles := GetCandles(initToken, settledBatches, 10)
+	require.Len(t, candles, 2)
+
+	// First candle aggregates the first three batches
+	require.Equal(t, int64(0), candles[0].StartHeight)
+	require.Equal(t, int64(9), candles[0].EndHeight)
+	require.Equal(t, decCoins("2"), candles[0].BuyPrices.Open)
+	require.Equal(t, decCoins("4"), candles[0].BuyPrices.High)
+	require.Equal(t, decCoins("2"), candles[0].BuyPrices.Low)
+	require.Equal(t, decCoins("3"), candles[0].BuyPrices.Close)
+	require.Equal(t, decCoins("1"), candles[0].SellPrices.Open)
+	require.Equal(t, decCoins("3"), candles[0].SellPrices.High)
+	require.Equal(t, decCoins("1"), candles[0].SellPrices.Low)
+	require.Equal(t, decCoins("2"), candles[0].SellPrices.Close)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 21), candles[0].Volume)
+
+	// Second candle ignores the prices of the empty batch
+	require.Equal(t, int64(20), candles[1].StartHeight)
+	require.Equal(t, int64(29), candles[1].EndHeight)
+	require.Equal(t, decCoins("6"), candles[1].BuyPrices.Open)
+	require.Equal(t, decCoins("6"), candles[1].BuyPrices.Close)
+	require.Equal(t, decCoins("5"), candles[1].SellPrices.Low)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 4), candles[1].Volume)
+}
+
+func TestGetCandlesWithNoSettledBatches(t *testing.T) {
+	require.Len(t, GetCandles(initToken, nil, 10), 0)
+}
@@ -19,13 +19,34 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&Batch{}, "bonds/Batch", nil)
 	cdc.RegisterConcrete(&BaseOrder{}, "bonds/BaseOrder", nil)
 	cdc.RegisterConcrete(&BuyOrder{}, "bonds/BuyOrder", nil)
+	cdc.RegisterConcrete(&BuyWithReserveOrder{}, "bonds/BuyWithReserveOrder", nil)
 	cdc.RegisterConcrete(&SellOrder{}, "bonds/SellOrder", nil)
 	cdc.RegisterConcrete(&SwapOrder{}, "bonds/SwapOrder", nil)
+	cdc.RegisterConcrete(&AddLiquidityOrder{}, "bonds/AddLiquidityOrder", nil)
+	cdc.RegisterConcrete(&RemoveLiquidityOrder{}, "bonds/RemoveLiquidityOrder", nil)
+	cdc.RegisterConcrete(&LimitOrder{}, "bonds/LimitOrder", nil)
 	cdc.RegisterConcrete(MsgCreateBond{}, "bonds/MsgCreateBond", nil)
 	cdc.RegisterConcrete(MsgEditBond{}, "bonds/MsgEditBond", nil)
 	cdc.RegisterConcrete(MsgBuy{}, "bonds/MsgBuy", nil)
+	cdc.RegisterConcrete(MsgBuyWithReserve{}, "bonds/MsgBuyWithReserve", nil)
 	cdc.RegisterConcrete(MsgSell{}, "bonds/MsgSell", nil)
 	cdc.RegisterConcrete(MsgSwap{}, "bonds/MsgSwap", nil)
+	cdc.RegisterConcrete(MsgSwapRoute{}, "bonds/MsgSwapRoute", nil)
+	cdc.RegisterConcrete(MsgAddLiquidity{}, "bonds/MsgAddLiquidity", nil)
+	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "bonds/MsgRemoveLiquidity", nil)
+	cdc.RegisterConcrete(MsgCancelOrder{}, "bonds/MsgCancelOrder", nil)
+	cdc.RegisterConcrete(MsgLimitBuy{}, "bonds/MsgLimitBuy", nil)
+	cdc.RegisterConcrete(MsgLimitSell{}, "bonds/MsgLimitSell", nil)
+	cdc.RegisterConcrete(MsgCancelLimitOrder{}, "bonds/MsgCancelLimitOrder", nil)
 	cdc.RegisterConcrete(MsgMakeOutcomePayment{}, "bonds/MsgMakeOutcomePayment", nil)
+	cdc.RegisterConcrete(MsgFinaliseOutcomePayment{}, "bonds/MsgFinaliseOutcomePayment", nil)
 	cdc.RegisterConcrete(MsgWithdrawShare{}, "bonds/MsgWithdrawShare", nil)
+	cdc.RegisterConcrete(MsgWithdrawVested{}, "bonds/MsgWithdrawVested", nil)
+	cdc.RegisterConcrete(MsgPauseBond{}, "bonds/MsgPauseBond", nil)
+	cdc.RegisterConcrete(MsgResumeBond{}, "bonds/MsgResumeBond", nil)
+	cdc.RegisterConcrete(MsgCloseBond{}, "bonds/MsgCloseBond", nil)
+	cdc.RegisterConcrete(MsgWithdrawFunding{}, "bonds/MsgWithdrawFunding", nil)
+	cdc.RegisterConcrete(MsgSetFundingTap{}, "bonds/MsgSetFundingTap", nil)
+	cdc.RegisterConcrete(PauseBondProposal{}, "bonds/PauseBondProposal", nil)
+	cdc.RegisterConcrete(ResumeBondProposal{}, "bonds/ResumeBondProposal", nil)
 }
@@ -22,6 +22,7 @@ var (
 	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
 	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
 	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
+	initMaxSupplyAllocation    = NoAllocation
 	initOrderQuantityLimits    = sdk.Coins(nil)
 	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
 	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
@@ -29,6 +30,13 @@ var (
 	initSigners                = []sdk.AccAddress{initCreator}
 	initBatchBlocks            = sdk.NewUint(10)
 	initOutcomePayment         = sdk.Coins(nil)
+	initHatchDeadline          = int64(0)
+	initMinRaise               = sdk.Coins(nil)
+	initVestingCliff           = int64(0)
+	initVestingDuration        = int64(0)
+	initFundingTap             = sdk.Coins(nil)
+	initFundingTapBlocks       = int64(0)
+	initFundingTaxPercentage   = sdk.ZeroDec()
 	initState                  = OpenState
 
 	// 9223372036854775807
@@ -97,8 +105,10 @@ func getValidPowerFunctionBond() Bond {
 	return NewBond(initToken, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage, initState)
 }
 
 func getValidBond() Bond {
@@ -130,8 +140,10 @@ func newValidMsgCreateBond() MsgCreateBond {
 	return NewMsgCreateBond(initToken, initName, initDescription, initCreator,
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
-		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initMaxSupplyAllocation, initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
+		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment,
+		initHatchDeadline, initMinRaise, initVestingCliff, initVestingDuration,
+		initFundingTap, initFundingTapBlocks, initFundingTaxPercentage)
 }
 
 func newValidMsgCreateSwapperBond() MsgCreateBond {
@@ -142,6 +154,13 @@ func newValidMsgCreateSwapperBond() MsgCreateBond {
 	return validMsg
 }
 
+func newValidMsgCreateAugmentedBond() MsgCreateBond {
+	validMsg := newValidMsgCreateBond()
+	validMsg.FunctionType = AugmentedFunction
+	validMsg.FunctionParameters = functionParametersAugmented()
+	return validMsg
+}
+
 func newEmptyStringsMsgEditBond() MsgEditBond {
 	return NewMsgEditBond(initToken, "", "", "", "", "",
 		initCreator, initSigners)
@@ -156,17 +175,100 @@ func newValidMsgBuy() MsgBuy {
 	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount, _ := sdk.ParseCoin("10" + initToken)
 	maxPrices, _ := sdk.ParseCoins("50" + initToken)
-	return NewMsgBuy(buyer, amount, maxPrices)
+	return NewMsgBuy(buyer, amount, maxPrices, false)
+}
+
+func newValidMsgBuyWithReserve() MsgBuyWithReserve {
+	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	budget, _ := sdk.ParseCoins("50" + reserveToken)
+	return NewMsgBuyWithReserve(buyer, initToken, budget)
 }
 
 func newValidMsgSell() MsgSell {
 	seller := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount, _ := sdk.ParseCoin("10" + initToken)
-	return NewMsgSell(seller, amount)
+	return NewMsgSell(seller, amount, nil)
 }
 
 func newValidMsgSwap() MsgSwap {
 	swapper := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	from := sdk.NewInt64Coin(reserveToken, 10)
-	return NewMsgSwap(swapper, initToken, from, reserveToken2)
+	minOutput := sdk.NewInt64Coin(reserveToken2, 0)
+	return NewMsgSwap(swapper, initToken, from, reserveToken2, minOutput)
+}
+
+func newValidMsgSwapRoute() MsgSwapRoute {
+	swapper := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	from := sdk.NewInt64Coin(reserveToken, 10)
+	minOutput := sdk.NewInt64Coin(reserveToken3, 0)
+	return NewMsgSwapRoute(swapper, []string{initToken, "othertoken"}, from, minOutput)
+}
+
+func newValidMsgAddLiquidity() MsgAddLiquidity {
+	provider := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	minAmount := sdk.NewInt64Coin(initToken, 0)
+	return NewMsgAddLiquidity(provider, initToken, deposit, minAmount)
+}
+
+func newValidMsgRemoveLiquidity() MsgRemoveLiquidity {
+	provider := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	minOutput := sdk.NewInt64Coin(reserveToken, 0)
+	return NewMsgRemoveLiquidity(provider, amount, reserveToken, minOutput)
+}
+
+func newValidMsgCancelOrder() MsgCancelOrder {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgCancelOrder(address, initToken, AttributeValueBuyOrder, 0)
+}
+
+func newValidMsgLimitBuy() MsgLimitBuy {
+	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount, _ := sdk.ParseCoin("10" + initToken)
+	maxPricesPerToken, _ := sdk.ParseDecCoins("5.5" + reserveToken)
+	return NewMsgLimitBuy(buyer, amount, maxPricesPerToken, 0)
+}
+
+func newValidMsgLimitSell() MsgLimitSell {
+	seller := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount, _ := sdk.ParseCoin("10" + initToken)
+	minPricesPerToken, _ := sdk.ParseDecCoins("5.5" + reserveToken)
+	return NewMsgLimitSell(seller, amount, minPricesPerToken, 0)
+}
+
+func newValidMsgCancelLimitOrder() MsgCancelLimitOrder {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgCancelLimitOrder(address, initToken, 0)
+}
+
+func newValidMsgPauseBond() MsgPauseBond {
+	return NewMsgPauseBond(initToken, initCreator, initSigners)
+}
+
+func newValidMsgCloseBond() MsgCloseBond {
+	return NewMsgCloseBond(initToken, initCreator, initSigners)
+}
+
+func newValidMsgMakeOutcomePayment() MsgMakeOutcomePayment {
+	amount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	return NewMsgMakeOutcomePayment(initCreator, initToken, amount)
+}
+
+func newValidMsgFinaliseOutcomePayment() MsgFinaliseOutcomePayment {
+	return NewMsgFinaliseOutcomePayment(initToken, initCreator, initSigners)
+}
+
+func newValidMsgWithdrawFunding() MsgWithdrawFunding {
+	amount := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	return NewMsgWithdrawFunding(initFeeAddress, initToken, amount)
+}
+
+func newValidMsgSetFundingTap() MsgSetFundingTap {
+	fundingTap := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	return NewMsgSetFundingTap(initToken, fundingTap, 10, initCreator, initSigners)
+}
+
+func newValidPauseBondProposal() PauseBondProposal {
+	return NewPauseBondProposal("title", "description", initToken)
 }
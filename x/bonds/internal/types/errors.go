@@ -49,4 +49,22 @@ var (
 	ErrArgumentMissingOrNonUInteger         = sdkerrors.Register(ModuleName, 338, "argument is missing or is not an unsigned integer")
 	ErrArgumentMissingOrNonBoolean          = sdkerrors.Register(ModuleName, 339, "argument is missing or is not true or false")
 	ErrReservedBondToken                    = sdkerrors.Register(ModuleName, 340, "bond token is reserved")
+	ErrMinReturnsNotMet                     = sdkerrors.Register(ModuleName, 341, "min returns not met")
+	ErrMinOutputNotMet                      = sdkerrors.Register(ModuleName, 342, "min output not met")
+	ErrOrderDoesNotExist                    = sdkerrors.Register(ModuleName, 343, "order does not exist")
+	ErrOrderAlreadyCancelled                = sdkerrors.Register(ModuleName, 344, "order already cancelled")
+	ErrUnrecognizedOrderType                = sdkerrors.Register(ModuleName, 345, "unrecognized order type")
+	ErrUnrecognizedMaxSupplyAllocation      = sdkerrors.Register(ModuleName, 346, "unrecognized max supply allocation")
+	ErrBondSoldOut                          = sdkerrors.Register(ModuleName, 347, "bond sold out")
+	ErrExpiryHeightAlreadyReached           = sdkerrors.Register(ModuleName, 348, "expiry height already reached")
+	ErrInvalidSwapRoute                     = sdkerrors.Register(ModuleName, 349, "invalid swap route")
+	ErrNoSwapRouteFound                     = sdkerrors.Register(ModuleName, 350, "no swap route found")
+	ErrMinAmountNotMet                      = sdkerrors.Register(ModuleName, 351, "min amount not met")
+	ErrHatchDeadlineAlreadyReached          = sdkerrors.Register(ModuleName, 352, "hatch deadline already reached")
+	ErrMinRaiseRequiresHatchDeadline        = sdkerrors.Register(ModuleName, 353, "min raise requires a hatch deadline")
+	ErrInsufficientVestedTokens             = sdkerrors.Register(ModuleName, 354, "insufficient vested tokens")
+	ErrBondHasNoFundingPool                 = sdkerrors.Register(ModuleName, 355, "bond has no funding pool")
+	ErrFundingTapExceeded                   = sdkerrors.Register(ModuleName, 356, "funding tap exceeded")
+	ErrOutcomePaymentExceeded               = sdkerrors.Register(ModuleName, 357, "outcome payment exceeded")
+	ErrNoOutcomePaymentMade                 = sdkerrors.Register(ModuleName, 358, "no outcome payment has been made")
 )
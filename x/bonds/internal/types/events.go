@@ -1,17 +1,35 @@
 package types
 
 const (
-	EventTypeCreateBond         = "create_bond"
-	EventTypeEditBond           = "edit_bond"
-	EventTypeInitSwapper        = "init_swapper"
-	EventTypeBuy                = "buy"
-	EventTypeSell               = "sell"
-	EventTypeSwap               = "swap"
-	EventTypeMakeOutcomePayment = "make_outcome_payment"
-	EventTypeWithdrawShare      = "withdraw_share"
-	EventTypeOrderCancel        = "order_cancel"
-	EventTypeOrderFulfill       = "order_fulfill"
-	EventTypeStateChange        = "state_change"
+	EventTypeCreateBond             = "create_bond"
+	EventTypeEditBond               = "edit_bond"
+	EventTypeInitSwapper            = "init_swapper"
+	EventTypeBuy                    = "buy"
+	EventTypeBuyWithReserve         = "buy_with_reserve"
+	EventTypeSell                   = "sell"
+	EventTypeSwap                   = "swap"
+	EventTypeSwapRoute              = "swap_route"
+	EventTypeSwapRouteHop           = "swap_route_hop"
+	EventTypeAddLiquidity           = "add_liquidity"
+	EventTypeRemoveLiquidity        = "remove_liquidity"
+	EventTypeCancelOrder            = "cancel_order"
+	EventTypeLimitBuy               = "limit_buy"
+	EventTypeLimitSell              = "limit_sell"
+	EventTypeCancelLimitOrder       = "cancel_limit_order"
+	EventTypeMakeOutcomePayment     = "make_outcome_payment"
+	EventTypeFinaliseOutcomePayment = "finalise_outcome_payment"
+	EventTypeWithdrawShare          = "withdraw_share"
+	EventTypeWithdrawVested         = "withdraw_vested"
+	EventTypePauseBond              = "pause_bond"
+	EventTypeResumeBond             = "resume_bond"
+	EventTypeCloseBond              = "close_bond"
+	EventTypeWithdrawFunding        = "withdraw_funding"
+	EventTypeSetFundingTap          = "set_funding_tap"
+	EventTypeOrderCancel            = "order_cancel"
+	EventTypeOrderFulfill           = "order_fulfill"
+	EventTypeSwapClearing           = "swap_clearing"
+	EventTypeStateChange            = "state_change"
+	EventTypeBondSoldOut            = "bond_sold_out"
 
 	AttributeKeyBond                   = "bond"
 	AttributeKeyName                   = "name"
@@ -23,6 +41,7 @@ const (
 	AttributeKeyExitFeePercentage      = "exit_fee_percentage"
 	AttributeKeyFeeAddress             = "fee_address"
 	AttributeKeyMaxSupply              = "max_supply"
+	AttributeKeyMaxSupplyAllocation    = "max_supply_allocation"
 	AttributeKeyOrderQuantityLimits    = "order_quantity_limits"
 	AttributeKeySanityRate             = "sanity_rate"
 	AttributeKeySanityMarginPercentage = "sanity_margin_percentage"
@@ -30,11 +49,33 @@ const (
 	AttributeKeySigners                = "signers"
 	AttributeKeyBatchBlocks            = "batch_blocks"
 	AttributeKeyOutcomePayment         = "outcome_payment"
+	AttributeKeyOutcomePaymentReceived = "outcome_payment_received"
+	AttributeKeyHatchDeadline          = "hatch_deadline"
+	AttributeKeyMinRaise               = "min_raise"
+	AttributeKeyVestingCliff           = "vesting_cliff"
+	AttributeKeyVestingDuration        = "vesting_duration"
+	AttributeKeyFundingTap             = "funding_tap"
+	AttributeKeyFundingTapBlocks       = "funding_tap_blocks"
+	AttributeKeyFundingTaxPercentage   = "funding_tax_percentage"
+	AttributeKeyBeneficiary            = "beneficiary"
 	AttributeKeyState                  = "state"
 	AttributeKeyMaxPrices              = "max_prices"
+	AttributeKeyAllowPartial           = "allow_partial"
+	AttributeKeyMinReturns             = "min_returns"
 	AttributeKeySwapFromToken          = "from_token"
 	AttributeKeySwapToToken            = "to_token"
+	AttributeKeyMinOutput              = "min_output"
+	AttributeKeySwapRoute              = "route"
+	AttributeKeyPreviousBond           = "previous_bond"
+	AttributeKeyPreviousOrderID        = "previous_order_id"
+	AttributeKeyMaxPricesPerToken      = "max_prices_per_token"
+	AttributeKeyMinPricesPerToken      = "min_prices_per_token"
+	AttributeKeyExpiryHeight           = "expiry_height"
+	AttributeKeyBudget                 = "budget"
+	AttributeKeyDeposit                = "deposit"
+	AttributeKeyMinAmount              = "min_amount"
 	AttributeKeyOrderType              = "order_type"
+	AttributeKeyOrderID                = "order_id"
 	AttributeKeyAddress                = "address"
 	AttributeKeyCancelReason           = "cancel_reason"
 	AttributeKeyTokensMinted           = "tokens_minted"
@@ -45,12 +86,21 @@ const (
 	AttributeKeyChargedPricesFunding   = "charged_prices_of_which_funding"
 	AttributeKeyChargedFees            = "charged_fees"
 	AttributeKeyReturnedToAddress      = "returned_to_address"
+	AttributeKeyFillRatio              = "fill_ratio"
+	AttributeKeyClearingRate           = "clearing_rate"
+	AttributeKeyTotalSwapped           = "total_swapped"
+	AttributeKeyTotalReturned          = "total_returned"
 	AttributeKeyNewBondTokenBalance    = "new_bond_token_balance"
 	AttributeKeyOldState               = "old_state"
 	AttributeKeyNewState               = "new_state"
 
-	AttributeValueBuyOrder  = "buy"
-	AttributeValueSellOrder = "sell"
-	AttributeValueSwapOrder = "swap"
-	AttributeValueCategory  = ModuleName
+	AttributeValueBuyOrder             = "buy"
+	AttributeValueBuyWithReserveOrder  = "buy_with_reserve"
+	AttributeValueSellOrder            = "sell"
+	AttributeValueSwapOrder            = "swap"
+	AttributeValueAddLiquidityOrder    = "add_liquidity"
+	AttributeValueRemoveLiquidityOrder = "remove_liquidity"
+	AttributeValueLimitBuyOrder        = "limit_buy"
+	AttributeValueLimitSellOrder       = "limit_sell"
+	AttributeValueCategory             = ModuleName
 )
@@ -1,16 +1,29 @@
 package types
 
 type GenesisState struct {
-	Bonds   []Bond  `json:"bonds" yaml:"bonds"`
-	Batches []Batch `json:"batches" yaml:"batches"`
-	Params  Params  `json:"params" yaml:"params"`
+	Bonds                       []Bond                       `json:"bonds" yaml:"bonds"`
+	Batches                     []Batch                      `json:"batches" yaml:"batches"`
+	BatchesHistory              []SettledBatch               `json:"batches_history" yaml:"batches_history"`
+	Positions                   []Position                   `json:"positions" yaml:"positions"`
+	LimitOrders                 []LimitOrder                 `json:"limit_orders" yaml:"limit_orders"`
+	Vestings                    []Vesting                    `json:"vestings" yaml:"vestings"`
+	OutcomePaymentContributions []OutcomePaymentContribution `json:"outcome_payment_contributions" yaml:"outcome_payment_contributions"`
+	Params                      Params                       `json:"params" yaml:"params"`
 }
 
-func NewGenesisState(bonds []Bond, batches []Batch, params Params) GenesisState {
+func NewGenesisState(bonds []Bond, batches []Batch,
+	batchesHistory []SettledBatch, positions []Position, limitOrders []LimitOrder,
+	vestings []Vesting, outcomePaymentContributions []OutcomePaymentContribution,
+	params Params) GenesisState {
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
-		Params:  params,
+		Bonds:                       bonds,
+		Batches:                     batches,
+		BatchesHistory:              batchesHistory,
+		Positions:                   positions,
+		LimitOrders:                 limitOrders,
+		Vestings:                    vestings,
+		OutcomePaymentContributions: outcomePaymentContributions,
+		Params:                      params,
 	}
 }
 
@@ -24,8 +37,13 @@ func ValidateGenesis(data GenesisState) error {
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Bonds:   nil,
-		Batches: nil,
-		Params:  DefaultParams(),
+		Bonds:                       nil,
+		Batches:                     nil,
+		BatchesHistory:              nil,
+		Positions:                   nil,
+		LimitOrders:                 nil,
+		Vestings:                    nil,
+		OutcomePaymentContributions: nil,
+		Params:                      DefaultParams(),
 	}
 }
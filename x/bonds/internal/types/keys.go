@@ -1,5 +1,10 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
 const (
 	// ModuleName is the name of this module
 	ModuleName = "bonds"
@@ -17,8 +22,25 @@ const (
 	BatchesIntermediaryAccount = "batches_intermediary_account"
 
 	// BondsReserveAccount the root string for the bonds reserve account address
+	// (superseded by per-bond reserve addresses; see GetReserveAddress)
 	BondsReserveAccount = "bonds_reserve_account"
 
+	// BondsHatchEscrowAccount the root string for the bonds hatch escrow
+	// account address (see GetHatchEscrowAddress)
+	BondsHatchEscrowAccount = "bonds_hatch_escrow_account"
+
+	// BondsFundingPoolAccount the root string for the bonds funding pool
+	// account address (see GetFundingPoolAddress)
+	BondsFundingPoolAccount = "bonds_funding_pool_account"
+
+	// BondsVestingEscrowAccount the root string for the bonds vesting escrow
+	// account address (see GetVestingEscrowAddress)
+	BondsVestingEscrowAccount = "bonds_vesting_escrow_account"
+
+	// BondsOutcomePaymentEscrowAccount the root string for the bonds outcome
+	// payment escrow account address (see GetOutcomePaymentEscrowAddress)
+	BondsOutcomePaymentEscrowAccount = "bonds_outcome_payment_escrow_account"
+
 	// QuerierRoute is the querier route for this module's store.
 	QuerierRoute = ModuleName
 
@@ -30,11 +52,39 @@ const (
 //
 // - Bonds: 0x00<bond_token_bytes>
 // - Batches: 0x01<bond_token_bytes>
+// - Batch orders: 0x01<bond_token_bytes>/<order_type_byte><order_id_bytes>
 // - Last batches: 0x02<bond_token_bytes>
+// - Batches history: 0x03<bond_token_bytes>/<height_bytes>
+// - Positions: 0x04<bond_token_bytes>/<address_bytes>
+// - Swapper products: 0x05<bond_token_bytes>
+// - Batch schedule: 0x06<height_bytes><bond_token_bytes>
+// - Limit orders: 0x07<bond_token_bytes>/<order_id_bytes>
+// - Hatch deadlines: 0x08<height_bytes><bond_token_bytes>
+// - Vestings: 0x09<bond_token_bytes>/<address_bytes>
+// - Outcome payment contributions: 0x0A<bond_token_bytes>/<address_bytes>
+var (
+	BondsKeyPrefix                       = []byte{0x00} // key for bonds
+	BatchesKeyPrefix                     = []byte{0x01} // key for batches
+	LastBatchesKeyPrefix                 = []byte{0x02} // key for last batches
+	BatchesHistoryKeyPrefix              = []byte{0x03} // key for batches history
+	PositionsKeyPrefix                   = []byte{0x04} // key for positions
+	SwapperProductsKeyPrefix             = []byte{0x05} // key for swapper products
+	BatchScheduleKeyPrefix               = []byte{0x06} // key for batch schedule
+	LimitOrdersKeyPrefix                 = []byte{0x07} // key for limit orders
+	HatchDeadlinesKeyPrefix              = []byte{0x08} // key for hatch deadlines
+	VestingsKeyPrefix                    = []byte{0x09} // key for vestings
+	OutcomePaymentContributionsKeyPrefix = []byte{0x0A} // key for outcome payment contributions
+)
+
+// The orders of a batch are stored separately from the batch, under the
+// batch's key, with the following prefixes to distinguish the order types:
 var (
-	BondsKeyPrefix       = []byte{0x00} // key for bonds
-	BatchesKeyPrefix     = []byte{0x01} // key for batches
-	LastBatchesKeyPrefix = []byte{0x02} // key for last batches
+	BuyOrdersKeyPrefix             = []byte{0x00} // key for buy orders
+	SellOrdersKeyPrefix            = []byte{0x01} // key for sell orders
+	SwapOrdersKeyPrefix            = []byte{0x02} // key for swap orders
+	BuyWithReserveOrdersKeyPrefix  = []byte{0x03} // key for buy with reserve orders
+	AddLiquidityOrdersKeyPrefix    = []byte{0x04} // key for add liquidity orders
+	RemoveLiquidityOrdersKeyPrefix = []byte{0x05} // key for remove liquidity orders
 )
 
 func GetBondKey(token string) []byte {
@@ -45,6 +95,112 @@ func GetBatchKey(token string) []byte {
 	return append(BatchesKeyPrefix, []byte(token)...)
 }
 
+func GetBatchOrdersPrefix(token string) []byte {
+	return append(BatchesKeyPrefix, []byte(token+"/")...)
+}
+
+func GetBatchOrdersOfTypePrefix(token string, orderTypePrefix []byte) []byte {
+	return append(GetBatchOrdersPrefix(token), orderTypePrefix...)
+}
+
+func GetBatchOrderKey(token string, orderTypePrefix []byte, id uint64) []byte {
+	return append(GetBatchOrdersOfTypePrefix(token, orderTypePrefix), sdk.Uint64ToBigEndian(id)...)
+}
+
 func GetLastBatchKey(token string) []byte {
 	return append(LastBatchesKeyPrefix, []byte(token)...)
 }
+
+func GetBatchesHistoryPrefix(token string) []byte {
+	return append(BatchesHistoryKeyPrefix, []byte(token+"/")...)
+}
+
+func GetBatchHistoryKey(token string, height int64) []byte {
+	return append(GetBatchesHistoryPrefix(token), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetPositionsPrefix(token string) []byte {
+	return append(PositionsKeyPrefix, []byte(token+"/")...)
+}
+
+func GetPositionKey(token string, address sdk.AccAddress) []byte {
+	return append(GetPositionsPrefix(token), address.Bytes()...)
+}
+
+func GetSwapperProductKey(token string) []byte {
+	return append(SwapperProductsKeyPrefix, []byte(token)...)
+}
+
+func GetBatchScheduleHeightPrefix(height int64) []byte {
+	return append(BatchScheduleKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetBatchScheduleKey(height int64, token string) []byte {
+	return append(GetBatchScheduleHeightPrefix(height), []byte(token)...)
+}
+
+func GetLimitOrdersPrefix(token string) []byte {
+	return append(LimitOrdersKeyPrefix, []byte(token+"/")...)
+}
+
+func GetLimitOrderKey(token string, id uint64) []byte {
+	return append(GetLimitOrdersPrefix(token), sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetHatchDeadlinesHeightPrefix(height int64) []byte {
+	return append(HatchDeadlinesKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetHatchDeadlineKey(height int64, token string) []byte {
+	return append(GetHatchDeadlinesHeightPrefix(height), []byte(token)...)
+}
+
+func GetVestingsPrefix(token string) []byte {
+	return append(VestingsKeyPrefix, []byte(token+"/")...)
+}
+
+func GetVestingKey(token string, address sdk.AccAddress) []byte {
+	return append(GetVestingsPrefix(token), address.Bytes()...)
+}
+
+func GetOutcomePaymentContributionsPrefix(token string) []byte {
+	return append(OutcomePaymentContributionsKeyPrefix, []byte(token+"/")...)
+}
+
+func GetOutcomePaymentContributionKey(token string, address sdk.AccAddress) []byte {
+	return append(GetOutcomePaymentContributionsPrefix(token), address.Bytes()...)
+}
+
+// GetReserveAddress returns the address derived for holding the reserve of the
+// bond with the specified token, such that each bond has a separate reserve.
+func GetReserveAddress(token string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(BondsReserveAccount + "/" + token)))
+}
+
+// GetHatchEscrowAddress returns the address derived for holding the funding
+// share of the hatch phase of the augmented bond with the specified token,
+// until the bond's hatch phase ends (only for bonds with a hatch deadline).
+func GetHatchEscrowAddress(token string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(BondsHatchEscrowAccount + "/" + token)))
+}
+
+// GetFundingPoolAddress returns the address derived for holding the funding
+// pool of the augmented bond with the specified token, from which funding is
+// released to the bond's fee address through the bond's funding tap.
+func GetFundingPoolAddress(token string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(BondsFundingPoolAccount + "/" + token)))
+}
+
+// GetVestingEscrowAddress returns the address derived for holding the bond
+// tokens bought in the hatch phase of the augmented bond with the specified
+// token, until these vest and are withdrawn (only for bonds with vesting).
+func GetVestingEscrowAddress(token string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(BondsVestingEscrowAccount + "/" + token)))
+}
+
+// GetOutcomePaymentEscrowAddress returns the address derived for holding the
+// outcome payments made to the bond with the specified token, until the bond
+// settles and these are moved to the bond's reserve.
+func GetOutcomePaymentEscrowAddress(token string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(BondsOutcomePaymentEscrowAccount + "/" + token)))
+}
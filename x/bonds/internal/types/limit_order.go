@@ -0,0 +1,96 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// A LimitOrder rests in the limit order book of a bond, with the tokens that it
+// needs held in escrow, until it is filled, cancelled, or it expires. A limit
+// buy is only filled at buy prices per token of at most its PricesPerToken, and
+// a limit sell at sell prices per token of at least its PricesPerToken, where
+// these prices exclude any fees.
+//
+// The MaxPrices of a limit buy are the total prices (including fees) of the buy
+// at its limit prices, and are held in escrow. The MinReturns of a limit sell
+// are the total returns (excluding fees) of the sell at its limit prices, while
+// the bond tokens to be sold are held in escrow. The ID of a limit order is
+// assigned from the same sequence as the IDs of batch orders.
+type LimitOrder struct {
+	ID             uint64         `json:"id" yaml:"id"`
+	OrderType      string         `json:"order_type" yaml:"order_type"`
+	Address        sdk.AccAddress `json:"address" yaml:"address"`
+	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
+	PricesPerToken sdk.DecCoins   `json:"prices_per_token" yaml:"prices_per_token"`
+	MaxPrices      sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	MinReturns     sdk.Coins      `json:"min_returns" yaml:"min_returns"`
+	ExpiryHeight   int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewLimitBuyOrder(bond Bond, address sdk.AccAddress, amount sdk.Coin,
+	maxPricesPerToken sdk.DecCoins, expiryHeight int64) LimitOrder {
+	reservePrices := MultiplyDecCoinsByInt(maxPricesPerToken, amount.Amount)
+	fundingTaxes := bond.GetFundingTaxes(reservePrices)
+	txFees := bond.GetTxFees(reservePrices)
+	return LimitOrder{
+		OrderType:      AttributeValueLimitBuyOrder,
+		Address:        address,
+		Amount:         amount,
+		PricesPerToken: maxPricesPerToken,
+		MaxPrices:      RoundReservePrices(reservePrices).Add(fundingTaxes...).Add(txFees...),
+		MinReturns:     nil,
+		ExpiryHeight:   expiryHeight,
+	}
+}
+
+func NewLimitSellOrder(bond Bond, address sdk.AccAddress, amount sdk.Coin,
+	minPricesPerToken sdk.DecCoins, expiryHeight int64) LimitOrder {
+	reserveReturns := MultiplyDecCoinsByInt(minPricesPerToken, amount.Amount)
+	reserveReturnsRounded := RoundReserveReturns(reserveReturns)
+	txFees := bond.GetTxFees(reserveReturns)
+	exitFees := bond.GetExitFees(reserveReturns)
+	totalFees := AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	return LimitOrder{
+		OrderType:      AttributeValueLimitSellOrder,
+		Address:        address,
+		Amount:         amount,
+		PricesPerToken: minPricesPerToken,
+		MaxPrices:      nil,
+		MinReturns:     reserveReturnsRounded.Sub(totalFees),
+		ExpiryHeight:   expiryHeight,
+	}
+}
+
+func (lo LimitOrder) IsBuy() bool {
+	return lo.OrderType == AttributeValueLimitBuyOrder
+}
+
+// IsExpired returns true if the limit order has an expiry height and the
+// specified height has reached it. An expiry height of zero never expires.
+func (lo LimitOrder) IsExpired(height int64) bool {
+	return lo.ExpiryHeight != 0 && height >= lo.ExpiryHeight
+}
+
+// GetEscrow returns the tokens held in escrow for the limit order, which are
+// the max prices of a limit buy or the bond tokens of a limit sell.
+func (lo LimitOrder) GetEscrow() sdk.Coins {
+	if lo.IsBuy() {
+		return lo.MaxPrices
+	}
+	return sdk.Coins{lo.Amount}
+}
+
+// ToBuyOrder returns the batch buy order that a limit buy is added to a batch
+// as, which has the same ID as the limit order.
+func (lo LimitOrder) ToBuyOrder() BuyOrder {
+	bo := NewBuyOrder(lo.Address, lo.Amount, lo.MaxPrices)
+	bo.ID = lo.ID
+	return bo
+}
+
+// ToSellOrder returns the batch sell order that a limit sell is added to a
+// batch as, which has the same ID as the limit order.
+func (lo LimitOrder) ToSellOrder() SellOrder {
+	so := NewSellOrder(lo.Address, lo.Amount, lo.MinReturns)
+	so.ID = lo.ID
+	return so
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestNewLimitBuyOrderDefaultValues(t *testing.T) {
+	bond := getValidBond()
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	pricesPerToken := sdk.NewDecCoins(sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr("5.5")))
+
+	// Prices of 55res (10 x 5.5res) plus 1res tx fee (0.1% of 55res, rounded up)
+	lo := NewLimitBuyOrder(bond, address, amount, pricesPerToken, 100)
+
+	require.True(t, lo.IsBuy())
+	require.Equal(t, address, lo.Address)
+	require.Equal(t, amount, lo.Amount)
+	require.Equal(t, pricesPerToken, lo.PricesPerToken)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 56)), lo.MaxPrices)
+	require.Nil(t, lo.MinReturns)
+	require.Equal(t, int64(100), lo.ExpiryHeight)
+	require.Equal(t, lo.MaxPrices, lo.GetEscrow())
+}
+
+func TestNewLimitSellOrderDefaultValues(t *testing.T) {
+	bond := getValidBond()
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	pricesPerToken := sdk.NewDecCoins(sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr("5.5")))
+
+	// Returns of 55res (10 x 5.5res) minus 1res tx fee and 1res exit fee
+	lo := NewLimitSellOrder(bond, address, amount, pricesPerToken, 0)
+
+	require.False(t, lo.IsBuy())
+	require.Equal(t, address, lo.Address)
+	require.Equal(t, amount, lo.Amount)
+	require.Equal(t, pricesPerToken, lo.PricesPerToken)
+	require.Nil(t, lo.MaxPrices)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 53)), lo.MinReturns)
+	require.Equal(t, int64(0), lo.ExpiryHeight)
+	require.Equal(t, sdk.Coins{amount}, lo.GetEscrow())
+}
+
+func TestLimitOrderIsExpired(t *testing.T) {
+	testCases := []struct {
+		expiryHeight int64
+		height       int64
+		isExpired    bool
+	}{
+		{0, 1, false},
+		{0, 1000, false},
+		{10, 9, false},
+		{10, 10, true},
+		{10, 11, true},
+	}
+	for _, tc := range testCases {
+		lo := LimitOrder{ExpiryHeight: tc.expiryHeight}
+		require.Equal(t, tc.isExpired, lo.IsExpired(tc.height))
+	}
+}
+
+func TestLimitOrderToBatchOrderKeepsID(t *testing.T) {
+	bond := getValidBond()
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	pricesPerToken := sdk.NewDecCoins(sdk.NewDecCoinFromDec(reserveToken, sdk.MustNewDecFromStr("5.5")))
+
+	buy := NewLimitBuyOrder(bond, address, amount, pricesPerToken, 0)
+	buy.ID = 7
+	bo := buy.ToBuyOrder()
+	require.Equal(t, uint64(7), bo.ID)
+	require.Equal(t, buy.MaxPrices, bo.MaxPrices)
+	require.Equal(t, amount, bo.Amount)
+
+	sell := NewLimitSellOrder(bond, address, amount, pricesPerToken, 0)
+	sell.ID = 8
+	so := sell.ToSellOrder()
+	require.Equal(t, uint64(8), so.ID)
+	require.Equal(t, sell.MinReturns, so.MinReturns)
+	require.Equal(t, amount, so.Amount)
+}
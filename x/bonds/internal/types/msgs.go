@@ -7,13 +7,28 @@ import (
 )
 
 const (
-	TypeMsgCreateBond         = "create_bond"
-	TypeMsgEditBond           = "edit_bond"
-	TypeMsgBuy                = "buy"
-	TypeMsgSell               = "sell"
-	TypeMsgSwap               = "swap"
-	TypeMsgMakeOutcomePayment = "make_outcome_payment"
-	TypeMsgWithdrawShare      = "withdraw_share"
+	TypeMsgCreateBond             = "create_bond"
+	TypeMsgEditBond               = "edit_bond"
+	TypeMsgBuy                    = "buy"
+	TypeMsgBuyWithReserve         = "buy_with_reserve"
+	TypeMsgSell                   = "sell"
+	TypeMsgSwap                   = "swap"
+	TypeMsgSwapRoute              = "swap_route"
+	TypeMsgAddLiquidity           = "add_liquidity"
+	TypeMsgRemoveLiquidity        = "remove_liquidity"
+	TypeMsgCancelOrder            = "cancel_order"
+	TypeMsgLimitBuy               = "limit_buy"
+	TypeMsgLimitSell              = "limit_sell"
+	TypeMsgCancelLimitOrder       = "cancel_limit_order"
+	TypeMsgMakeOutcomePayment     = "make_outcome_payment"
+	TypeMsgFinaliseOutcomePayment = "finalise_outcome_payment"
+	TypeMsgWithdrawShare          = "withdraw_share"
+	TypeMsgWithdrawVested         = "withdraw_vested"
+	TypeMsgPauseBond              = "pause_bond"
+	TypeMsgResumeBond             = "resume_bond"
+	TypeMsgCloseBond              = "close_bond"
+	TypeMsgWithdrawFunding        = "withdraw_funding"
+	TypeMsgSetFundingTap          = "set_funding_tap"
 )
 
 type MsgCreateBond struct {
@@ -28,6 +43,7 @@ type MsgCreateBond struct {
 	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
 	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
+	MaxSupplyAllocation    string           `json:"max_supply_allocation" yaml:"max_supply_allocation"`
 	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
@@ -35,14 +51,23 @@ type MsgCreateBond struct {
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
 	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	HatchDeadline          int64            `json:"hatch_deadline" yaml:"hatch_deadline"`
+	MinRaise               sdk.Coins        `json:"min_raise" yaml:"min_raise"`
+	VestingCliff           int64            `json:"vesting_cliff" yaml:"vesting_cliff"`
+	VestingDuration        int64            `json:"vesting_duration" yaml:"vesting_duration"`
+	FundingTap             sdk.Coins        `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks       int64            `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+	FundingTaxPercentage   sdk.Dec          `json:"funding_tax_percentage" yaml:"funding_tax_percentage"`
 }
 
 func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress, maxSupply sdk.Coin,
-	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
+	maxSupplyAllocation string, orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
 	allowSell bool, signers []sdk.AccAddress, batchBlocks sdk.Uint,
-	outcomePayment sdk.Coins) MsgCreateBond {
+	outcomePayment sdk.Coins, hatchDeadline int64, minRaise sdk.Coins,
+	vestingCliff, vestingDuration int64, fundingTap sdk.Coins,
+	fundingTapBlocks int64, fundingTaxPercentage sdk.Dec) MsgCreateBond {
 	return MsgCreateBond{
 		Token:                  token,
 		Name:                   name,
@@ -55,6 +80,7 @@ func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 		ExitFeePercentage:      exitFeePercentage,
 		FeeAddress:             feeAddress,
 		MaxSupply:              maxSupply,
+		MaxSupplyAllocation:    maxSupplyAllocation,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
 		SanityMarginPercentage: sanityMarginPercentage,
@@ -62,6 +88,13 @@ func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
 		OutcomePayment:         outcomePayment,
+		HatchDeadline:          hatchDeadline,
+		MinRaise:               minRaise,
+		VestingCliff:           vestingCliff,
+		VestingDuration:        vestingDuration,
+		FundingTap:             fundingTap,
+		FundingTapBlocks:       fundingTapBlocks,
+		FundingTaxPercentage:   fundingTaxPercentage,
 	}
 }
 
@@ -82,7 +115,8 @@ func (msg MsgCreateBond) ValidateBasic() error {
 	} else if strings.TrimSpace(msg.FunctionType) == "" {
 		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Function Type")
 	}
-	// Note: FunctionParameters and OutcomePayment can be empty
+	// Note: FunctionParameters, OutcomePayment, MinRaise, and FundingTap can
+	// be empty
 
 	// Check that bond token is a valid token name
 	err := CheckCoinDenom(msg.Token)
@@ -109,6 +143,10 @@ func (msg MsgCreateBond) ValidateBasic() error {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "order quantity limits are invalid")
 	} else if !msg.OutcomePayment.IsValid() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "outcome payment is invalid")
+	} else if !msg.MinRaise.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min raise is invalid")
+	} else if !msg.FundingTap.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "funding tap is invalid")
 	}
 
 	// Check that max supply denom matches token denom
@@ -116,6 +154,62 @@ func (msg MsgCreateBond) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrMaxSupplyDenomDoesNotMatchTokenDenom, msg.Token)
 	}
 
+	// Validate max supply allocation
+	if err = CheckMaxSupplyAllocation(msg.MaxSupplyAllocation); err != nil {
+		return err
+	}
+
+	// Validate hatch deadline and min raise, which are only available for
+	// augmented bonds, and where the min raise requires a hatch deadline
+	if msg.HatchDeadline < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "HatchDeadline")
+	} else if msg.FunctionType != AugmentedFunction &&
+		(msg.HatchDeadline != 0 || !msg.MinRaise.Empty()) {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, msg.FunctionType)
+	} else if msg.HatchDeadline == 0 && !msg.MinRaise.Empty() {
+		return ErrMinRaiseRequiresHatchDeadline
+	}
+	for _, c := range msg.MinRaise {
+		isReserveToken := false
+		for _, rt := range msg.ReserveTokens {
+			isReserveToken = isReserveToken || c.Denom == rt
+		}
+		if !isReserveToken {
+			return sdkerrors.Wrap(ErrReserveDenomsMismatch, c.Denom)
+		}
+	}
+
+	// Validate vesting cliff and duration, which are only available for
+	// augmented bonds
+	if msg.VestingCliff < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "VestingCliff")
+	} else if msg.VestingDuration < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "VestingDuration")
+	} else if msg.FunctionType != AugmentedFunction &&
+		(msg.VestingCliff != 0 || msg.VestingDuration != 0) {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, msg.FunctionType)
+	}
+
+	// Validate funding tap, which is only available for augmented bonds, and
+	// where the funding tap and funding tap blocks are set together
+	if msg.FundingTapBlocks < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "FundingTapBlocks")
+	} else if msg.FunctionType != AugmentedFunction &&
+		(!msg.FundingTap.Empty() || msg.FundingTapBlocks != 0) {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, msg.FunctionType)
+	} else if msg.FundingTap.Empty() != (msg.FundingTapBlocks == 0) {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FundingTap or FundingTapBlocks")
+	}
+	for _, c := range msg.FundingTap {
+		isReserveToken := false
+		for _, rt := range msg.ReserveTokens {
+			isReserveToken = isReserveToken || c.Denom == rt
+		}
+		if !isReserveToken {
+			return sdkerrors.Wrap(ErrReserveDenomsMismatch, c.Denom)
+		}
+	}
+
 	// Check that Sanity values not negative
 	if msg.SanityRate.IsNegative() {
 		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
@@ -132,6 +226,16 @@ func (msg MsgCreateBond) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrFeesCannotBeOrExceed100Percent, msg.TxFeePercentage.Add(msg.ExitFeePercentage).String())
 	}
 
+	// Check FundingTaxPercentage not negative and less than 100, and that it
+	// is only set for augmented bonds
+	if msg.FundingTaxPercentage.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "FundingTaxPercentage")
+	} else if msg.FundingTaxPercentage.GTE(sdk.NewDec(100)) {
+		return sdkerrors.Wrap(ErrFeesCannotBeOrExceed100Percent, msg.FundingTaxPercentage.String())
+	} else if msg.FunctionType != AugmentedFunction && !msg.FundingTaxPercentage.IsZero() {
+		return sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, msg.FunctionType)
+	}
+
 	// Check that not zero
 	if msg.BatchBlocks.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "BatchBlocks")
@@ -232,16 +336,18 @@ func (msg MsgEditBond) Route() string { return RouterKey }
 func (msg MsgEditBond) Type() string { return TypeMsgEditBond }
 
 type MsgBuy struct {
-	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
-	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
-	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	Buyer        sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices    sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	AllowPartial bool           `json:"allow_partial" yaml:"allow_partial"`
 }
 
-func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) MsgBuy {
+func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins, allowPartial bool) MsgBuy {
 	return MsgBuy{
-		Buyer:     buyer,
-		Amount:    amount,
-		MaxPrices: maxPrices,
+		Buyer:        buyer,
+		Amount:       amount,
+		MaxPrices:    maxPrices,
+		AllowPartial: allowPartial,
 	}
 }
 
@@ -278,15 +384,67 @@ func (msg MsgBuy) Route() string { return RouterKey }
 
 func (msg MsgBuy) Type() string { return TypeMsgBuy }
 
+type MsgBuyWithReserve struct {
+	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Budget    sdk.Coins      `json:"budget" yaml:"budget"`
+}
+
+func NewMsgBuyWithReserve(buyer sdk.AccAddress, bondToken string, budget sdk.Coins) MsgBuyWithReserve {
+	return MsgBuyWithReserve{
+		Buyer:     buyer,
+		BondToken: bondToken,
+		Budget:    budget,
+	}
+}
+
+func (msg MsgBuyWithReserve) ValidateBasic() error {
+	// Check if empty
+	if msg.Buyer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Buyer")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	// Check that budget valid and non zero
+	if !msg.Budget.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "budget is invalid")
+	} else if msg.Budget.Empty() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Budget")
+	}
+
+	return nil
+}
+
+func (msg MsgBuyWithReserve) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBuyWithReserve) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+func (msg MsgBuyWithReserve) Route() string { return RouterKey }
+
+func (msg MsgBuyWithReserve) Type() string { return TypeMsgBuyWithReserve }
+
 type MsgSell struct {
-	Seller sdk.AccAddress `json:"seller" yaml:"seller"`
-	Amount sdk.Coin       `json:"amount" yaml:"amount"`
+	Seller     sdk.AccAddress `json:"seller" yaml:"seller"`
+	Amount     sdk.Coin       `json:"amount" yaml:"amount"`
+	MinReturns sdk.Coins      `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin) MsgSell {
+func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins) MsgSell {
 	return MsgSell{
-		Seller: seller,
-		Amount: amount,
+		Seller:     seller,
+		Amount:     amount,
+		MinReturns: minReturns,
 	}
 }
 
@@ -303,6 +461,11 @@ func (msg MsgSell) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
 	}
 
+	// Check that minReturns valid (can be empty)
+	if !msg.MinReturns.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "minreturns is invalid")
+	}
+
 	return nil
 }
 
@@ -323,14 +486,17 @@ type MsgSwap struct {
 	BondToken string         `json:"bond_token" yaml:"bond_token"`
 	From      sdk.Coin       `json:"from" yaml:"from"`
 	ToToken   string         `json:"to_token" yaml:"to_token"`
+	MinOutput sdk.Coin       `json:"min_output" yaml:"min_output"`
 }
 
-func NewMsgSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin, toToken string) MsgSwap {
+func NewMsgSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin,
+	toToken string, minOutput sdk.Coin) MsgSwap {
 	return MsgSwap{
 		Swapper:   swapper,
 		BondToken: bondToken,
 		From:      from,
 		ToToken:   toToken,
+		MinOutput: minOutput,
 	}
 }
 
@@ -360,6 +526,13 @@ func (msg MsgSwap) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrFromAndToCannotBeTheSameToken, msg.From.Denom)
 	}
 
+	// Validate min output (can be zero) and check that it is in to token
+	if !msg.MinOutput.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min output is invalid")
+	} else if msg.MinOutput.Denom != msg.ToToken {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "min output denom must be %s", msg.ToToken)
+	}
+
 	// Check that non zero
 	if msg.From.Amount.IsZero() {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FromAmount")
@@ -381,15 +554,431 @@ func (msg MsgSwap) Route() string { return RouterKey }
 
 func (msg MsgSwap) Type() string { return TypeMsgSwap }
 
+// MsgSwapRoute swaps the from amount through a route of swapper bonds, with a
+// minimum output that applies to the whole route. The route is not atomic:
+// each hop is performed in its bond's batch, so if a later hop is cancelled
+// (e.g. its minimum output is not met or its bond was closed), the swapper
+// receives the tokens returned by the previous hop, and the fees charged by
+// the completed hops are not refunded.
+type MsgSwapRoute struct {
+	Swapper    sdk.AccAddress `json:"swapper" yaml:"swapper"`
+	BondTokens []string       `json:"bond_tokens" yaml:"bond_tokens"`
+	From       sdk.Coin       `json:"from" yaml:"from"`
+	MinOutput  sdk.Coin       `json:"min_output" yaml:"min_output"`
+}
+
+func NewMsgSwapRoute(swapper sdk.AccAddress, bondTokens []string,
+	from sdk.Coin, minOutput sdk.Coin) MsgSwapRoute {
+	return MsgSwapRoute{
+		Swapper:    swapper,
+		BondTokens: bondTokens,
+		From:       from,
+		MinOutput:  minOutput,
+	}
+}
+
+func (msg MsgSwapRoute) ValidateBasic() error {
+	// Check if empty
+	if msg.Swapper.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Swapper")
+	} else if len(msg.BondTokens) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondTokens")
+	}
+
+	// Validate route (bond tokens must be valid and cannot repeat)
+	if len(msg.BondTokens) > MaxSwapRouteHops {
+		return sdkerrors.Wrapf(ErrInvalidSwapRoute, "route cannot have more than %d hops", MaxSwapRouteHops)
+	}
+	seen := make(map[string]bool)
+	for _, bondToken := range msg.BondTokens {
+		err := CheckCoinDenom(bondToken)
+		if err != nil {
+			return err
+		} else if seen[bondToken] {
+			return sdkerrors.Wrapf(ErrInvalidSwapRoute, "bond %s repeated in route", bondToken)
+		}
+		seen[bondToken] = true
+	}
+
+	// Validate from amount
+	if !msg.From.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "from amount is invalid")
+	}
+
+	// Validate min output (can be zero)
+	if !msg.MinOutput.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min output is invalid")
+	}
+
+	// Check if from and to the same token
+	if msg.From.Denom == msg.MinOutput.Denom {
+		return sdkerrors.Wrap(ErrFromAndToCannotBeTheSameToken, msg.From.Denom)
+	}
+
+	// Check that non zero
+	if msg.From.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FromAmount")
+	}
+
+	return nil
+}
+
+func (msg MsgSwapRoute) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSwapRoute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Swapper}
+}
+
+func (msg MsgSwapRoute) Route() string { return RouterKey }
+
+func (msg MsgSwapRoute) Type() string { return TypeMsgSwapRoute }
+
+type MsgAddLiquidity struct {
+	Provider  sdk.AccAddress `json:"provider" yaml:"provider"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Deposit   sdk.Coins      `json:"deposit" yaml:"deposit"`
+	MinAmount sdk.Coin       `json:"min_amount" yaml:"min_amount"`
+}
+
+func NewMsgAddLiquidity(provider sdk.AccAddress, bondToken string,
+	deposit sdk.Coins, minAmount sdk.Coin) MsgAddLiquidity {
+	return MsgAddLiquidity{
+		Provider:  provider,
+		BondToken: bondToken,
+		Deposit:   deposit,
+		MinAmount: minAmount,
+	}
+}
+
+func (msg MsgAddLiquidity) ValidateBasic() error {
+	// Check if empty
+	if msg.Provider.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Provider")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	// Check that deposit valid and non zero
+	if !msg.Deposit.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "deposit is invalid")
+	} else if msg.Deposit.Empty() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Deposit")
+	}
+
+	// Validate min amount (can be zero) and check that it is in bond token
+	if !msg.MinAmount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min amount is invalid")
+	} else if msg.MinAmount.Denom != msg.BondToken {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "min amount denom must be %s", msg.BondToken)
+	}
+
+	return nil
+}
+
+func (msg MsgAddLiquidity) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAddLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+func (msg MsgAddLiquidity) Route() string { return RouterKey }
+
+func (msg MsgAddLiquidity) Type() string { return TypeMsgAddLiquidity }
+
+type MsgRemoveLiquidity struct {
+	Provider  sdk.AccAddress `json:"provider" yaml:"provider"`
+	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
+	ToToken   string         `json:"to_token" yaml:"to_token"`
+	MinOutput sdk.Coin       `json:"min_output" yaml:"min_output"`
+}
+
+func NewMsgRemoveLiquidity(provider sdk.AccAddress, amount sdk.Coin,
+	toToken string, minOutput sdk.Coin) MsgRemoveLiquidity {
+	return MsgRemoveLiquidity{
+		Provider:  provider,
+		Amount:    amount,
+		ToToken:   toToken,
+		MinOutput: minOutput,
+	}
+}
+
+func (msg MsgRemoveLiquidity) ValidateBasic() error {
+	// Check if empty
+	if msg.Provider.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Provider")
+	} else if strings.TrimSpace(msg.ToToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "ToToken")
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Validate to token
+	err := CheckCoinDenom(msg.ToToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate min output (can be zero) and check that it is in to token
+	if !msg.MinOutput.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min output is invalid")
+	} else if msg.MinOutput.Denom != msg.ToToken {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "min output denom must be %s", msg.ToToken)
+	}
+
+	return nil
+}
+
+func (msg MsgRemoveLiquidity) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRemoveLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Provider}
+}
+
+func (msg MsgRemoveLiquidity) Route() string { return RouterKey }
+
+func (msg MsgRemoveLiquidity) Type() string { return TypeMsgRemoveLiquidity }
+
+type MsgCancelOrder struct {
+	Address   sdk.AccAddress `json:"address" yaml:"address"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	OrderType string         `json:"order_type" yaml:"order_type"`
+	OrderID   uint64         `json:"order_id" yaml:"order_id"`
+}
+
+func NewMsgCancelOrder(address sdk.AccAddress, bondToken, orderType string,
+	orderID uint64) MsgCancelOrder {
+	return MsgCancelOrder{
+		Address:   address,
+		BondToken: bondToken,
+		OrderType: orderType,
+		OrderID:   orderID,
+	}
+}
+
+func (msg MsgCancelOrder) ValidateBasic() error {
+	// Check if empty
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Address")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if strings.TrimSpace(msg.OrderType) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "OrderType")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate order type
+	switch msg.OrderType {
+	case AttributeValueBuyOrder, AttributeValueBuyWithReserveOrder,
+		AttributeValueSellOrder, AttributeValueSwapOrder,
+		AttributeValueAddLiquidityOrder, AttributeValueRemoveLiquidityOrder:
+	default:
+		return sdkerrors.Wrap(ErrUnrecognizedOrderType, msg.OrderType)
+	}
+
+	return nil
+}
+
+func (msg MsgCancelOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+func (msg MsgCancelOrder) Route() string { return RouterKey }
+
+func (msg MsgCancelOrder) Type() string { return TypeMsgCancelOrder }
+
+type MsgLimitBuy struct {
+	Buyer             sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount            sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPricesPerToken sdk.DecCoins   `json:"max_prices_per_token" yaml:"max_prices_per_token"`
+	ExpiryHeight      int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewMsgLimitBuy(buyer sdk.AccAddress, amount sdk.Coin,
+	maxPricesPerToken sdk.DecCoins, expiryHeight int64) MsgLimitBuy {
+	return MsgLimitBuy{
+		Buyer:             buyer,
+		Amount:            amount,
+		MaxPricesPerToken: maxPricesPerToken,
+		ExpiryHeight:      expiryHeight,
+	}
+}
+
+func (msg MsgLimitBuy) ValidateBasic() error {
+	// Check if empty
+	if msg.Buyer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Buyer")
+	} else if msg.MaxPricesPerToken.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MaxPricesPerToken")
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Check that maxPricesPerToken valid
+	if !msg.MaxPricesPerToken.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "maxpricespertoken is invalid")
+	}
+
+	// Check that expiry height not negative (zero means no expiry)
+	if msg.ExpiryHeight < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "ExpiryHeight")
+	}
+
+	return nil
+}
+
+func (msg MsgLimitBuy) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgLimitBuy) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+func (msg MsgLimitBuy) Route() string { return RouterKey }
+
+func (msg MsgLimitBuy) Type() string { return TypeMsgLimitBuy }
+
+type MsgLimitSell struct {
+	Seller            sdk.AccAddress `json:"seller" yaml:"seller"`
+	Amount            sdk.Coin       `json:"amount" yaml:"amount"`
+	MinPricesPerToken sdk.DecCoins   `json:"min_prices_per_token" yaml:"min_prices_per_token"`
+	ExpiryHeight      int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewMsgLimitSell(seller sdk.AccAddress, amount sdk.Coin,
+	minPricesPerToken sdk.DecCoins, expiryHeight int64) MsgLimitSell {
+	return MsgLimitSell{
+		Seller:            seller,
+		Amount:            amount,
+		MinPricesPerToken: minPricesPerToken,
+		ExpiryHeight:      expiryHeight,
+	}
+}
+
+func (msg MsgLimitSell) ValidateBasic() error {
+	// Check if empty
+	if msg.Seller.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Seller")
+	} else if msg.MinPricesPerToken.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MinPricesPerToken")
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Check that minPricesPerToken valid
+	if !msg.MinPricesPerToken.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "minpricespertoken is invalid")
+	}
+
+	// Check that expiry height not negative (zero means no expiry)
+	if msg.ExpiryHeight < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "ExpiryHeight")
+	}
+
+	return nil
+}
+
+func (msg MsgLimitSell) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgLimitSell) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Seller}
+}
+
+func (msg MsgLimitSell) Route() string { return RouterKey }
+
+func (msg MsgLimitSell) Type() string { return TypeMsgLimitSell }
+
+type MsgCancelLimitOrder struct {
+	Address   sdk.AccAddress `json:"address" yaml:"address"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	OrderID   uint64         `json:"order_id" yaml:"order_id"`
+}
+
+func NewMsgCancelLimitOrder(address sdk.AccAddress, bondToken string,
+	orderID uint64) MsgCancelLimitOrder {
+	return MsgCancelLimitOrder{
+		Address:   address,
+		BondToken: bondToken,
+		OrderID:   orderID,
+	}
+}
+
+func (msg MsgCancelLimitOrder) ValidateBasic() error {
+	// Check if empty
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Address")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	return CheckCoinDenom(msg.BondToken)
+}
+
+func (msg MsgCancelLimitOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelLimitOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+func (msg MsgCancelLimitOrder) Route() string { return RouterKey }
+
+func (msg MsgCancelLimitOrder) Type() string { return TypeMsgCancelLimitOrder }
+
 type MsgMakeOutcomePayment struct {
 	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
 	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Amount    sdk.Coins      `json:"amount" yaml:"amount"`
 }
 
-func NewMsgMakeOutcomePayment(sender sdk.AccAddress, bondToken string) MsgMakeOutcomePayment {
+// NewMsgMakeOutcomePayment creates a message to make an outcome payment of the
+// specified amount, or of the remaining outcome payment if the amount is empty.
+func NewMsgMakeOutcomePayment(sender sdk.AccAddress, bondToken string,
+	amount sdk.Coins) MsgMakeOutcomePayment {
 	return MsgMakeOutcomePayment{
 		Sender:    sender,
 		BondToken: bondToken,
+		Amount:    amount,
 	}
 }
 
@@ -407,6 +996,11 @@ func (msg MsgMakeOutcomePayment) ValidateBasic() error {
 		return err
 	}
 
+	// Validate amount (empty amount pays the remaining outcome payment)
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	}
+
 	return nil
 }
 
@@ -422,6 +1016,52 @@ func (msg MsgMakeOutcomePayment) Route() string { return RouterKey }
 
 func (msg MsgMakeOutcomePayment) Type() string { return TypeMsgMakeOutcomePayment }
 
+type MsgFinaliseOutcomePayment struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Editor    sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgFinaliseOutcomePayment(bondToken string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgFinaliseOutcomePayment {
+	return MsgFinaliseOutcomePayment{
+		BondToken: bondToken,
+		Editor:    editor,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgFinaliseOutcomePayment) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	} else if len(msg.Signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgFinaliseOutcomePayment) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgFinaliseOutcomePayment) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgFinaliseOutcomePayment) Route() string { return RouterKey }
+
+func (msg MsgFinaliseOutcomePayment) Type() string { return TypeMsgFinaliseOutcomePayment }
+
 type MsgWithdrawShare struct {
 	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
 	BondToken string         `json:"bond_token" yaml:"bond_token"`
@@ -462,3 +1102,293 @@ func (msg MsgWithdrawShare) GetSigners() []sdk.AccAddress {
 func (msg MsgWithdrawShare) Route() string { return RouterKey }
 
 func (msg MsgWithdrawShare) Type() string { return TypeMsgWithdrawShare }
+
+type MsgWithdrawVested struct {
+	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgWithdrawVested(recipient sdk.AccAddress, bondToken string) MsgWithdrawVested {
+	return MsgWithdrawVested{
+		Recipient: recipient,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgWithdrawVested) ValidateBasic() error {
+	// Check if empty
+	if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Recipient")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgWithdrawVested) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgWithdrawVested) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Recipient}
+}
+
+func (msg MsgWithdrawVested) Route() string { return RouterKey }
+
+func (msg MsgWithdrawVested) Type() string { return TypeMsgWithdrawVested }
+
+type MsgPauseBond struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Editor    sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgPauseBond(bondToken string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgPauseBond {
+	return MsgPauseBond{
+		BondToken: bondToken,
+		Editor:    editor,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgPauseBond) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	} else if len(msg.Signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgPauseBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgPauseBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgPauseBond) Route() string { return RouterKey }
+
+func (msg MsgPauseBond) Type() string { return TypeMsgPauseBond }
+
+type MsgResumeBond struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Editor    sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgResumeBond(bondToken string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgResumeBond {
+	return MsgResumeBond{
+		BondToken: bondToken,
+		Editor:    editor,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgResumeBond) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	} else if len(msg.Signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgResumeBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgResumeBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgResumeBond) Route() string { return RouterKey }
+
+func (msg MsgResumeBond) Type() string { return TypeMsgResumeBond }
+
+type MsgCloseBond struct {
+	BondToken string           `json:"bond_token" yaml:"bond_token"`
+	Editor    sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers   []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgCloseBond(bondToken string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgCloseBond {
+	return MsgCloseBond{
+		BondToken: bondToken,
+		Editor:    editor,
+		Signers:   signers,
+	}
+}
+
+func (msg MsgCloseBond) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	} else if len(msg.Signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgCloseBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCloseBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgCloseBond) Route() string { return RouterKey }
+
+func (msg MsgCloseBond) Type() string { return TypeMsgCloseBond }
+
+type MsgWithdrawFunding struct {
+	Beneficiary sdk.AccAddress `json:"beneficiary" yaml:"beneficiary"`
+	BondToken   string         `json:"bond_token" yaml:"bond_token"`
+	Amount      sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+func NewMsgWithdrawFunding(beneficiary sdk.AccAddress, bondToken string,
+	amount sdk.Coins) MsgWithdrawFunding {
+	return MsgWithdrawFunding{
+		Beneficiary: beneficiary,
+		BondToken:   bondToken,
+		Amount:      amount,
+	}
+}
+
+func (msg MsgWithdrawFunding) ValidateBasic() error {
+	// Check if empty
+	if msg.Beneficiary.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Beneficiary")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	return nil
+}
+
+func (msg MsgWithdrawFunding) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgWithdrawFunding) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Beneficiary}
+}
+
+func (msg MsgWithdrawFunding) Route() string { return RouterKey }
+
+func (msg MsgWithdrawFunding) Type() string { return TypeMsgWithdrawFunding }
+
+type MsgSetFundingTap struct {
+	BondToken        string           `json:"bond_token" yaml:"bond_token"`
+	FundingTap       sdk.Coins        `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks int64            `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+	Editor           sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers          []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgSetFundingTap(bondToken string, fundingTap sdk.Coins,
+	fundingTapBlocks int64, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgSetFundingTap {
+	return MsgSetFundingTap{
+		BondToken:        bondToken,
+		FundingTap:       fundingTap,
+		FundingTapBlocks: fundingTapBlocks,
+		Editor:           editor,
+		Signers:          signers,
+	}
+}
+
+func (msg MsgSetFundingTap) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if msg.FundingTap.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FundingTap")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	} else if len(msg.Signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	// Validate bond token
+	err := CheckCoinDenom(msg.BondToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate funding tap and funding tap blocks
+	if !msg.FundingTap.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "funding tap is invalid")
+	} else if msg.FundingTapBlocks <= 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FundingTapBlocks")
+	}
+
+	return nil
+}
+
+func (msg MsgSetFundingTap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetFundingTap) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgSetFundingTap) Route() string { return RouterKey }
+
+func (msg MsgSetFundingTap) Type() string { return TypeMsgSetFundingTap }
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -175,6 +177,159 @@ func TestValidateBasicMsgCreateMaxSupplyDenomTokenDenomMismatchGivesError(t *tes
 	require.NotNil(t, err)
 }
 
+// MsgCreateBond: Max supply allocation must be recognized
+
+func TestValidateBasicMsgCreateInvalidMaxSupplyAllocationGivesError(t *testing.T) {
+	message := newValidMsgCreateBond()
+	message.MaxSupplyAllocation = "invalid_allocation"
+
+	err := message.ValidateBasic()
+	require.Error(t, err)
+	require.True(t, ErrUnrecognizedMaxSupplyAllocation.Is(err))
+}
+
+// MsgCreateBond: Hatch deadline and min raise must be valid
+
+func TestValidateBasicMsgCreateHatchParametersGivesError(t *testing.T) {
+	testCases := []struct {
+		functionType  string
+		hatchDeadline int64
+		minRaise      sdk.Coins
+		expectedErr   *sdkerrors.Error
+	}{
+		{AugmentedFunction, -1, nil, ErrArgumentCannotBeNegative},
+		{PowerFunction, 10, nil, ErrFunctionNotAvailableForFunctionType},
+		{PowerFunction, 0, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), ErrFunctionNotAvailableForFunctionType},
+		{AugmentedFunction, 0, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), ErrMinRaiseRequiresHatchDeadline},
+		{AugmentedFunction, 10, sdk.NewCoins(sdk.NewInt64Coin("xyz", 10)), ErrReserveDenomsMismatch},
+		{AugmentedFunction, 10, sdk.Coins{sdk.Coin{Denom: reserveToken, Amount: sdk.ZeroInt()}}, sdkerrors.ErrInvalidCoins},
+	}
+	for _, tc := range testCases {
+		message := newValidMsgCreateBond()
+		if tc.functionType == AugmentedFunction {
+			message = newValidMsgCreateAugmentedBond()
+		}
+		message.HatchDeadline = tc.hatchDeadline
+		message.MinRaise = tc.minRaise
+
+		err := message.ValidateBasic()
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err))
+	}
+}
+
+func TestValidateBasicMsgCreateHatchParametersPasses(t *testing.T) {
+	message := newValidMsgCreateAugmentedBond()
+	message.HatchDeadline = 10
+	message.MinRaise = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+
+	require.Nil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateVestingParametersGivesError(t *testing.T) {
+	testCases := []struct {
+		functionType    string
+		vestingCliff    int64
+		vestingDuration int64
+		expectedErr     *sdkerrors.Error
+	}{
+		{AugmentedFunction, -1, 10, ErrArgumentCannotBeNegative},
+		{AugmentedFunction, 10, -1, ErrArgumentCannotBeNegative},
+		{PowerFunction, 10, 0, ErrFunctionNotAvailableForFunctionType},
+		{PowerFunction, 0, 10, ErrFunctionNotAvailableForFunctionType},
+	}
+	for _, tc := range testCases {
+		message := newValidMsgCreateBond()
+		if tc.functionType == AugmentedFunction {
+			message = newValidMsgCreateAugmentedBond()
+		}
+		message.VestingCliff = tc.vestingCliff
+		message.VestingDuration = tc.vestingDuration
+
+		err := message.ValidateBasic()
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err))
+	}
+}
+
+func TestValidateBasicMsgCreateVestingParametersPasses(t *testing.T) {
+	message := newValidMsgCreateAugmentedBond()
+	message.VestingCliff = 10
+	message.VestingDuration = 20
+
+	require.Nil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateFundingTapParametersGivesError(t *testing.T) {
+	validTap := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	otherTap := sdk.NewCoins(sdk.NewInt64Coin("othertoken", 10))
+	invalidTap := sdk.Coins{sdk.Coin{Denom: reserveToken, Amount: sdk.NewInt(-10)}}
+
+	testCases := []struct {
+		functionType     string
+		fundingTap       sdk.Coins
+		fundingTapBlocks int64
+		expectedErr      *sdkerrors.Error
+	}{
+		{AugmentedFunction, invalidTap, 10, sdkerrors.ErrInvalidCoins},
+		{AugmentedFunction, validTap, -1, ErrArgumentCannotBeNegative},
+		{AugmentedFunction, validTap, 0, ErrArgumentCannotBeEmpty},
+		{AugmentedFunction, nil, 10, ErrArgumentCannotBeEmpty},
+		{AugmentedFunction, otherTap, 10, ErrReserveDenomsMismatch},
+		{PowerFunction, validTap, 10, ErrFunctionNotAvailableForFunctionType},
+	}
+	for _, tc := range testCases {
+		message := newValidMsgCreateBond()
+		if tc.functionType == AugmentedFunction {
+			message = newValidMsgCreateAugmentedBond()
+		}
+		message.FundingTap = tc.fundingTap
+		message.FundingTapBlocks = tc.fundingTapBlocks
+
+		err := message.ValidateBasic()
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err))
+	}
+}
+
+func TestValidateBasicMsgCreateFundingTapParametersPasses(t *testing.T) {
+	message := newValidMsgCreateAugmentedBond()
+	message.FundingTap = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	message.FundingTapBlocks = 20
+
+	require.Nil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateFundingTaxPercentageGivesError(t *testing.T) {
+	testCases := []struct {
+		functionType         string
+		fundingTaxPercentage sdk.Dec
+		expectedErr          *sdkerrors.Error
+	}{
+		{AugmentedFunction, sdk.NewDec(-1), ErrArgumentCannotBeNegative},
+		{AugmentedFunction, sdk.NewDec(100), ErrFeesCannotBeOrExceed100Percent},
+		{PowerFunction, sdk.NewDec(1), ErrFunctionNotAvailableForFunctionType},
+	}
+	for _, tc := range testCases {
+		message := newValidMsgCreateBond()
+		if tc.functionType == AugmentedFunction {
+			message = newValidMsgCreateAugmentedBond()
+		}
+		message.FundingTaxPercentage = tc.fundingTaxPercentage
+
+		err := message.ValidateBasic()
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err))
+	}
+}
+
+func TestValidateBasicMsgCreateFundingTaxPercentagePasses(t *testing.T) {
+	message := newValidMsgCreateAugmentedBond()
+	message.FundingTaxPercentage = sdk.MustNewDecFromStr("2.5")
+
+	require.Nil(t, message.ValidateBasic())
+}
+
 // MsgCreateBond: Sanity values must be positive
 
 func TestValidateBasicMsgCreateNegativeSanityRateGivesError(t *testing.T) {
@@ -404,6 +559,59 @@ func TestValidateBasicMsgBuyCorrectlyGivesNoError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+// MsgBuyWithReserve: missing arguments
+
+func TestValidateBasicMsgBuyWithReserveBuyerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+	message.Buyer = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithReserveBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithReserveBudgetArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+	message.Budget = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuyWithReserve: invalid arguments
+
+func TestValidateBasicMsgBuyWithReserveInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+	message.BondToken = "123abc" // starts with number
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithReserveInvalidBudgetGivesError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+	message.Budget[0].Amount = message.Budget[0].Amount.Neg()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuyWithReserve: correct buy with reserve
+
+func TestValidateBasicMsgBuyWithReserveCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgBuyWithReserve()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
 // MsgSell: missing arguments
 
 func TestValidateBasicMsgSellSellerArgumentMissingGivesError(t *testing.T) {
@@ -432,6 +640,14 @@ func TestValidateBasicMsgSellZeroAmountGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgSellInvalidMinReturnsGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.MinReturns = sdk.Coins{sdk.Coin{Denom: "res", Amount: sdk.NewInt(-1)}}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 // MsgSell: correct sell
 
 func TestValidateBasicMsgSellCorrectlyGivesNoError(t *testing.T) {
@@ -504,6 +720,22 @@ func TestValidateBasicMsgSwapFromAndToSameTokenGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgSwapInvalidMinOutputGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.MinOutput.Amount = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapMinOutputNotInToTokenGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.MinOutput.Denom = message.From.Denom
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 // MsgSwap: correct swap
 
 func TestValidateBasicMsgSwapCorrectlyGivesNoError(t *testing.T) {
@@ -512,3 +744,614 @@ func TestValidateBasicMsgSwapCorrectlyGivesNoError(t *testing.T) {
 	err := message.ValidateBasic()
 	require.Nil(t, err)
 }
+
+// MsgSwapRoute: missing arguments
+
+func TestValidateBasicMsgSwapRouteSwapperArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.Swapper = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapRouteBondTokensArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.BondTokens = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSwapRoute: invalid arguments
+
+func TestValidateBasicMsgSwapRouteInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.BondTokens[1] = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapRouteRepeatedBondTokenGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.BondTokens[1] = message.BondTokens[0]
+
+	err := message.ValidateBasic()
+	require.Error(t, err)
+	require.True(t, ErrInvalidSwapRoute.Is(err))
+}
+
+func TestValidateBasicMsgSwapRouteTooManyHopsGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.BondTokens = nil
+	for i := 0; i <= MaxSwapRouteHops; i++ {
+		message.BondTokens = append(message.BondTokens, fmt.Sprintf("token%d", i))
+	}
+
+	err := message.ValidateBasic()
+	require.Error(t, err)
+	require.True(t, ErrInvalidSwapRoute.Is(err))
+}
+
+func TestValidateBasicMsgSwapRouteZeroFromAmountGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.From.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapRouteInvalidMinOutputGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.MinOutput.Amount = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapRouteFromAndToSameTokenGivesError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+	message.MinOutput.Denom = message.From.Denom
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSwapRoute: correct swap route
+
+func TestValidateBasicMsgSwapRouteCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSwapRoute()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgAddLiquidity: missing arguments
+
+func TestValidateBasicMsgAddLiquidityProviderArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+	message.Provider = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgAddLiquidityDepositArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+	message.Deposit = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgAddLiquidity: invalid arguments
+
+func TestValidateBasicMsgAddLiquidityInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+	message.BondToken = "123abc"
+	message.MinAmount.Denom = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgAddLiquidityInvalidMinAmountGivesError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+	message.MinAmount.Amount = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgAddLiquidityMinAmountNotInBondTokenGivesError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+	message.MinAmount.Denom = reserveToken
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgAddLiquidity: correct add liquidity
+
+func TestValidateBasicMsgAddLiquidityCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgAddLiquidity()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgRemoveLiquidity: missing arguments
+
+func TestValidateBasicMsgRemoveLiquidityProviderArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+	message.Provider = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRemoveLiquidityToTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+	message.ToToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRemoveLiquidity: invalid arguments
+
+func TestValidateBasicMsgRemoveLiquidityZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRemoveLiquidityInvalidMinOutputGivesError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+	message.MinOutput.Amount = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRemoveLiquidityMinOutputNotInToTokenGivesError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+	message.MinOutput.Denom = reserveToken2
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRemoveLiquidity: correct remove liquidity
+
+func TestValidateBasicMsgRemoveLiquidityCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgRemoveLiquidity()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelOrder: missing arguments
+
+func TestValidateBasicMsgCancelOrderAddressArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.Address = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderOrderTypeArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.OrderType = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelOrder: invalid arguments
+
+func TestValidateBasicMsgCancelOrderInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.BondToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderInvalidOrderTypeGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.OrderType = "invalid"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelOrder: correct cancel order
+
+func TestValidateBasicMsgCancelOrderCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgLimitBuy: missing arguments
+
+func TestValidateBasicMsgLimitBuyBuyerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.Buyer = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitBuyMaxPricesPerTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.MaxPricesPerToken = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitBuy: invalid arguments
+
+func TestValidateBasicMsgLimitBuyZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitBuyInvalidMaxPricesPerTokenGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.MaxPricesPerToken = sdk.DecCoins{{Denom: reserveToken, Amount: sdk.NewDec(-1)}}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitBuyNegativeExpiryHeightGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.ExpiryHeight = -1
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitBuy: correct limit buy
+
+func TestValidateBasicMsgLimitBuyCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgLimitSell: missing arguments
+
+func TestValidateBasicMsgLimitSellSellerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.Seller = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitSellMinPricesPerTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.MinPricesPerToken = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitSell: invalid arguments
+
+func TestValidateBasicMsgLimitSellZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitSellNegativeExpiryHeightGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.ExpiryHeight = -1
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitSell: correct limit sell
+
+func TestValidateBasicMsgLimitSellCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgLimitSell()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelLimitOrder: missing arguments
+
+func TestValidateBasicMsgCancelLimitOrderAddressArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.Address = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelLimitOrderBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelLimitOrder: invalid arguments
+
+func TestValidateBasicMsgCancelLimitOrderInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.BondToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelLimitOrder: correct cancel limit order
+
+func TestValidateBasicMsgCancelLimitOrderCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgPauseBond: missing arguments
+
+func TestValidateBasicMsgPauseBondBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgPauseBond()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgPauseBondEditorArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgPauseBond()
+	message.Editor = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgPauseBondSignersArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgPauseBond()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgPauseBond: invalid arguments
+
+func TestValidateBasicMsgPauseBondInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgPauseBond()
+	message.BondToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgPauseBond: correct pause bond
+
+func TestValidateBasicMsgPauseBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgPauseBond()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCloseBond: missing arguments
+
+func TestValidateBasicMsgCloseBondBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCloseBond()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCloseBondEditorArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCloseBond()
+	message.Editor = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCloseBondSignersArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCloseBond()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCloseBond: invalid arguments
+
+func TestValidateBasicMsgCloseBondInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgCloseBond()
+	message.BondToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCloseBond: correct close bond
+
+func TestValidateBasicMsgCloseBondCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCloseBond()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgMakeOutcomePayment: invalid arguments
+
+func TestValidateBasicMsgMakeOutcomePaymentInvalidAmountGivesError(t *testing.T) {
+	message := newValidMsgMakeOutcomePayment()
+	message.Amount = sdk.Coins{sdk.Coin{Denom: reserveToken, Amount: sdk.NewInt(-1)}}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgMakeOutcomePayment: correct make outcome payment
+
+func TestValidateBasicMsgMakeOutcomePaymentCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgMakeOutcomePayment()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgMakeOutcomePaymentWithoutAmountGivesNoError(t *testing.T) {
+	message := newValidMsgMakeOutcomePayment()
+	message.Amount = nil
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgFinaliseOutcomePayment: missing arguments
+
+func TestValidateBasicMsgFinaliseOutcomePaymentBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgFinaliseOutcomePayment()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgFinaliseOutcomePaymentEditorArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgFinaliseOutcomePayment()
+	message.Editor = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgFinaliseOutcomePaymentSignersArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgFinaliseOutcomePayment()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgFinaliseOutcomePayment: correct finalise outcome payment
+
+func TestValidateBasicMsgFinaliseOutcomePaymentCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgFinaliseOutcomePayment()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgWithdrawFunding: missing arguments
+
+func TestValidateBasicMsgWithdrawFundingBeneficiaryArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgWithdrawFunding()
+	message.Beneficiary = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgWithdrawFundingBondTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgWithdrawFunding()
+	message.BondToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgWithdrawFunding: invalid arguments
+
+func TestValidateBasicMsgWithdrawFundingInvalidBondTokenGivesError(t *testing.T) {
+	message := newValidMsgWithdrawFunding()
+	message.BondToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgWithdrawFundingZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgWithdrawFunding()
+	message.Amount = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgWithdrawFunding: correct withdraw funding
+
+func TestValidateBasicMsgWithdrawFundingCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgWithdrawFunding()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSetFundingTap: missing arguments
+
+func TestValidateBasicMsgSetFundingTapFundingTapArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSetFundingTap()
+	message.FundingTap = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSetFundingTapSignersArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSetFundingTap()
+	message.Signers = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSetFundingTap: invalid arguments
+
+func TestValidateBasicMsgSetFundingTapZeroFundingTapBlocksGivesError(t *testing.T) {
+	message := newValidMsgSetFundingTap()
+	message.FundingTapBlocks = 0
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSetFundingTap: correct set funding tap
+
+func TestValidateBasicMsgSetFundingTapCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSetFundingTap()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
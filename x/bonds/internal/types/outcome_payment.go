@@ -0,0 +1,27 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OutcomePaymentContribution holds the total amount contributed by an address
+// towards the outcome payment of a bond.
+type OutcomePaymentContribution struct {
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Address   sdk.AccAddress `json:"address" yaml:"address"`
+	Amount    sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+func NewOutcomePaymentContribution(bondToken string, address sdk.AccAddress) OutcomePaymentContribution {
+	return OutcomePaymentContribution{
+		BondToken: bondToken,
+		Address:   address,
+		Amount:    nil,
+	}
+}
+
+// Add adds an outcome payment made by the address to its contribution.
+func (c OutcomePaymentContribution) Add(amount sdk.Coins) OutcomePaymentContribution {
+	c.Amount = c.Amount.Add(amount...)
+	return c
+}
@@ -7,12 +7,16 @@ import (
 
 // Parameter store keys
 var (
-	KeyReservedBondTokens = []byte("ReservedBondTokens")
+	KeyReservedBondTokens    = []byte("ReservedBondTokens")
+	KeyBatchHistoryRetention = []byte("BatchHistoryRetention")
 )
 
 // bonds parameters
 type Params struct {
 	ReservedBondTokens []string `json:"reserved_bond_tokens" yaml:"reserved_bond_tokens"`
+	// Number of blocks for which settled batches are kept in the batches
+	// history of a bond. A value of zero disables the batches history.
+	BatchHistoryRetention uint64 `json:"batch_history_retention" yaml:"batch_history_retention"`
 }
 
 // ParamTable for bonds module.
@@ -20,9 +24,10 @@ func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable().RegisterParamSet(&Params{})
 }
 
-func NewParams(reservedBondTokens []string) Params {
+func NewParams(reservedBondTokens []string, batchHistoryRetention uint64) Params {
 	return Params{
-		ReservedBondTokens: reservedBondTokens,
+		ReservedBondTokens:    reservedBondTokens,
+		BatchHistoryRetention: batchHistoryRetention,
 	}
 
 }
@@ -30,7 +35,8 @@ func NewParams(reservedBondTokens []string) Params {
 // default bonds module parameters
 func DefaultParams() Params {
 	return Params{
-		ReservedBondTokens: []string{}, // no reserved bond tokens
+		ReservedBondTokens:    []string{}, // no reserved bond tokens
+		BatchHistoryRetention: 100000,     // approx. one week of blocks
 	}
 }
 
@@ -41,9 +47,10 @@ func ValidateParams(params Params) error {
 
 func (p Params) String() string {
 	return fmt.Sprintf(`Bonds Params:
-  Reserved Bond Tokens: %s
+  Reserved Bond Tokens:    %s
+  Batch History Retention: %d
 `,
-		p.ReservedBondTokens)
+		p.ReservedBondTokens, p.BatchHistoryRetention)
 }
 
 func validateReservedBondTokens(i interface{}) error {
@@ -54,9 +61,18 @@ func validateReservedBondTokens(i interface{}) error {
 	return nil
 }
 
+func validateBatchHistoryRetention(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
 // Implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
-		{KeyReservedBondTokens, &p.ReservedBondTokens, validateReservedBondTokens},
+		params.NewParamSetPair(KeyReservedBondTokens, &p.ReservedBondTokens, validateReservedBondTokens),
+		params.NewParamSetPair(KeyBatchHistoryRetention, &p.BatchHistoryRetention, validateBatchHistoryRetention),
 	}
 }
@@ -0,0 +1,60 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Position holds the running totals of an address' activity in a bond. The
+// reserve paid and received exclude any fees, which are tracked separately.
+type Position struct {
+	BondToken       string         `json:"bond_token" yaml:"bond_token"`
+	Address         sdk.AccAddress `json:"address" yaml:"address"`
+	TokensBought    sdk.Coin       `json:"tokens_bought" yaml:"tokens_bought"`
+	TokensSold      sdk.Coin       `json:"tokens_sold" yaml:"tokens_sold"`
+	TokensWithdrawn sdk.Coin       `json:"tokens_withdrawn" yaml:"tokens_withdrawn"`
+	ReservePaid     sdk.Coins      `json:"reserve_paid" yaml:"reserve_paid"`
+	ReserveReceived sdk.Coins      `json:"reserve_received" yaml:"reserve_received"`
+	FeesPaid        sdk.Coins      `json:"fees_paid" yaml:"fees_paid"`
+	SharesWithdrawn sdk.Coins      `json:"shares_withdrawn" yaml:"shares_withdrawn"`
+}
+
+func NewPosition(bondToken string, address sdk.AccAddress) Position {
+	return Position{
+		BondToken:       bondToken,
+		Address:         address,
+		TokensBought:    sdk.NewInt64Coin(bondToken, 0),
+		TokensSold:      sdk.NewInt64Coin(bondToken, 0),
+		TokensWithdrawn: sdk.NewInt64Coin(bondToken, 0),
+		ReservePaid:     sdk.NewCoins(),
+		ReserveReceived: sdk.NewCoins(),
+		FeesPaid:        sdk.NewCoins(),
+		SharesWithdrawn: sdk.NewCoins(),
+	}
+}
+
+func (p Position) AddBuy(tokensBought sdk.Coin, reservePaid, feesPaid sdk.Coins) Position {
+	p.TokensBought = p.TokensBought.Add(tokensBought)
+	p.ReservePaid = p.ReservePaid.Add(reservePaid...)
+	p.FeesPaid = p.FeesPaid.Add(feesPaid...)
+	return p
+}
+
+func (p Position) AddSell(tokensSold sdk.Coin, reserveReceived, feesPaid sdk.Coins) Position {
+	p.TokensSold = p.TokensSold.Add(tokensSold)
+	p.ReserveReceived = p.ReserveReceived.Add(reserveReceived...)
+	p.FeesPaid = p.FeesPaid.Add(feesPaid...)
+	return p
+}
+
+func (p Position) AddSwap(reservePaid, reserveReceived, feesPaid sdk.Coins) Position {
+	p.ReservePaid = p.ReservePaid.Add(reservePaid...)
+	p.ReserveReceived = p.ReserveReceived.Add(reserveReceived...)
+	p.FeesPaid = p.FeesPaid.Add(feesPaid...)
+	return p
+}
+
+func (p Position) AddShareWithdrawal(tokensWithdrawn sdk.Coin, shareWithdrawn sdk.Coins) Position {
+	p.TokensWithdrawn = p.TokensWithdrawn.Add(tokensWithdrawn)
+	p.SharesWithdrawn = p.SharesWithdrawn.Add(shareWithdrawn...)
+	return p
+}
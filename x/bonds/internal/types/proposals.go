@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	ProposalTypePauseBond  = "PauseBond"
+	ProposalTypeResumeBond = "ResumeBond"
+)
+
+var (
+	_ govtypes.Content = PauseBondProposal{}
+	_ govtypes.Content = ResumeBondProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypePauseBond)
+	govtypes.RegisterProposalTypeCodec(PauseBondProposal{}, "bonds/PauseBondProposal")
+	govtypes.RegisterProposalType(ProposalTypeResumeBond)
+	govtypes.RegisterProposalTypeCodec(ResumeBondProposal{}, "bonds/ResumeBondProposal")
+}
+
+// PauseBondProposal is a governance proposal to move a bond into the PAUSED
+// state, in which no new orders are accepted and batches are not performed.
+type PauseBondProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	BondToken   string `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewPauseBondProposal(title, description, bondToken string) PauseBondProposal {
+	return PauseBondProposal{
+		Title:       title,
+		Description: description,
+		BondToken:   bondToken,
+	}
+}
+
+// nolint
+func (p PauseBondProposal) GetTitle() string       { return p.Title }
+func (p PauseBondProposal) GetDescription() string { return p.Description }
+func (p PauseBondProposal) ProposalRoute() string  { return RouterKey }
+func (p PauseBondProposal) ProposalType() string   { return ProposalTypePauseBond }
+
+func (p PauseBondProposal) ValidateBasic() error {
+	if strings.TrimSpace(p.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if err := CheckCoinDenom(p.BondToken); err != nil {
+		return err
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p PauseBondProposal) String() string {
+	return fmt.Sprintf(`Pause Bond Proposal:
+  Title:       %s
+  Description: %s
+  Bond Token:  %s
+`, p.Title, p.Description, p.BondToken)
+}
+
+// ResumeBondProposal is a governance proposal to move a PAUSED bond back into
+// the state that it was in before being paused.
+type ResumeBondProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	BondToken   string `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewResumeBondProposal(title, description, bondToken string) ResumeBondProposal {
+	return ResumeBondProposal{
+		Title:       title,
+		Description: description,
+		BondToken:   bondToken,
+	}
+}
+
+// nolint
+func (p ResumeBondProposal) GetTitle() string       { return p.Title }
+func (p ResumeBondProposal) GetDescription() string { return p.Description }
+func (p ResumeBondProposal) ProposalRoute() string  { return RouterKey }
+func (p ResumeBondProposal) ProposalType() string   { return ProposalTypeResumeBond }
+
+func (p ResumeBondProposal) ValidateBasic() error {
+	if strings.TrimSpace(p.BondToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BondToken")
+	} else if err := CheckCoinDenom(p.BondToken); err != nil {
+		return err
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p ResumeBondProposal) String() string {
+	return fmt.Sprintf(`Resume Bond Proposal:
+  Title:       %s
+  Description: %s
+  Bond Token:  %s
+`, p.Title, p.Description, p.BondToken)
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBasicPauseBondProposalBondTokenArgumentMissingGivesError(t *testing.T) {
+	proposal := newValidPauseBondProposal()
+	proposal.BondToken = ""
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicPauseBondProposalInvalidBondTokenGivesError(t *testing.T) {
+	proposal := newValidPauseBondProposal()
+	proposal.BondToken = "123abc"
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicPauseBondProposalTitleArgumentMissingGivesError(t *testing.T) {
+	proposal := newValidPauseBondProposal()
+	proposal.Title = ""
+
+	err := proposal.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicPauseBondProposalCorrectlyGivesNoError(t *testing.T) {
+	proposal := newValidPauseBondProposal()
+
+	err := proposal.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestPauseAndResumeBondProposalTypesAreRegistered(t *testing.T) {
+	require.True(t, govtypes.IsValidProposalType(ProposalTypePauseBond))
+	require.True(t, govtypes.IsValidProposalType(ProposalTypeResumeBond))
+
+	var content govtypes.Content = newValidPauseBondProposal()
+	bz := govtypes.ModuleCdc.MustMarshalBinaryBare(&content)
+
+	var decoded govtypes.Content
+	govtypes.ModuleCdc.MustUnmarshalBinaryBare(bz, &decoded)
+	require.Equal(t, content, decoded)
+}
@@ -14,6 +14,7 @@ func (b QueryBonds) String() string {
 type QueryBuyPrice struct {
 	AdjustedSupply sdk.Coin  `json:"adjusted_supply" yaml:"asdjusted_supply"`
 	Prices         sdk.Coins `json:"prices" yaml:"prices"`
+	FundingTaxes   sdk.Coins `json:"funding_taxes" yaml:"funding_taxes"`
 	TxFees         sdk.Coins `json:"tx_fees" yaml:"tx_fees"`
 	TotalPrices    sdk.Coins `json:"total_prices" yaml:"total_prices"`
 	TotalFees      sdk.Coins `json:"total_fees" yaml:"total_fees"`
@@ -26,9 +27,96 @@ type QuerySellReturn struct {
 	ExitFees       sdk.Coins `json:"exit_fees" yaml:"exit_fees"`
 	TotalReturns   sdk.Coins `json:"total_returns" yaml:"total_returns"`
 	TotalFees      sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	MinReturns     sdk.Coins `json:"min_returns" yaml:"min_returns"`
+	MinReturnsMet  bool      `json:"min_returns_met" yaml:"min_returns_met"`
 }
 
 type QuerySwapReturn struct {
 	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
 	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	MinOutput    sdk.Coin  `json:"min_output" yaml:"min_output"`
+	MinOutputMet bool      `json:"min_output_met" yaml:"min_output_met"`
+}
+
+// QuerySwapRouteReturn holds the estimated returns of a swap through a route of
+// swapper bonds, where the returns of each hop are the input of the next hop.
+type QuerySwapRouteReturn struct {
+	BondTokens   []string   `json:"bond_tokens" yaml:"bond_tokens"`
+	HopReturns   []sdk.Coin `json:"hop_returns" yaml:"hop_returns"`
+	TotalReturns sdk.Coins  `json:"total_returns" yaml:"total_returns"`
+	TotalFees    sdk.Coins  `json:"total_fees" yaml:"total_fees"`
+}
+
+// QueryAddLiquidityReturn holds the estimated result of adding a deposit of
+// liquidity to a swapper bond, where the Swap is the part of the deposit that
+// is swapped for the other reserve token and the Returned amounts are what is
+// left over of the deposit after buying the bond tokens.
+type QueryAddLiquidityReturn struct {
+	Swap        sdk.Coin  `json:"swap" yaml:"swap"`
+	SwapReturns sdk.Coins `json:"swap_returns" yaml:"swap_returns"`
+	Amount      sdk.Coin  `json:"amount" yaml:"amount"`
+	Prices      sdk.Coins `json:"prices" yaml:"prices"`
+	TotalFees   sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	Returned    sdk.Coins `json:"returned" yaml:"returned"`
+}
+
+// QueryRemoveLiquidityReturn holds the estimated result of removing liquidity
+// from a swapper bond in a single reserve token, where the Swap is the part of
+// the returns of the burn that is swapped for the to token.
+type QueryRemoveLiquidityReturn struct {
+	Swap         sdk.Coin  `json:"swap" yaml:"swap"`
+	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
+	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
+	MinOutput    sdk.Coin  `json:"min_output" yaml:"min_output"`
+	MinOutputMet bool      `json:"min_output_met" yaml:"min_output_met"`
+}
+
+// QueryVesting holds the vesting of an address in a bond, along with the
+// amounts of its bond tokens that have and have not yet vested, and the amount
+// that has vested but has not yet been withdrawn.
+type QueryVesting struct {
+	Vesting      Vesting  `json:"vesting" yaml:"vesting"`
+	Vested       sdk.Coin `json:"vested" yaml:"vested"`
+	Unvested     sdk.Coin `json:"unvested" yaml:"unvested"`
+	Withdrawable sdk.Coin `json:"withdrawable" yaml:"withdrawable"`
+}
+
+// QueryFundingPool holds the address and balance of the funding pool of a bond,
+// along with the bond's funding tap.
+type QueryFundingPool struct {
+	Address          sdk.AccAddress `json:"address" yaml:"address"`
+	Balance          sdk.Coins      `json:"balance" yaml:"balance"`
+	FundingTap       sdk.Coins      `json:"funding_tap" yaml:"funding_tap"`
+	FundingTapBlocks int64          `json:"funding_tap_blocks" yaml:"funding_tap_blocks"`
+}
+
+// QueryNextFundingWithdrawal holds the next withdrawal allowed from the
+// funding pool of a bond, i.e. the earliest height at which funding can be
+// withdrawn and the max amount that can be withdrawn at that height.
+type QueryNextFundingWithdrawal struct {
+	Height int64     `json:"height" yaml:"height"`
+	Amount sdk.Coins `json:"amount" yaml:"amount"`
+}
+
+// QueryOutcomePayments holds the outcome payment of a bond, the total received
+// so far and the amount still remaining, along with the contributions made by
+// each payer.
+type QueryOutcomePayments struct {
+	OutcomePayment sdk.Coins                    `json:"outcome_payment" yaml:"outcome_payment"`
+	Received       sdk.Coins                    `json:"received" yaml:"received"`
+	Remaining      sdk.Coins                    `json:"remaining" yaml:"remaining"`
+	Contributions  []OutcomePaymentContribution `json:"contributions" yaml:"contributions"`
+}
+
+// QueryOrder holds an order found in the current batch or the last batch of a
+// bond. Only the field matching the order type is set.
+type QueryOrder struct {
+	OrderType            string                `json:"order_type" yaml:"order_type"`
+	InLastBatch          bool                  `json:"in_last_batch" yaml:"in_last_batch"`
+	BuyOrder             *BuyOrder             `json:"buy_order,omitempty" yaml:"buy_order,omitempty"`
+	BuyWithReserveOrder  *BuyWithReserveOrder  `json:"buy_with_reserve_order,omitempty" yaml:"buy_with_reserve_order,omitempty"`
+	SellOrder            *SellOrder            `json:"sell_order,omitempty" yaml:"sell_order,omitempty"`
+	SwapOrder            *SwapOrder            `json:"swap_order,omitempty" yaml:"swap_order,omitempty"`
+	AddLiquidityOrder    *AddLiquidityOrder    `json:"add_liquidity_order,omitempty" yaml:"add_liquidity_order,omitempty"`
+	RemoveLiquidityOrder *RemoveLiquidityOrder `json:"remove_liquidity_order,omitempty" yaml:"remove_liquidity_order,omitempty"`
 }
@@ -46,6 +46,15 @@ func CheckNoOfReserveTokens(resTokens []string, fnType string) error {
 	return nil
 }
 
+func CheckMaxSupplyAllocation(allocation string) error {
+	switch allocation {
+	case NoAllocation, ProRataAllocation, FirstComeAllocation:
+		return nil
+	default:
+		return sdkerrors.Wrap(ErrUnrecognizedMaxSupplyAllocation, allocation)
+	}
+}
+
 func CheckCoinDenom(denom string) (err error) {
 	coin, err2 := sdk.ParseCoin("0" + denom)
 	if err2 != nil {
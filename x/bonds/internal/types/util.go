@@ -91,6 +91,16 @@ func AdjustFees(fees sdk.Coins, maxFees sdk.Coins) sdk.Coins {
 	return fees.Sub(extraFees)
 }
 
+// MinCoins returns, for each denomination in a, the smaller of the amounts in
+// a and b (excluding any denominations for which this amount is zero).
+func MinCoins(a, b sdk.Coins) sdk.Coins {
+	min := sdk.NewCoins()
+	for _, c := range a {
+		min = min.Add(sdk.NewCoin(c.Denom, sdk.MinInt(c.Amount, b.AmountOf(c.Denom))))
+	}
+	return min
+}
+
 func AccAddressesToString(addresses []sdk.AccAddress) (result string) {
 	result = "["
 	for _, a := range addresses {
@@ -0,0 +1,98 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingEntry holds an amount of bond tokens bought in the hatch phase of a
+// bond, which start vesting at the block height at which they were bought.
+type VestingEntry struct {
+	StartHeight int64    `json:"start_height" yaml:"start_height"`
+	Amount      sdk.Coin `json:"amount" yaml:"amount"`
+}
+
+// GetUnvested returns the amount of the entry's bond tokens that have not yet
+// vested at the specified height. None of the tokens vest until the cliff has
+// passed, after which they vest linearly over the vesting duration.
+func (e VestingEntry) GetUnvested(height, cliff, duration int64) sdk.Coin {
+	elapsed := height - e.StartHeight - cliff
+	if elapsed < 0 {
+		return e.Amount
+	} else if elapsed >= duration {
+		return sdk.NewCoin(e.Amount.Denom, sdk.ZeroInt())
+	}
+
+	vested := e.Amount.Amount.MulRaw(elapsed).QuoRaw(duration)
+	return sdk.NewCoin(e.Amount.Denom, e.Amount.Amount.Sub(vested))
+}
+
+// Vesting holds the bond tokens bought by an address in the hatch phase of a
+// bond, which are held in escrow until they have vested and are withdrawn by
+// the address, along with the amount already withdrawn.
+type Vesting struct {
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Address   sdk.AccAddress `json:"address" yaml:"address"`
+	Entries   []VestingEntry `json:"entries" yaml:"entries"`
+	Withdrawn sdk.Coin       `json:"withdrawn" yaml:"withdrawn"`
+}
+
+func NewVesting(bondToken string, address sdk.AccAddress) Vesting {
+	return Vesting{
+		BondToken: bondToken,
+		Address:   address,
+		Entries:   nil,
+		Withdrawn: sdk.NewCoin(bondToken, sdk.ZeroInt()),
+	}
+}
+
+// AddEntry adds bond tokens bought at the specified height to the vesting,
+// merging them into the last entry if it was added at the same height.
+func (v Vesting) AddEntry(height int64, amount sdk.Coin) Vesting {
+	entries := make([]VestingEntry, len(v.Entries))
+	copy(entries, v.Entries)
+
+	if last := len(entries) - 1; last >= 0 && entries[last].StartHeight == height {
+		entries[last].Amount = entries[last].Amount.Add(amount)
+	} else {
+		entries = append(entries, VestingEntry{StartHeight: height, Amount: amount})
+	}
+
+	v.Entries = entries
+	return v
+}
+
+// GetTotal returns the total amount of bond tokens in the vesting, including
+// any that have already vested.
+func (v Vesting) GetTotal() sdk.Coin {
+	total := sdk.NewCoin(v.BondToken, sdk.ZeroInt())
+	for _, e := range v.Entries {
+		total = total.Add(e.Amount)
+	}
+	return total
+}
+
+// GetUnvested returns the amount of bond tokens in the vesting that have not
+// yet vested at the specified height.
+func (v Vesting) GetUnvested(height, cliff, duration int64) sdk.Coin {
+	unvested := sdk.NewCoin(v.BondToken, sdk.ZeroInt())
+	for _, e := range v.Entries {
+		unvested = unvested.Add(e.GetUnvested(height, cliff, duration))
+	}
+	return unvested
+}
+
+// GetWithdrawable returns the amount of bond tokens in the vesting that have
+// vested at the specified height but have not yet been withdrawn.
+func (v Vesting) GetWithdrawable(height, cliff, duration int64) sdk.Coin {
+	vested := v.GetTotal().Sub(v.GetUnvested(height, cliff, duration))
+	if vested.IsLT(v.Withdrawn) {
+		return sdk.NewCoin(v.BondToken, sdk.ZeroInt())
+	}
+	return vested.Sub(v.Withdrawn)
+}
+
+// AddWithdrawal records that the amount of vested bond tokens was withdrawn.
+func (v Vesting) AddWithdrawal(amount sdk.Coin) Vesting {
+	v.Withdrawn = v.Withdrawn.Add(amount)
+	return v
+}
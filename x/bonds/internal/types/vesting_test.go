@@ -0,0 +1,79 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestVestingEntryGetUnvested(t *testing.T) {
+	// Entry of 100 tokens bought at height 10, with a cliff of 5 blocks and
+	// a duration of 20 blocks (i.e. fully vested at height 35)
+	entry := VestingEntry{StartHeight: 10, Amount: sdk.NewInt64Coin(initToken, 100)}
+	cliff := int64(5)
+	duration := int64(20)
+
+	testCases := []struct {
+		height   int64
+		unvested int64
+	}{
+		{10, 100}, // start height
+		{14, 100}, // before cliff
+		{15, 100}, // at cliff
+		{16, 95},  // 1/20 vested
+		{25, 50},  // 10/20 vested
+		{34, 5},   // 19/20 vested
+		{35, 0},   // fully vested
+		{100, 0},  // fully vested
+	}
+	for _, tc := range testCases {
+		unvested := entry.GetUnvested(tc.height, cliff, duration)
+		require.Equal(t, sdk.NewInt64Coin(initToken, tc.unvested), unvested)
+	}
+}
+
+func TestVestingEntryGetUnvestedWithoutDuration(t *testing.T) {
+	// With no duration, all of the tokens vest once the cliff has passed
+	entry := VestingEntry{StartHeight: 10, Amount: sdk.NewInt64Coin(initToken, 100)}
+
+	require.Equal(t, int64(100), entry.GetUnvested(14, 5, 0).Amount.Int64())
+	require.Equal(t, int64(0), entry.GetUnvested(15, 5, 0).Amount.Int64())
+}
+
+func TestVestingAddEntry(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	vesting := NewVesting(initToken, address)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 0), vesting.GetTotal())
+
+	// Entries added at the same height are merged
+	vesting = vesting.AddEntry(10, sdk.NewInt64Coin(initToken, 100))
+	vesting = vesting.AddEntry(10, sdk.NewInt64Coin(initToken, 50))
+	vesting = vesting.AddEntry(20, sdk.NewInt64Coin(initToken, 20))
+
+	expectedEntries := []VestingEntry{
+		{StartHeight: 10, Amount: sdk.NewInt64Coin(initToken, 150)},
+		{StartHeight: 20, Amount: sdk.NewInt64Coin(initToken, 20)},
+	}
+	require.Equal(t, expectedEntries, vesting.Entries)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 170), vesting.GetTotal())
+
+	// At height 20, first entry is 10/10 vested and second is 0/10 vested
+	require.Equal(t, sdk.NewInt64Coin(initToken, 20), vesting.GetUnvested(20, 0, 10))
+}
+
+func TestVestingGetWithdrawable(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	vesting := NewVesting(initToken, address)
+	vesting = vesting.AddEntry(10, sdk.NewInt64Coin(initToken, 100))
+
+	// At height 15, half of the tokens have vested, and none withdrawn yet
+	require.Equal(t, sdk.NewInt64Coin(initToken, 50), vesting.GetWithdrawable(15, 0, 10))
+
+	// Withdrawn tokens are no longer withdrawable
+	vesting = vesting.AddWithdrawal(sdk.NewInt64Coin(initToken, 50))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 50), vesting.Withdrawn)
+	require.True(t, vesting.GetWithdrawable(15, 0, 10).IsZero())
+	require.Equal(t, sdk.NewInt64Coin(initToken, 30), vesting.GetWithdrawable(18, 0, 10))
+	require.Equal(t, sdk.NewInt64Coin(initToken, 50), vesting.GetWithdrawable(20, 0, 10))
+}
@@ -0,0 +1,56 @@
+package bonds
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/ixoworld/bonds/x/bonds/client/cli"
+	"github.com/ixoworld/bonds/x/bonds/client/rest"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+)
+
+var (
+	PauseBondProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitPauseBondProposal, rest.PauseBondProposalRESTHandler)
+	ResumeBondProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitResumeBondProposal, rest.ResumeBondProposalRESTHandler)
+)
+
+func NewBondsProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.PauseBondProposal:
+			return handlePauseBondProposal(ctx, k, c)
+		case types.ResumeBondProposal:
+			return handleResumeBondProposal(ctx, k, c)
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized bonds proposal content type: %T", c)
+		}
+	}
+}
+
+func handlePauseBondProposal(ctx sdk.Context, k keeper.Keeper, p types.PauseBondProposal) error {
+	err := k.PauseBond(ctx, p.BondToken, types.PausedByGovernance)
+	if err != nil {
+		return err
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s paused by governance proposal", p.BondToken))
+	return nil
+}
+
+func handleResumeBondProposal(ctx sdk.Context, k keeper.Keeper, p types.ResumeBondProposal) error {
+	err := k.ResumeBond(ctx, p.BondToken, types.PausedByGovernance)
+	if err != nil {
+		return err
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond %s resumed by governance proposal", p.BondToken))
+	return nil
+}
@@ -0,0 +1,89 @@
+package bonds_test
+
+import (
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/ixoworld/bonds/x/bonds"
+	"github.com/ixoworld/bonds/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseAndResumeBondProposals(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewBondsProposalHandler(app.BondsKeeper)
+
+	// Pausing a non-existent bond fails
+	err := ph(ctx, types.NewPauseBondProposal("title", "description", token))
+	require.Error(t, err)
+
+	// Create bond
+	h(ctx, newValidMsgCreateBond())
+
+	// Pause bond
+	err = ph(ctx, types.NewPauseBondProposal("title", "description", token))
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.PausedState, bond.State)
+	require.Equal(t, types.OpenState, bond.StateBeforePause)
+	require.Equal(t, types.PausedByGovernance, bond.PausedBy)
+
+	// Resume bond
+	err = ph(ctx, types.NewResumeBondProposal("title", "description", token))
+	require.NoError(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Resuming a bond that is not paused fails
+	err = ph(ctx, types.NewResumeBondProposal("title", "description", token))
+	require.Error(t, err)
+}
+
+func TestSignersCannotResumeBondPausedByGovernance(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewBondsProposalHandler(app.BondsKeeper)
+
+	// Create bond and pause it by governance proposal
+	h(ctx, newValidMsgCreateBond())
+	err := ph(ctx, types.NewPauseBondProposal("title", "description", token))
+	require.NoError(t, err)
+
+	// Signers cannot resume the bond
+	_, err = h(ctx, newValidMsgResumeBond())
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.Equal(t, types.PausedState, app.BondsKeeper.MustGetBond(ctx, token).State)
+
+	// Governance can resume the bond
+	err = ph(ctx, types.NewResumeBondProposal("title", "description", token))
+	require.NoError(t, err)
+	bond := app.BondsKeeper.MustGetBond(ctx, token)
+	require.Equal(t, types.OpenState, bond.State)
+	require.Equal(t, "", bond.PausedBy)
+}
+
+func TestGovernanceCanResumeBondPausedBySigners(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := bonds.NewHandler(app.BondsKeeper)
+	ph := bonds.NewBondsProposalHandler(app.BondsKeeper)
+
+	// Create bond and pause it by its signers
+	h(ctx, newValidMsgCreateBond())
+	_, err := h(ctx, newValidMsgPauseBond())
+	require.NoError(t, err)
+	require.Equal(t, types.PausedBySigners, app.BondsKeeper.MustGetBond(ctx, token).PausedBy)
+
+	// Governance can resume the bond
+	err = ph(ctx, types.NewResumeBondProposal("title", "description", token))
+	require.NoError(t, err)
+	require.Equal(t, types.OpenState, app.BondsKeeper.MustGetBond(ctx, token).State)
+}
+
+func TestUnrecognizedProposalFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ph := bonds.NewBondsProposalHandler(app.BondsKeeper)
+
+	err := ph(ctx, govtypes.NewTextProposal("title", "description"))
+	require.Error(t, err)
+}
@@ -31,6 +31,36 @@ func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
 		cdc.MustUnmarshalBinaryBare(kvB.Value, &batchB)
 		return fmt.Sprintf("%v\n%v", batchA, batchB)
 
+	case bytes.Equal(kvA.Key[:1], types.BatchesHistoryKeyPrefix):
+		var settledBatchA, settledBatchB types.SettledBatch
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &settledBatchA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &settledBatchB)
+		return fmt.Sprintf("%v\n%v", settledBatchA, settledBatchB)
+
+	case bytes.Equal(kvA.Key[:1], types.PositionsKeyPrefix):
+		var positionA, positionB types.Position
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &positionA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &positionB)
+		return fmt.Sprintf("%v\n%v", positionA, positionB)
+
+	case bytes.Equal(kvA.Key[:1], types.LimitOrdersKeyPrefix):
+		var limitOrderA, limitOrderB types.LimitOrder
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &limitOrderA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &limitOrderB)
+		return fmt.Sprintf("%v\n%v", limitOrderA, limitOrderB)
+
+	case bytes.Equal(kvA.Key[:1], types.VestingsKeyPrefix):
+		var vestingA, vestingB types.Vesting
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &vestingA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &vestingB)
+		return fmt.Sprintf("%v\n%v", vestingA, vestingB)
+
+	case bytes.Equal(kvA.Key[:1], types.OutcomePaymentContributionsKeyPrefix):
+		var contributionA, contributionB types.OutcomePaymentContribution
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &contributionA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &contributionB)
+		return fmt.Sprintf("%v\n%v", contributionA, contributionB)
+
 	default:
 		panic(fmt.Sprintf("invalid %s key prefix %X", types.ModuleName, kvA.Key[:1]))
 	}
@@ -38,6 +38,7 @@ func TestDecodeStore(t *testing.T) {
 	exitFeePercentage := sdk.MustNewDecFromStr("0.2")
 	feeAddress := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	maxSupply := sdk.NewInt64Coin(token, 10000)
+	maxSupplyAllocation := types.ProRataAllocation
 	orderQuantityLimits := sdk.NewCoins(
 		sdk.NewInt64Coin("token1", 1),
 		sdk.NewInt64Coin("token2", 2),
@@ -53,14 +54,30 @@ func TestDecodeStore(t *testing.T) {
 		sdk.NewInt64Coin("token2", 2),
 		sdk.NewInt64Coin("token3", 3),
 	)
+	hatchDeadline := int64(100)
+	minRaise := sdk.NewCoins(sdk.NewInt64Coin("token1", 10))
+	vestingCliff := int64(10)
+	vestingDuration := int64(20)
+	fundingTap := sdk.NewCoins(sdk.NewInt64Coin("token1", 5))
+	fundingTapBlocks := int64(30)
+	fundingTaxPercentage := sdk.MustNewDecFromStr("0.5")
 	state := "dummy_state"
 
 	bond := types.NewBond(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
-		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		feeAddress, maxSupply, maxSupplyAllocation, orderQuantityLimits, sanityRate, sanityMarginPercentage,
+		allowSell, signers, batchBlocks, outcomePayment, hatchDeadline, minRaise,
+		vestingCliff, vestingDuration, fundingTap, fundingTapBlocks,
+		fundingTaxPercentage, state)
 	batch := types.NewBatch(bond.Token, bond.BatchBlocks)
 	lastBatch := types.NewBatch(bond.Token, bond.BatchBlocks)
+	settledBatch := types.NewSettledBatch(1, lastBatch)
+	position := types.NewPosition(token, creator).AddBuy(
+		sdk.NewInt64Coin(token, 1), outcomePayment, outcomePayment)
+	limitOrder := types.NewLimitSellOrder(bond, creator, sdk.NewInt64Coin(token, 1),
+		sdk.NewDecCoins(sdk.NewInt64DecCoin("token1", 1)), 0)
+	vesting := types.NewVesting(token, creator).AddEntry(1, sdk.NewInt64Coin(token, 1))
+	contribution := types.NewOutcomePaymentContribution(token, creator).Add(outcomePayment)
 
 	kvPairs := tmkv.Pairs{
 		tmkv.Pair{Key: types.GetBondKey(token),
@@ -69,6 +86,16 @@ func TestDecodeStore(t *testing.T) {
 			Value: cdc.MustMarshalBinaryBare(batch)},
 		tmkv.Pair{Key: types.GetLastBatchKey(token),
 			Value: cdc.MustMarshalBinaryBare(lastBatch)},
+		tmkv.Pair{Key: types.GetBatchHistoryKey(token, settledBatch.Height),
+			Value: cdc.MustMarshalBinaryBare(settledBatch)},
+		tmkv.Pair{Key: types.GetPositionKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(position)},
+		tmkv.Pair{Key: types.GetLimitOrderKey(token, limitOrder.ID),
+			Value: cdc.MustMarshalBinaryBare(limitOrder)},
+		tmkv.Pair{Key: types.GetVestingKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(vesting)},
+		tmkv.Pair{Key: types.GetOutcomePaymentContributionKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(contribution)},
 		tmkv.Pair{Key: []byte{0x99}, Value: []byte{0x99}},
 	}
 
@@ -79,6 +106,11 @@ func TestDecodeStore(t *testing.T) {
 		{"bonds", fmt.Sprintf("%v\n%v", bond, bond)},
 		{"batches", fmt.Sprintf("%v\n%v", batch, batch)},
 		{"lastBatches", fmt.Sprintf("%v\n%v", lastBatch, lastBatch)},
+		{"batchesHistory", fmt.Sprintf("%v\n%v", settledBatch, settledBatch)},
+		{"positions", fmt.Sprintf("%v\n%v", position, position)},
+		{"limitOrders", fmt.Sprintf("%v\n%v", limitOrder, limitOrder)},
+		{"vestings", fmt.Sprintf("%v\n%v", vesting, vesting)},
+		{"outcomePaymentContributions", fmt.Sprintf("%v\n%v", contribution, contribution)},
 		{"other", ""},
 	}
 
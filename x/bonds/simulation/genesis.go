@@ -13,10 +13,12 @@ import (
 
 // Simulation parameters constants
 const (
-	InitialBonds            = "initial_bonds"
-	MaxBonds                = "max_bonds"
-	MaxNumberOfInitialBonds = 100
-	MaxNumberOfBonds        = 100000
+	InitialBonds             = "initial_bonds"
+	MaxBonds                 = "max_bonds"
+	BatchHistoryRetention    = "batch_history_retention"
+	MaxNumberOfInitialBonds  = 100
+	MaxNumberOfBonds         = 100000
+	MaxBatchHistoryRetention = 100
 )
 
 // GenInitialNumberOfBonds randomized initial number of bonds
@@ -29,6 +31,11 @@ func GenMaxNumberOfBonds(r *rand.Rand) (maxBonds uint64) {
 	return uint64(r.Int63n(MaxNumberOfBonds-MaxNumberOfInitialBonds) + MaxNumberOfInitialBonds + 1)
 }
 
+// GenBatchHistoryRetention randomized batch history retention
+func GenBatchHistoryRetention(r *rand.Rand) (retention uint64) {
+	return uint64(r.Int63n(MaxBatchHistoryRetention + 1))
+}
+
 // RandomizedGenState generates a random GenesisState
 func RandomizedGenState(simState *module.SimulationState) {
 	r := simState.Rand
@@ -44,6 +51,13 @@ func RandomizedGenState(simState *module.SimulationState) {
 		func(r *rand.Rand) { maxBonds = GenMaxNumberOfBonds(r) },
 	)
 
+	// Generate a random batch history retention
+	var batchHistoryRetention uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, BatchHistoryRetention, &batchHistoryRetention, simState.Rand,
+		func(r *rand.Rand) { batchHistoryRetention = GenBatchHistoryRetention(r) },
+	)
+
 	if initialBonds > maxBonds {
 		panic("initialBonds > maxBonds")
 	}
@@ -89,6 +103,7 @@ func RandomizedGenState(simState *module.SimulationState) {
 		// Max supply, allow sells, batch blocks
 		maxSupply := sdk.NewCoin(token, sdk.NewInt(int64(
 			simulation.RandIntBetween(r, 1000000, 1000000000))))
+		maxSupplyAllocation := getRandomMaxSupplyAllocation(r)
 		allowSells := getRandomAllowSellsValue(r)
 		batchBlocks := sdk.NewUint(uint64(
 			simulation.RandIntBetween(r, 1, 10)))
@@ -97,9 +112,10 @@ func RandomizedGenState(simState *module.SimulationState) {
 
 		bond := types.NewBond(token, name, desc, creator, functionType,
 			functionParameters, reserveTokens, txFeePercentage,
-			exitFeePercentage, feeAddress, maxSupply, blankOrderQuantityLimits,
+			exitFeePercentage, feeAddress, maxSupply, maxSupplyAllocation,
+			blankOrderQuantityLimits,
 			blankSanityRate, blankSanityMarginPercentage, allowSells, signers,
-			batchBlocks, outcomePayment, state)
+			batchBlocks, outcomePayment, 0, nil, 0, 0, nil, 0, sdk.ZeroDec(), state)
 		batch := types.NewBatch(bond.Token, bond.BatchBlocks)
 
 		bonds = append(bonds, bond)
@@ -110,8 +126,8 @@ func RandomizedGenState(simState *module.SimulationState) {
 		}
 	}
 
-	bondsGenesis := types.NewGenesisState(bonds, batches,
-		types.Params{ReservedBondTokens: defaultReserveTokens})
+	bondsGenesis := types.NewGenesisState(bonds, batches, nil, nil, nil, nil, nil,
+		types.NewParams(defaultReserveTokens, batchHistoryRetention))
 
 	fmt.Printf("Selected randomly generated bonds genesis state:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, bondsGenesis))
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(bondsGenesis)
@@ -17,17 +17,29 @@ import (
 
 // Simulation operation weights constants
 const (
-	OpWeightMsgCreateBond = "op_weight_msg_create_bond"
-	OpWeightMsgEditBond   = "op_weight_msg_edit_bond"
-	OpWeightMsgBuy        = "op_weight_msg_buy"
-	OpWeightMsgSell       = "op_weight_msg_sell"
-	OpWeightMsgSwap       = "op_weight_msg_swap"
-
-	DefaultWeightMsgCreateBond = 5
-	DefaultWeightMsgEditBond   = 5
-	DefaultWeightMsgBuy        = 100
-	DefaultWeightMsgSell       = 100
-	DefaultWeightMsgSwap       = 100
+	OpWeightMsgCreateBond      = "op_weight_msg_create_bond"
+	OpWeightMsgEditBond        = "op_weight_msg_edit_bond"
+	OpWeightMsgBuy             = "op_weight_msg_buy"
+	OpWeightMsgBuyWithReserve  = "op_weight_msg_buy_with_reserve"
+	OpWeightMsgSell            = "op_weight_msg_sell"
+	OpWeightMsgSwap            = "op_weight_msg_swap"
+	OpWeightMsgSwapRoute       = "op_weight_msg_swap_route"
+	OpWeightMsgAddLiquidity    = "op_weight_msg_add_liquidity"
+	OpWeightMsgRemoveLiquidity = "op_weight_msg_remove_liquidity"
+	OpWeightMsgLimitBuy        = "op_weight_msg_limit_buy"
+	OpWeightMsgLimitSell       = "op_weight_msg_limit_sell"
+
+	DefaultWeightMsgCreateBond      = 5
+	DefaultWeightMsgEditBond        = 5
+	DefaultWeightMsgBuy             = 100
+	DefaultWeightMsgBuyWithReserve  = 50
+	DefaultWeightMsgSell            = 100
+	DefaultWeightMsgSwap            = 100
+	DefaultWeightMsgSwapRoute       = 50
+	DefaultWeightMsgAddLiquidity    = 50
+	DefaultWeightMsgRemoveLiquidity = 50
+	DefaultWeightMsgLimitBuy        = 50
+	DefaultWeightMsgLimitSell       = 50
 )
 
 // WeightedOperations returns all the operations from the module with their respective weights
@@ -57,6 +69,13 @@ func WeightedOperations(
 		},
 	)
 
+	var weightMsgBuyWithReserve int
+	appParams.GetOrGenerate(cdc, OpWeightMsgBuyWithReserve, &weightMsgBuyWithReserve, nil,
+		func(_ *rand.Rand) {
+			weightMsgBuyWithReserve = DefaultWeightMsgBuyWithReserve
+		},
+	)
+
 	var weightMsgSell int
 	appParams.GetOrGenerate(cdc, OpWeightMsgSell, &weightMsgSell, nil,
 		func(_ *rand.Rand) {
@@ -71,6 +90,41 @@ func WeightedOperations(
 		},
 	)
 
+	var weightMsgSwapRoute int
+	appParams.GetOrGenerate(cdc, OpWeightMsgSwapRoute, &weightMsgSwapRoute, nil,
+		func(_ *rand.Rand) {
+			weightMsgSwapRoute = DefaultWeightMsgSwapRoute
+		},
+	)
+
+	var weightMsgAddLiquidity int
+	appParams.GetOrGenerate(cdc, OpWeightMsgAddLiquidity, &weightMsgAddLiquidity, nil,
+		func(_ *rand.Rand) {
+			weightMsgAddLiquidity = DefaultWeightMsgAddLiquidity
+		},
+	)
+
+	var weightMsgRemoveLiquidity int
+	appParams.GetOrGenerate(cdc, OpWeightMsgRemoveLiquidity, &weightMsgRemoveLiquidity, nil,
+		func(_ *rand.Rand) {
+			weightMsgRemoveLiquidity = DefaultWeightMsgRemoveLiquidity
+		},
+	)
+
+	var weightMsgLimitBuy int
+	appParams.GetOrGenerate(cdc, OpWeightMsgLimitBuy, &weightMsgLimitBuy, nil,
+		func(_ *rand.Rand) {
+			weightMsgLimitBuy = DefaultWeightMsgLimitBuy
+		},
+	)
+
+	var weightMsgLimitSell int
+	appParams.GetOrGenerate(cdc, OpWeightMsgLimitSell, &weightMsgLimitSell, nil,
+		func(_ *rand.Rand) {
+			weightMsgLimitSell = DefaultWeightMsgLimitSell
+		},
+	)
+
 	return simulation.WeightedOperations{
 		simulation.NewWeightedOperation(
 			weightMsgCreateBond,
@@ -84,6 +138,10 @@ func WeightedOperations(
 			weightMsgBuy,
 			SimulateMsgBuy(ak, k),
 		),
+		simulation.NewWeightedOperation(
+			weightMsgBuyWithReserve,
+			SimulateMsgBuyWithReserve(ak, k),
+		),
 		simulation.NewWeightedOperation(
 			weightMsgSell,
 			SimulateMsgSell(ak, k),
@@ -92,6 +150,26 @@ func WeightedOperations(
 			weightMsgSwap,
 			SimulateMsgSwap(ak, k),
 		),
+		simulation.NewWeightedOperation(
+			weightMsgSwapRoute,
+			SimulateMsgSwapRoute(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgAddLiquidity,
+			SimulateMsgAddLiquidity(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgRemoveLiquidity,
+			SimulateMsgRemoveLiquidity(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgLimitBuy,
+			SimulateMsgLimitBuy(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgLimitSell,
+			SimulateMsgLimitSell(ak, k),
+		),
 	}
 }
 
@@ -149,14 +227,22 @@ func SimulateMsgCreateBond(ak auth.AccountKeeper) simulation.Operation {
 		// Max supply, allow sells, batch blocks
 		maxSupply := sdk.NewCoin(token, sdk.NewInt(int64(
 			simulation.RandIntBetween(r, 1000000, 1000000000))))
+		maxSupplyAllocation := getRandomMaxSupplyAllocation(r)
 		allowSells := getRandomAllowSellsValue(r)
 		batchBlocks := sdk.NewUint(uint64(
 			simulation.RandIntBetween(r, 1, 10)))
+		hatchDeadline, minRaise := getRandomHatchDeadline(r, ctx.BlockHeight(),
+			functionType, functionParameters, reserveTokens)
+		vestingCliff, vestingDuration := getRandomVesting(r, functionType)
+		fundingTap, fundingTapBlocks := getRandomFundingTap(r, functionType, reserveTokens)
+		fundingTaxPercentage := getRandomFundingTaxPercentage(r, functionType)
 
 		msg := types.NewMsgCreateBond(token, name, desc, creator, functionType,
 			functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
-			feeAddress, maxSupply, blankOrderQuantityLimits, blankSanityRate,
-			blankSanityMarginPercentage, allowSells, signers, batchBlocks, blankOutcomePayment)
+			feeAddress, maxSupply, maxSupplyAllocation, blankOrderQuantityLimits, blankSanityRate,
+			blankSanityMarginPercentage, allowSells, signers, batchBlocks, blankOutcomePayment,
+			hatchDeadline, minRaise, vestingCliff, vestingDuration, fundingTap,
+			fundingTapBlocks, fundingTaxPercentage)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil,
 				fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
@@ -286,7 +372,8 @@ func getBuyIntoSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 		}
 	}
 
-	return types.NewMsgBuy(address, amountToBuy, maxPrices), nil, true
+	allowPartial := r.Intn(2) == 0
+	return types.NewMsgBuy(address, amountToBuy, maxPrices, allowPartial), nil, true
 }
 
 func getBuyIntoNonSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
@@ -351,7 +438,8 @@ func getBuyIntoNonSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 		return types.MsgBuy{}, err, true
 	}
 
-	return types.NewMsgBuy(address, toBuy, maxPrices), nil, true
+	allowPartial := r.Intn(2) == 0
+	return types.NewMsgBuy(address, toBuy, maxPrices, allowPartial), nil, true
 }
 
 func SimulateMsgBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
@@ -420,6 +508,84 @@ func SimulateMsgBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation
 	}
 }
 
+func SimulateMsgBuyWithReserve(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get random bond
+		token, ok := getRandomBondName(r)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		bond, found := k.GetBond(ctx, token)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Swapper function bonds need to be initialised using a MsgBuy
+		if bond.FunctionType == types.SwapperFunction && bond.CurrentSupply.IsZero() {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		} else if bond.State != types.HatchState && bond.State != types.OpenState {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get accounts that have ALL the reserve tokens
+		var filteredAccs []simulation.Account
+		dummyNonZeroReserve := getDummyNonZeroReserve(bond.ReserveTokens)
+		for _, a := range accs {
+			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
+			if dummyNonZeroReserve.DenomsSubsetOf(coins) {
+				filteredAccs = append(filteredAccs, a)
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := account.SpendableCoins(ctx.BlockTime())
+
+		// Come up with a budget based on what is spendable
+		var budget sdk.Coins
+		for _, rt := range bond.ReserveTokens {
+			budgetInt, err := simulation.RandPositiveInt(r, spendable.AmountOf(rt))
+			if err != nil {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+			budget = budget.Add(sdk.NewCoin(rt, budgetInt))
+		}
+
+		// Skip if budget exceeds an order quantity limit
+		if bond.AnyOrderQuantityLimitsExceeded(budget) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgBuyWithReserve(account.GetAddress(), bond.Token, budget)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
 func SimulateMsgSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
@@ -436,11 +602,10 @@ func SimulateMsgSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 			return simulation.NoOpMsg(types.ModuleName), nil, nil
 		}
 
-		// Get accounts that have the token to be sold
+		// Get accounts that have the token to be sold (and that has vested)
 		var filteredAccs []simulation.Account
 		for _, a := range accs {
-			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
-			if coins.AmountOf(bond.Token).IsPositive() {
+			if getSellableAmount(ctx, ak, k, bond, a.Address).IsPositive() {
 				filteredAccs = append(filteredAccs, a)
 			}
 		}
@@ -452,7 +617,7 @@ func SimulateMsgSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
 		address := simAccount.Address
 		account := ak.GetAccount(ctx, address)
-		amount := account.SpendableCoins(ctx.BlockTime()).AmountOf(bond.Token)
+		amount := getSellableAmount(ctx, ak, k, bond, address)
 
 		toSellInt, err := simulation.RandPositiveInt(r, amount)
 		if err != nil {
@@ -460,7 +625,7 @@ func SimulateMsgSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		}
 		amountToSell := sdk.NewCoin(bond.Token, toSellInt)
 
-		msg := types.NewMsgSell(address, amountToSell)
+		msg := types.NewMsgSell(address, amountToSell, nil)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
@@ -534,7 +699,421 @@ func SimulateMsgSwap(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		}
 		amountToSwap := sdk.NewCoin(fromToken, toSwapInt)
 
-		msg := types.NewMsgSwap(address, token, amountToSwap, toToken)
+		msg := types.NewMsgSwap(address, token, amountToSwap, toToken, sdk.NewCoin(toToken, sdk.ZeroInt()))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+func SimulateMsgSwapRoute(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get reserve tokens of swapper function bonds with some reserve
+		var reserveTokens []string
+		for _, sbToken := range swapperBonds {
+			if !k.GetReserveBalances(ctx, sbToken).IsZero() {
+				reserveTokens = append(reserveTokens, k.MustGetBond(ctx, sbToken).ReserveTokens...)
+			}
+		}
+
+		if len(reserveTokens) < 2 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get random from and to tokens
+		fromToken := reserveTokens[simulation.RandIntBetween(r, 0, len(reserveTokens))]
+		toToken := reserveTokens[simulation.RandIntBetween(r, 0, len(reserveTokens))]
+		if fromToken == toToken {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get accounts that have the token to be swapped
+		var filteredAccs []simulation.Account
+		for _, a := range accs {
+			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
+			if coins.AmountOf(fromToken).IsPositive() {
+				filteredAccs = append(filteredAccs, a)
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		address := simAccount.Address
+		account := ak.GetAccount(ctx, address)
+		fromBalance := account.SpendableCoins(ctx.BlockTime()).AmountOf(fromToken)
+
+		toSwapInt, err := simulation.RandPositiveInt(r, fromBalance)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+		amountToSwap := sdk.NewCoin(fromToken, toSwapInt)
+
+		// Swap through the best route, if there is one
+		route, _, err := k.FindBestSwapRoute(ctx, amountToSwap, toToken)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgSwapRoute(address, route, amountToSwap, sdk.NewCoin(toToken, sdk.ZeroInt()))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+// getRandomLimitPricesPT returns prices per token that are within 50% of the
+// bond's current prices per token, so that some limit orders get filled and
+// some rest in the limit order book
+func getRandomLimitPricesPT(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
+	bond types.Bond) (pricesPT sdk.DecCoins, ok bool) {
+	currentPricesPT, err := bond.GetCurrentPricesPT(k.GetReserveBalances(ctx, bond.Token))
+	if err != nil {
+		return nil, false
+	}
+
+	factor := sdk.NewDecWithPrec(int64(simulation.RandIntBetween(r, 50, 150)), 2)
+	pricesPT = currentPricesPT.MulDec(factor)
+	if len(pricesPT) != len(bond.ReserveTokens) || !pricesPT.IsAllPositive() {
+		return nil, false
+	}
+	return pricesPT, true
+}
+
+func getRandomExpiryHeight(r *rand.Rand, ctx sdk.Context) int64 {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return ctx.BlockHeight() + int64(simulation.RandIntBetween(r, 1, 50))
+}
+
+func SimulateMsgAddLiquidity(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get initialised OPEN swapper function bonds
+		var filteredBonds []string
+		for _, sbToken := range swapperBonds {
+			bond := k.MustGetBond(ctx, sbToken)
+			if !bond.CurrentSupply.IsZero() && bond.State == types.OpenState {
+				filteredBonds = append(filteredBonds, sbToken)
+			}
+		}
+
+		if len(filteredBonds) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get random bond
+		token := filteredBonds[simulation.RandIntBetween(r, 0, len(filteredBonds))]
+		bond := k.MustGetBond(ctx, token)
+
+		// Get accounts that have ANY of the reserve tokens
+		var filteredAccs []simulation.Account
+		for _, a := range accs {
+			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
+			for _, rt := range bond.ReserveTokens {
+				if coins.AmountOf(rt).IsPositive() {
+					filteredAccs = append(filteredAccs, a)
+					break
+				}
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		address := simAccount.Address
+		account := ak.GetAccount(ctx, address)
+		spendable := account.SpendableCoins(ctx.BlockTime())
+
+		// Deposit a random amount of each reserve token held
+		deposit := sdk.NewCoins()
+		for _, rt := range bond.ReserveTokens {
+			if spendable.AmountOf(rt).IsPositive() {
+				amount, err := simulation.RandPositiveInt(r, spendable.AmountOf(rt))
+				if err != nil {
+					return simulation.NoOpMsg(types.ModuleName), nil, err
+				}
+				deposit = deposit.Add(sdk.NewCoin(rt, amount))
+			}
+		}
+
+		if bond.AnyOrderQuantityLimitsExceeded(deposit) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgAddLiquidity(address, token, deposit, sdk.NewCoin(token, sdk.ZeroInt()))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+func SimulateMsgRemoveLiquidity(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get initialised OPEN swapper function bonds that allow sells
+		var filteredBonds []string
+		for _, sbToken := range swapperBonds {
+			bond := k.MustGetBond(ctx, sbToken)
+			if !bond.CurrentSupply.IsZero() && bond.State == types.OpenState && bond.AllowSells {
+				filteredBonds = append(filteredBonds, sbToken)
+			}
+		}
+
+		if len(filteredBonds) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get random bond
+		token := filteredBonds[simulation.RandIntBetween(r, 0, len(filteredBonds))]
+		bond := k.MustGetBond(ctx, token)
+
+		// Get accounts that have the token to be removed
+		var filteredAccs []simulation.Account
+		for _, a := range accs {
+			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
+			if coins.AmountOf(bond.Token).IsPositive() {
+				filteredAccs = append(filteredAccs, a)
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		address := simAccount.Address
+		account := ak.GetAccount(ctx, address)
+		amount := account.SpendableCoins(ctx.BlockTime()).AmountOf(bond.Token)
+
+		toRemoveInt, err := simulation.RandPositiveInt(r, amount)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+		amountToRemove := sdk.NewCoin(bond.Token, toRemoveInt)
+
+		if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{amountToRemove}) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		toToken := bond.ReserveTokens[simulation.RandIntBetween(r, 0, 2)]
+
+		msg := types.NewMsgRemoveLiquidity(address, amountToRemove, toToken, sdk.NewCoin(toToken, sdk.ZeroInt()))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+func SimulateMsgLimitBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get random bond
+		token, ok := getRandomBondName(r)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		bond, found := k.GetBond(ctx, token)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Swapper function bonds need to be initialised using a MsgBuy
+		if bond.FunctionType == types.SwapperFunction && bond.CurrentSupply.IsZero() {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		} else if bond.State != types.HatchState && bond.State != types.OpenState {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get accounts that have ALL the reserve tokens
+		var filteredAccs []simulation.Account
+		dummyNonZeroReserve := getDummyNonZeroReserve(bond.ReserveTokens)
+		for _, a := range accs {
+			coins := ak.GetAccount(ctx, a.Address).SpendableCoins(ctx.BlockTime())
+			if dummyNonZeroReserve.DenomsSubsetOf(coins) {
+				filteredAccs = append(filteredAccs, a)
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := account.SpendableCoins(ctx.BlockTime())
+
+		// Come up with max prices per token around the current prices
+		maxPricesPT, ok := getRandomLimitPricesPT(r, ctx, k, bond)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Pick a random amount and check that the max prices (which are held
+		// in escrow) are affordable and in the reserve denominations
+		toBuy := sdk.NewInt64Coin(bond.Token, int64(simulation.RandIntBetween(r, 1, 100)))
+		expiryHeight := getRandomExpiryHeight(r, ctx)
+		order := types.NewLimitBuyOrder(bond, account.GetAddress(), toBuy, maxPricesPT, expiryHeight)
+		if !bond.ReserveDenomsEqualTo(order.MaxPrices) ||
+			!spendable.IsAllGTE(order.MaxPrices) ||
+			bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{toBuy}) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgLimitBuy(account.GetAddress(), toBuy, maxPricesPT, expiryHeight)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			sdk.Coins{},
+			gas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+func SimulateMsgLimitSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		// Get random bond
+		token, ok := getRandomBondName(r)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		bond, found := k.GetBond(ctx, token)
+		if !found || !bond.AllowSells ||
+			bond.CurrentSupply.IsZero() ||
+			bond.State != types.OpenState {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// Get accounts that have the token to be sold (and that has vested)
+		var filteredAccs []simulation.Account
+		for _, a := range accs {
+			if getSellableAmount(ctx, ak, k, bond, a.Address).IsPositive() {
+				filteredAccs = append(filteredAccs, a)
+			}
+		}
+
+		if len(filteredAccs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, filteredAccs)
+		address := simAccount.Address
+		account := ak.GetAccount(ctx, address)
+		amount := getSellableAmount(ctx, ak, k, bond, address)
+
+		toSellInt, err := simulation.RandPositiveInt(r, amount)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+		amountToSell := sdk.NewCoin(bond.Token, toSellInt)
+
+		// Come up with min prices per token around the current prices, and
+		// check that the resulting returns are in the reserve denominations
+		minPricesPT, ok := getRandomLimitPricesPT(r, ctx, k, bond)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		reserveReturns := types.MultiplyDecCoinsByInt(minPricesPT, toSellInt)
+		if !bond.ReserveDenomsEqualTo(types.RoundReservePrices(reserveReturns)) ||
+			bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{amountToSell}) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgLimitSell(address, amountToSell, minPricesPT, getRandomExpiryHeight(r, ctx))
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
@@ -2,7 +2,9 @@ package simulation
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/ixoworld/bonds/x/bonds/internal/keeper"
 	"github.com/ixoworld/bonds/x/bonds/internal/types"
 	"math/rand"
 	"strconv"
@@ -126,6 +128,84 @@ func getRandomAllowSellsValue(r *rand.Rand) bool {
 	}
 }
 
+func getRandomMaxSupplyAllocation(r *rand.Rand) string {
+	allocations := []string{types.NoAllocation,
+		types.ProRataAllocation, types.FirstComeAllocation}
+	return allocations[r.Intn(len(allocations))]
+}
+
+// getRandomHatchDeadline returns, for half of the augmented bonds, a random
+// hatch deadline that is up to 100 blocks from the specified height, and a
+// random min raise of up to d0 (for half of these). Otherwise, zero and no
+// min raise are returned.
+func getRandomHatchDeadline(r *rand.Rand, height int64, functionType string,
+	functionParameters types.FunctionParams, reserveTokens []string) (int64, sdk.Coins) {
+	if functionType != types.AugmentedFunction || r.Intn(2) == 0 {
+		return 0, nil
+	}
+	hatchDeadline := height + int64(simulation.RandIntBetween(r, 1, 101))
+
+	minRaise := sdk.NewCoins()
+	if r.Intn(2) == 0 {
+		d0 := functionParameters.AsMap()["d0"].TruncateInt64()
+		for _, rt := range reserveTokens {
+			minRaise = minRaise.Add(sdk.NewInt64Coin(rt,
+				int64(simulation.RandIntBetween(r, 1, int(d0)+1))))
+		}
+	}
+	return hatchDeadline, minRaise
+}
+
+// getRandomVesting returns, for half of the augmented bonds, a random vesting
+// cliff and duration of up to 50 blocks each. Otherwise, zeroes are returned.
+func getRandomVesting(r *rand.Rand, functionType string) (int64, int64) {
+	if functionType != types.AugmentedFunction || r.Intn(2) == 0 {
+		return 0, 0
+	}
+	vestingCliff := int64(simulation.RandIntBetween(r, 0, 51))
+	vestingDuration := int64(simulation.RandIntBetween(r, 0, 51))
+	return vestingCliff, vestingDuration
+}
+
+// getRandomFundingTap returns, for half of the augmented bonds, a random
+// funding tap and a random number of funding tap blocks of up to 50 blocks.
+// Otherwise, no funding tap is returned, so funding goes to the fee address.
+func getRandomFundingTap(r *rand.Rand, functionType string,
+	reserveTokens []string) (sdk.Coins, int64) {
+	if functionType != types.AugmentedFunction || r.Intn(2) == 0 {
+		return nil, 0
+	}
+	var fundingTap sdk.Coins
+	for _, rt := range reserveTokens {
+		fundingTap = fundingTap.Add(sdk.NewInt64Coin(rt,
+			int64(simulation.RandIntBetween(r, 1, 1000000))))
+	}
+	fundingTapBlocks := int64(simulation.RandIntBetween(r, 1, 51))
+	return fundingTap, fundingTapBlocks
+}
+
+// getRandomFundingTaxPercentage returns, for half of the augmented bonds, a
+// random funding tax percentage of up to 10%. Otherwise, no tax is charged.
+func getRandomFundingTaxPercentage(r *rand.Rand, functionType string) sdk.Dec {
+	if functionType != types.AugmentedFunction || r.Intn(2) == 0 {
+		return sdk.ZeroDec()
+	}
+	return simulation.RandomDecAmount(r, sdk.NewDec(10))
+}
+
+// getSellableAmount returns the amount of bond tokens that the address is able
+// to sell, i.e. its spendable bond tokens plus any that have vested and are
+// still held in the vesting escrow (these are withdrawn automatically on sell).
+func getSellableAmount(ctx sdk.Context, ak auth.AccountKeeper, k keeper.Keeper,
+	bond types.Bond, address sdk.AccAddress) sdk.Int {
+	coins := ak.GetAccount(ctx, address).SpendableCoins(ctx.BlockTime())
+	amount := coins.AmountOf(bond.Token)
+	if bond.HasVesting() {
+		amount = amount.Add(k.GetWithdrawableVested(ctx, bond.Token, address).Amount)
+	}
+	return amount
+}
+
 func getInitialBondState(functionType string) string {
 	switch functionType {
 	case types.AugmentedFunction: